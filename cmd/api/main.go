@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"pokemon-battle/internal/business"
 	"pokemon-battle/internal/database"
 	"pokemon-battle/internal/server"
 	"strconv"
@@ -41,26 +42,41 @@ func gracefulShutdown(fiberServer *server.FiberServer, done chan bool) {
 
 func main() {
 
-	server := server.New()
+	fiberServer := server.New()
 
 	// Initialize the database service
 	srv := database.New()
 
-	server.RegisterFiberRoutes(database.NewPokemonService(srv), database.NewBattleService(srv))
+	battles := database.NewBattleService(srv).WithRatings(business.RateBattle).WithExperience(business.GrantExperience)
+
+	fiberServer.RegisterFiberRoutes(server.Services{
+		Pokemons:       database.NewPokemonService(srv),
+		Battles:        battles,
+		Moves:          database.NewMoveService(srv),
+		PokemonMoves:   database.NewPokemonMoveService(srv),
+		Matchups:       database.NewMatchupService(srv),
+		Ratings:        database.NewRatingService(srv),
+		Tournaments:    database.NewTournamentService(srv),
+		BattleSessions: database.NewBattleSessionService(srv).WithBattles(battles),
+		LevelUps:       database.NewLevelUpService(srv),
+		Evolutions:     database.NewEvolutionService(srv),
+		Items:          database.NewItemService(srv),
+		HeldItems:      database.NewHeldItemService(srv),
+	})
 
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 
 	go func() {
 		port, _ := strconv.Atoi(os.Getenv("PORT"))
-		err := server.Listen(fmt.Sprintf(":%d", port))
+		err := fiberServer.Listen(fmt.Sprintf(":%d", port))
 		if err != nil {
 			panic(fmt.Sprintf("http server error: %s", err))
 		}
 	}()
 
 	// Run graceful shutdown in a separate goroutine
-	go gracefulShutdown(server, done)
+	go gracefulShutdown(fiberServer, done)
 
 	// Wait for the graceful shutdown to complete
 	<-done
@@ -0,0 +1,240 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+
+	"pokemon-battle/internal/models"
+)
+
+// ErrUnknownAbility se devuelve cuando no existe una habilidad con el nombre indicado.
+var ErrUnknownAbility = errors.New("unknown ability")
+
+// Attack es un ataque en curso, que los ganchos de las habilidades del atacante
+// y del defensor pueden modificar.
+type Attack struct {
+	Attacker *Combatant  // Pokémon que ataca
+	Defender *Combatant  // Pokémon que recibe el ataque
+	Move     models.Move // Movimiento del ataque, vacío si el ataque no usa ningún movimiento
+	HasMove  bool        // Si el ataque usa un movimiento
+
+	// Type es el tipo del ataque: el de su movimiento o, si no usa ninguno,
+	// el más efectivo de los tipos del atacante contra el defensor.
+	Type string
+
+	// AttackStat y DefenseStat son el ataque y la defensa con los que se resuelve
+	// el ataque, a los que se suman las tiradas. Empiezan con las estadísticas de
+	// los Pokémon y los ganchos BeforeAttack pueden cambiarlos.
+	AttackStat  int
+	DefenseStat int
+
+	// Immune anula el ataque, que no causa daño ni efectos. Lo activan los ganchos BeforeAttack.
+	Immune bool
+
+	// Damage es el daño causado por el ataque, que reciben los ganchos AfterDamage.
+	Damage int
+}
+
+// Ability es una habilidad o rasgo pasivo de un Pokémon, que interviene en la
+// batalla a través de sus ganchos. Cada gancho recibe el Pokémon con la habilidad
+// y devuelve si la habilidad se ha activado, para registrarlo en la batalla.
+// Las habilidades no deben tirar dados, de manera que la semilla siga
+// determinando la batalla completa.
+type Ability interface {
+	// Name es el nombre de la habilidad, con el que se asocia a los Pokémon.
+	Name() string
+
+	// BeforeAttack se llama antes de resolver un ataque en el que participa
+	// el Pokémon, como atacante o como defensor.
+	BeforeAttack(holder *Combatant, attack *Attack) bool
+
+	// AfterDamage se llama cuando un ataque en el que participa el Pokémon,
+	// como atacante o como defensor, causa daño.
+	AfterDamage(holder *Combatant, attack *Attack) bool
+
+	// OnFaint se llama cuando un Pokémon se queda sin HP, tanto para el Pokémon
+	// debilitado como para el Pokémon que lo ha debilitado con su ataque.
+	OnFaint(holder *Combatant, fainted *Combatant) bool
+
+	// EndOfTurn se llama al final de cada turno, si el Pokémon sigue combatiendo.
+	EndOfTurn(holder *Combatant) bool
+}
+
+// BaseAbility implementa todos los ganchos sin hacer nada. Las habilidades
+// la incrustan para implementar solo los ganchos que necesitan.
+type BaseAbility struct{}
+
+func (BaseAbility) BeforeAttack(holder *Combatant, attack *Attack) bool { return false }
+func (BaseAbility) AfterDamage(holder *Combatant, attack *Attack) bool  { return false }
+func (BaseAbility) OnFaint(holder *Combatant, fainted *Combatant) bool  { return false }
+func (BaseAbility) EndOfTurn(holder *Combatant) bool                    { return false }
+
+// Intimidate reduce a dos tercios el ataque de los rivales que atacan al Pokémon.
+type Intimidate struct{ BaseAbility }
+
+func (Intimidate) Name() string {
+	return "intimidate"
+}
+
+func (Intimidate) BeforeAttack(holder *Combatant, attack *Attack) bool {
+	if attack.Defender != holder {
+		return false
+	}
+	attack.AttackStat = attack.AttackStat * 2 / 3
+	return true
+}
+
+// Sturdy deja al Pokémon con 1 HP cuando un ataque lo debilitaría con el HP completo.
+type Sturdy struct{ BaseAbility }
+
+func (Sturdy) Name() string {
+	return "sturdy"
+}
+
+func (Sturdy) AfterDamage(holder *Combatant, attack *Attack) bool {
+	if attack.Defender != holder || holder.HP > 0 || holder.HP+attack.Damage < holder.MaxHP() {
+		return false
+	}
+	holder.HP = 1
+	return true
+}
+
+// Levitate hace al Pokémon inmune a los ataques de tipo tierra, también a los
+// de los atacantes de tipo tierra que no usan ningún movimiento.
+type Levitate struct{ BaseAbility }
+
+func (Levitate) Name() string {
+	return "levitate"
+}
+
+func (Levitate) BeforeAttack(holder *Combatant, attack *Attack) bool {
+	if attack.Defender != holder || normalizeType(attack.Type) != "ground" {
+		return false
+	}
+	attack.Immune = true
+	return true
+}
+
+// Moxie sube el ataque del Pokémon un 50% cada vez que debilita a un rival.
+type Moxie struct{ BaseAbility }
+
+func (Moxie) Name() string {
+	return "moxie"
+}
+
+func (Moxie) OnFaint(holder *Combatant, fainted *Combatant) bool {
+	if fainted == holder {
+		return false
+	}
+	holder.Attack += holder.Attack / 2
+	return true
+}
+
+// SpeedBoost sube la velocidad del Pokémon un 10% al final de cada turno.
+type SpeedBoost struct{ BaseAbility }
+
+func (SpeedBoost) Name() string {
+	return "speed-boost"
+}
+
+func (SpeedBoost) EndOfTurn(holder *Combatant) bool {
+	holder.Speed += max(holder.Speed/10, 1)
+	return true
+}
+
+// abilities son las habilidades disponibles, por nombre.
+var abilities = map[string]Ability{
+	Intimidate{}.Name(): Intimidate{},
+	Sturdy{}.Name():     Sturdy{},
+	Levitate{}.Name():   Levitate{},
+	Moxie{}.Name():      Moxie{},
+	SpeedBoost{}.Name(): SpeedBoost{},
+}
+
+// AbilityByName devuelve la habilidad con el nombre indicado.
+// Con un nombre vacío devuelve nil, es decir, un Pokémon sin habilidad.
+func AbilityByName(name string) (Ability, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	ability, ok := abilities[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAbility, name)
+	}
+	return ability, nil
+}
+
+// ability devuelve la habilidad de un Pokémon: una de las añadidas a la batalla
+// con WithAbilities o una de las disponibles, o nil si no tiene ninguna conocida.
+func (f *fight) ability(c *Combatant) Ability {
+	if c.Ability == "" {
+		return nil
+	}
+	if ability, ok := f.cfg.abilities[c.Ability]; ok {
+		return ability
+	}
+	return abilities[c.Ability]
+}
+
+// activate registra que la habilidad de un Pokémon se ha activado.
+func (f *fight) activate(holder *Combatant, ability Ability, target *Combatant) {
+	event := models.BattleEvent{
+		Type:      models.EventAbility,
+		PokemonID: holder.ID,
+		Ability:   ability.Name(),
+		HP:        holder.HP,
+	}
+	if target != nil && target != holder {
+		event.TargetID = target.ID
+		event.TargetHP = target.HP
+	}
+	f.record(event)
+}
+
+// beforeAttack llama a los ganchos BeforeAttack del atacante y del defensor, en ese orden.
+func (f *fight) beforeAttack(attack *Attack) {
+	for _, holder := range []*Combatant{attack.Attacker, attack.Defender} {
+		if ability := f.ability(holder); ability != nil && ability.BeforeAttack(holder, attack) {
+			f.activate(holder, ability, opponent(holder, attack))
+		}
+	}
+}
+
+// afterDamage llama a los ganchos AfterDamage del atacante y del defensor, en ese orden.
+func (f *fight) afterDamage(attack *Attack) {
+	for _, holder := range []*Combatant{attack.Attacker, attack.Defender} {
+		if ability := f.ability(holder); ability != nil && ability.AfterDamage(holder, attack) {
+			f.activate(holder, ability, opponent(holder, attack))
+		}
+	}
+}
+
+// fainted llama a los ganchos OnFaint del Pokémon debilitado y, si se ha
+// debilitado por un ataque, del atacante.
+func (f *fight) fainted(c *Combatant, attacker *Combatant) {
+	holders := []*Combatant{c}
+	if attacker != nil && attacker != c {
+		holders = append(holders, attacker)
+	}
+	for _, holder := range holders {
+		if ability := f.ability(holder); ability != nil && ability.OnFaint(holder, c) {
+			f.activate(holder, ability, c)
+		}
+	}
+}
+
+// endOfTurn llama al gancho EndOfTurn de un Pokémon que sigue combatiendo.
+func (f *fight) endOfTurn(c *Combatant) {
+	if ability := f.ability(c); ability != nil && ability.EndOfTurn(c) {
+		f.activate(c, ability, nil)
+	}
+}
+
+// opponent devuelve el rival del Pokémon en un ataque.
+func opponent(holder *Combatant, attack *Attack) *Combatant {
+	if holder == attack.Attacker {
+		return attack.Defender
+	}
+	return attack.Attacker
+}
@@ -0,0 +1,187 @@
+package business_test
+
+import (
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+// abilityEvents devuelve los eventos de la habilidad activada por el Pokémon.
+func abilityEvents(battle models.Battle, pokemonID int, ability string) []models.BattleEvent {
+	var events []models.BattleEvent
+	for _, event := range battle.Log {
+		if event.Type == models.EventAbility && event.PokemonID == pokemonID && event.Ability == ability {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// damageTo devuelve el daño total causado por los ataques al Pokémon.
+func damageTo(battle models.Battle, pokemonID int) int {
+	damage := 0
+	for _, event := range battle.Log {
+		if event.Type == models.EventAttack && event.TargetID == pokemonID {
+			damage += event.Damage
+		}
+	}
+	return damage
+}
+
+// wall es un Pokémon que no puede causar ni recibir daño, para alargar las batallas.
+var wall = models.Pokemon{ID: 10, Name: "Shuckle", Type: "Normal", HP: 1000, Attack: 0, Defense: 1000}
+
+func TestAbilityByName(t *testing.T) {
+	ability, err := business.AbilityByName("levitate")
+	if err != nil || ability.Name() != "levitate" {
+		t.Fatalf("expected the levitate ability, got %v, %v", ability, err)
+	}
+
+	ability, err = business.AbilityByName("")
+	if err != nil || ability != nil {
+		t.Fatalf("expected no ability, got %v, %v", ability, err)
+	}
+
+	if _, err := business.AbilityByName("cloud-nine"); !errors.Is(err, business.ErrUnknownAbility) {
+		t.Fatalf("expected ErrUnknownAbility, got %v", err)
+	}
+}
+
+func TestAbilities(t *testing.T) {
+	hitter := models.Pokemon{ID: 1, Name: "Machamp", Type: "Normal", HP: 100, Attack: 1000, Defense: 1000, Speed: 100}
+
+	t.Run("sturdy", func(t *testing.T) {
+		sturdy := models.Pokemon{ID: 2, Name: "Geodude", Type: "Normal", HP: 10, Defense: 0, Ability: "sturdy"}
+
+		battle := business.Fight(6, hitter, sturdy, business.WithSeed(42))
+		if battle.WinnerID != hitter.ID || battle.Turns != 2 {
+			t.Fatalf("expected the sturdy pokemon to survive the first turn, got winner %d in %d turns", battle.WinnerID, battle.Turns)
+		}
+
+		events := abilityEvents(battle, sturdy.ID, "sturdy")
+		if len(events) != 1 || events[0].HP != 1 || events[0].Turn != 1 {
+			t.Fatalf("expected sturdy to leave the pokemon with 1 HP in the first turn, got %+v", events)
+		}
+	})
+
+	t.Run("levitate", func(t *testing.T) {
+		earthquake := models.Move{ID: 1, Name: "Earthquake", Type: "Ground", Power: 100, Accuracy: 100, Category: models.MoveCategoryPhysical}
+		digger := hitter
+		digger.Moves = []models.Move{earthquake}
+		levitating := wall
+		levitating.Defense = 0
+		levitating.Ability = "levitate"
+
+		battle := business.Fight(6, digger, levitating, business.WithSeed(42), business.WithMaxTurns(3))
+		if damage := damageTo(battle, levitating.ID); damage != 0 {
+			t.Fatalf("expected the ground moves not to damage the levitating pokemon, got %d damage", damage)
+		}
+		if events := abilityEvents(battle, levitating.ID, "levitate"); len(events) != 3 {
+			t.Fatalf("expected levitate to activate once per turn, got %d times", len(events))
+		}
+
+		// sin movimientos, el Pokémon de tipo tierra ataca con su tipo
+		sandshrew := hitter
+		sandshrew.Type = "Ground"
+		sandshrew.Moves = nil
+		battle = business.Fight(6, sandshrew, levitating, business.WithSeed(42), business.WithMaxTurns(3))
+		if damage := damageTo(battle, levitating.ID); damage != 0 {
+			t.Fatalf("expected the ground pokemon not to damage the levitating pokemon, got %d damage", damage)
+		}
+		if events := abilityEvents(battle, levitating.ID, "levitate"); len(events) != 3 {
+			t.Fatalf("expected levitate to activate once per turn, got %d times", len(events))
+		}
+	})
+
+	t.Run("intimidate", func(t *testing.T) {
+		attacker := models.Pokemon{ID: 1, Name: "Rattata", Type: "Normal", HP: 100, Attack: 60, Defense: 1000, Speed: 100}
+		target := wall
+		target.Defense = 10
+
+		plain := business.Fight(6, attacker, target, business.WithSeed(42), business.WithMaxTurns(5))
+		target.Ability = "intimidate"
+		intimidated := business.Fight(6, attacker, target, business.WithSeed(42), business.WithMaxTurns(5))
+
+		if damageTo(intimidated, target.ID) >= damageTo(plain, target.ID) {
+			t.Fatalf("expected intimidate to reduce the damage, got %d with it and %d without it", damageTo(intimidated, target.ID), damageTo(plain, target.ID))
+		}
+		events := abilityEvents(intimidated, target.ID, "intimidate")
+		if len(events) != 5 || events[0].TargetID != attacker.ID {
+			t.Fatalf("expected intimidate to activate against the attacker every turn, got %+v", events)
+		}
+	})
+
+	t.Run("moxie", func(t *testing.T) {
+		moxie := hitter
+		moxie.Ability = "moxie"
+		var foes []models.Pokemon
+		for id := 2; id <= 4; id++ {
+			foes = append(foes, models.Pokemon{ID: id, Name: "Magikarp", Type: "Water", HP: 1})
+		}
+
+		battle := business.FightTeams(6, []models.Pokemon{moxie}, foes, business.WithSeed(42))
+		if battle.WinnerID != moxie.ID {
+			t.Fatalf("expected the moxie pokemon to win, got %d", battle.WinnerID)
+		}
+		if events := abilityEvents(battle, moxie.ID, "moxie"); len(events) != len(foes) {
+			t.Fatalf("expected moxie to activate once per fainted foe, got %d times", len(events))
+		}
+	})
+
+	t.Run("speed-boost", func(t *testing.T) {
+		boosted := wall
+		boosted.ID = 11
+		boosted.Ability = "speed-boost"
+
+		battle := business.Fight(6, wall, boosted, business.WithSeed(42), business.WithMaxTurns(4))
+		events := abilityEvents(battle, boosted.ID, "speed-boost")
+		if len(events) != 4 {
+			t.Fatalf("expected speed boost to activate at the end of every turn, got %d times", len(events))
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		unknown := wall
+		unknown.ID = 11
+		unknown.Ability = "cloud-nine"
+
+		battle := business.Fight(6, wall, unknown, business.WithSeed(42), business.WithMaxTurns(2))
+		for _, event := range battle.Log {
+			if event.Type == models.EventAbility {
+				t.Fatalf("expected unknown abilities to be ignored, got %+v", event)
+			}
+		}
+	})
+}
+
+// countingAbility es una habilidad de prueba que cuenta los finales de turno.
+type countingAbility struct {
+	business.BaseAbility
+	turns *int
+}
+
+func (countingAbility) Name() string {
+	return "counting"
+}
+
+func (a countingAbility) EndOfTurn(holder *business.Combatant) bool {
+	*a.turns++
+	return false
+}
+
+func TestWithAbilities(t *testing.T) {
+	counting := wall
+	counting.ID = 11
+	counting.Ability = "counting"
+
+	turns := 0
+	battle := business.Fight(6, wall, counting, business.WithSeed(42), business.WithMaxTurns(3), business.WithAbilities(countingAbility{turns: &turns}))
+	if turns != 3 {
+		t.Fatalf("expected the custom ability to be called every turn, got %d calls", turns)
+	}
+	if events := abilityEvents(battle, counting.ID, "counting"); len(events) != 0 {
+		t.Fatalf("expected no events for an ability that doesn't activate, got %+v", events)
+	}
+}
@@ -1,81 +1,480 @@
 package business
 
 import (
+	"math"
+
 	"pokemon-battle/internal/models"
 )
 
-const initiativeDiceSides = 6
+// DefaultInitiativeDiceSides es el número de caras por defecto del dado
+// que se suma a la velocidad para decidir quién ataca primero.
+const DefaultInitiativeDiceSides = 6
 
-func Fight(diceSides int, pokemon1 models.Pokemon, pokemon2 models.Pokemon) models.Battle {
-	// Create a battle record
-	battle := models.Battle{
-		Pokemon1ID: pokemon1.ID,
-		Pokemon2ID: pokemon2.ID,
+// MinInitiativeDiceSides es el número mínimo de caras del dado de iniciativa.
+// Con un dado de una cara los empates de velocidad no se podrían deshacer.
+const MinInitiativeDiceSides = 2
+
+// DefaultMaxTurns es el número máximo de turnos por defecto de una batalla,
+// tras los que la batalla termina en empate.
+const DefaultMaxTurns = 100
+
+// criticalMultiplier es el multiplicador de daño de los golpes críticos,
+// que se producen cuando explota el dado de ataque.
+const criticalMultiplier = 1.5
+
+// Combatant es un Pokémon durante una batalla, junto con su estado.
+// El Pokémon es una copia, por lo que la batalla no modifica el original.
+type Combatant struct {
+	models.Pokemon
+
+	// maxHP es el HP del Pokémon al empezar la batalla
+	maxHP int
+
+	// status es el problema de estado que sufre el Pokémon, si sufre alguno,
+	// y statusTurns los turnos que le quedan dormido
+	status      string
+	statusTurns int
+
+	// criticals es el número de golpes críticos causados por el Pokémon
+	criticals int
+
+	// item es el objeto que lleva el Pokémon, nil si no lleva ninguno o ya lo
+	// ha gastado, e itemUse el resumen de su uso
+	item    *models.Item
+	itemUse *models.ItemUse
+}
+
+func newCombatant(pokemon models.Pokemon) *Combatant {
+	c := &Combatant{
+		Pokemon: pokemon,
+		maxHP:   pokemon.HP,
 	}
+	if pokemon.Item != nil {
+		item := *pokemon.Item
+		c.item = &item
+		c.itemUse = &models.ItemUse{
+			PokemonID: pokemon.ID,
+			ItemID:    item.ID,
+			Item:      item.Name,
+		}
+	}
+	return c
+}
 
-	initiativeDice := &SavageDice{
-		BaseDice: BaseDice{
-			Sides: initiativeDiceSides,
+// MaxHP devuelve el HP del Pokémon al empezar la batalla.
+func (c *Combatant) MaxHP() int {
+	return c.maxHP
+}
+
+// Status devuelve el problema de estado que sufre el Pokémon, vacío si no sufre ninguno.
+func (c *Combatant) Status() string {
+	return c.status
+}
+
+// fight contiene el estado de una batalla en curso.
+type fight struct {
+	cfg       *fightConfig
+	diceSides int
+
+	// random es la fuente compartida por todos los dados de la batalla,
+	// de manera que la semilla determina la batalla completa.
+	random         Randomizer
+	initiativeDice Dice
+	attackDice     Dice
+
+	turn int
+	log  []models.BattleEvent
+
+	// condition es la condición del campo en curso, vacía si no hay ninguna, y
+	// conditionTurns los turnos que le quedan, 0 si dura hasta el final de la batalla.
+	// conditions son las condiciones que ha habido, en orden.
+	condition      string
+	conditionTurns int
+	conditions     []models.FieldCondition
+}
+
+func Fight(diceSides int, pokemon1 models.Pokemon, pokemon2 models.Pokemon, opts ...Option) models.Battle {
+	return newFight(diceSides, opts...).run([]models.Pokemon{pokemon1}, []models.Pokemon{pokemon2})
+}
+
+// FightTeams libra una batalla entre dos equipos. Cada equipo sale en el orden
+// indicado: cuando un Pokémon se debilita entra el siguiente, y la batalla
+// termina cuando uno de los equipos se queda sin Pokémon. Con WithSwitchStrategies
+// los equipos pueden además cambiar de Pokémon voluntariamente al empezar cada turno.
+func FightTeams(diceSides int, team1 []models.Pokemon, team2 []models.Pokemon, opts ...Option) models.Battle {
+	battle := newFight(diceSides, opts...).run(team1, team2)
+	battle.Team1 = teamIDs(team1)
+	battle.Team2 = teamIDs(team2)
+
+	return battle
+}
+
+// teamIDs devuelve los IDs de los Pokémon de un equipo, en orden.
+func teamIDs(team []models.Pokemon) []int {
+	ids := make([]int, len(team))
+	for i, pokemon := range team {
+		ids[i] = pokemon.ID
+	}
+	return ids
+}
+
+// newFight prepara una batalla con la configuración y los dados indicados.
+func newFight(diceSides int, opts ...Option) *fight {
+	cfg := newFightConfig(opts...)
+	random := NewRandomizer(cfg.seed)
+
+	f := &fight{
+		cfg:       cfg,
+		diceSides: diceSides,
+		random:    random,
+		initiativeDice: &SavageDice{
+			BaseDice: BaseDice{
+				Sides: cfg.initiativeDiceSides,
+				Rand:  random,
+			},
+		},
+		attackDice: &SavageDice{
+			BaseDice: BaseDice{
+				Sides: diceSides,
+				Rand:  random,
+			},
+			maxExplosions: cfg.maxExplosions,
 		},
 	}
+	if cfg.attackDice != nil {
+		f.attackDice = cfg.attackDice.withRand(random, cfg.maxExplosions)
+	}
 
-	attackDice := &SavageDice{
-		BaseDice: BaseDice{
-			Sides: diceSides,
+	return f
+}
+
+// newBattle crea el registro de una batalla entre dos equipos, con la
+// configuración necesaria para repetirla.
+func (f *fight) newBattle(pokemons1 []models.Pokemon, pokemons2 []models.Pokemon) models.Battle {
+	cfg := f.cfg
+
+	// Create a battle record
+	battle := models.Battle{
+		Pokemon1ID: pokemons1[0].ID,
+		Pokemon2ID: pokemons2[0].ID,
+		Seed:       cfg.seed,
+		Format:     models.FormatSingles,
+		Settings: models.BattleSettings{
+			DiceSides:           f.diceSides,
+			InitiativeDiceSides: cfg.initiativeDiceSides,
+			MaxExplosions:       cfg.maxExplosions,
+			MaxTurns:            cfg.maxTurns,
+			SwitchStrategy1:     strategyName(cfg.switchStrategy1),
+			SwitchStrategy2:     strategyName(cfg.switchStrategy2),
+			Strategy1:           strategyName(cfg.strategy1),
+			Strategy2:           strategyName(cfg.strategy2),
+			Condition:           cfg.condition,
 		},
+		// keep the stats of the participants before the fight, to replay it
+		Participants: append(append([]models.Pokemon(nil), pokemons1...), pokemons2...),
+	}
+	if cfg.attackDice != nil {
+		battle.Settings.DiceExpression = cfg.attackDice.String()
 	}
 
-	// Battle continues until one Pokemon's HP reaches 0
-	turns := 1
-	for {
-		// Decide who starts (1-100 roll)
-		var startRoll1, startRoll2 int
-		for startRoll1 == startRoll2 {
-			startRoll1 = initiativeDice.Roll()
-			startRoll2 = initiativeDice.Roll()
-		}
+	return battle
+}
 
-		attacker, defender := &pokemon1, &pokemon2
-		if startRoll2 > startRoll1 {
-			attacker, defender = &pokemon2, &pokemon1
-		}
+// singlesRun es una batalla individual o por equipos en curso, que se libra turno a turno.
+type singlesRun struct {
+	f            *fight
+	battle       models.Battle
+	team1, team2 *team
+	finished     bool
+}
 
-		attack(attackDice, attacker, defender)
+// start prepara la batalla entre dos equipos, que en las batallas individuales
+// tienen un único Pokémon cada uno, antes de su primer turno.
+func (f *fight) start(pokemons1 []models.Pokemon, pokemons2 []models.Pokemon) *singlesRun {
+	cfg := f.cfg
+	f.turn = 1
+	if cfg.condition != "" {
+		f.setCondition(cfg.condition, 0, nil)
+	}
 
-		// If defender is still alive, they get to attack
-		if defender.HP > 0 {
-			attack(attackDice, defender, attacker)
+	return &singlesRun{
+		f:      f,
+		battle: f.newBattle(pokemons1, pokemons2),
+		team1:  newTeam(pokemons1, cfg.switchStrategy1, cfg.strategy1),
+		team2:  newTeam(pokemons2, cfg.switchStrategy2, cfg.strategy2),
+	}
+}
+
+// run libra la batalla entre dos equipos, que en las batallas individuales
+// tienen un único Pokémon cada uno.
+func (f *fight) run(pokemons1 []models.Pokemon, pokemons2 []models.Pokemon) models.Battle {
+	r := f.start(pokemons1, pokemons2)
+
+	// Battle continues until one team runs out of Pokemon,
+	// or ends in a draw after the maximum number of turns
+	for !r.playTurn() {
+	}
+
+	return r.result()
+}
+
+// playTurn libra el turno en curso y, si la batalla no ha terminado, pasa al
+// siguiente. Indica si la batalla ha terminado.
+func (r *singlesRun) playTurn() bool {
+	f, cfg := r.f, r.f.cfg
+	team1, team2 := r.team1, r.team2
+
+	// Before anything else, each team may switch its active Pokemon
+	// or use its item, losing its attack for the turn
+	switched1 := f.takeAction(team1, team2)
+	switched2 := f.takeAction(team2, team1)
+
+	c1, c2 := team1.active(), team2.active()
+
+	// Decide who starts (speed + initiative roll), rerolling ties
+	startRoll1, startRoll2 := f.initiativeDice.Roll(), f.initiativeDice.Roll()
+	for c1.Speed+startRoll1 == c2.Speed+startRoll2 {
+		startRoll1 = f.initiativeDice.Roll()
+		startRoll2 = f.initiativeDice.Roll()
+	}
+
+	attacker, defender := c1, c2
+	attackerTeam, defenderTeam := team1, team2
+	attackerRoll, defenderRoll := startRoll1, startRoll2
+	attackerSwitched, defenderSwitched := switched1, switched2
+	if c2.Speed+startRoll2 > c1.Speed+startRoll1 {
+		attacker, defender = c2, c1
+		attackerTeam, defenderTeam = team2, team1
+		attackerRoll, defenderRoll = startRoll2, startRoll1
+		attackerSwitched, defenderSwitched = switched2, switched1
+	}
+
+	f.record(models.BattleEvent{
+		Type:       models.EventInitiative,
+		PokemonID:  attacker.ID,
+		TargetID:   defender.ID,
+		Roll:       attackerRoll,
+		TargetRoll: defenderRoll,
+		HP:         attacker.HP,
+		TargetHP:   defender.HP,
+	})
+
+	if !attackerSwitched && f.canAct(attacker) {
+		f.attack(attacker, defender, attackerTeam.move)
+	}
+
+	// If defender is still alive, they get to attack
+	if defender.HP > 0 && !defenderSwitched && f.canAct(defender) {
+		f.attack(defender, attacker, defenderTeam.move)
+	}
+
+	// At the end of the turn, the status conditions, the field condition,
+	// the abilities and the items of the survivors take effect
+	for _, c := range []*Combatant{attacker, defender} {
+		if attacker.HP > 0 && defender.HP > 0 {
+			f.residual(c)
+			f.conditionResidual(c)
+		}
+	}
+	for _, c := range []*Combatant{attacker, defender} {
+		if attacker.HP > 0 && defender.HP > 0 {
+			f.endOfTurn(c)
+			f.itemEndOfTurn(c)
 		}
+	}
+	f.conditionEndOfTurn()
 
-		// Determine winner, if one of them is without HP
-		if attacker.HP <= 0 {
-			battle.WinnerID = defender.ID
-			break
-		} else if defender.HP <= 0 {
-			battle.WinnerID = attacker.ID
-			break
+	// Determine winner, if one of the teams is left without Pokemon.
+	// Otherwise, the next Pokemon of the team replaces the fainted one
+	if attacker.HP <= 0 {
+		f.faint(attacker)
+		if !f.replaceFainted(attackerTeam, defenderTeam) {
+			r.battle.WinnerID = defender.ID
+			r.finished = true
+			return true
+		}
+	} else if defender.HP <= 0 {
+		f.faint(defender)
+		if !f.replaceFainted(defenderTeam, attackerTeam) {
+			r.battle.WinnerID = attacker.ID
+			r.finished = true
+			return true
 		}
+	}
 
-		turns++
+	if f.turn == cfg.maxTurns {
+		c1, c2 = team1.active(), team2.active()
+		f.record(models.BattleEvent{
+			Type:      models.EventDraw,
+			PokemonID: c1.ID,
+			TargetID:  c2.ID,
+			HP:        c1.HP,
+			TargetHP:  c2.HP,
+		})
+		r.finished = true
+		return true
 	}
 
-	battle.Turns = turns
+	f.turn++
+	return false
+}
+
+// result devuelve el registro de la batalla, con los turnos librados hasta el momento.
+func (r *singlesRun) result() models.Battle {
+	battle := r.battle
+	battle.Turns = r.f.turn
+	battle.Pokemon1Criticals = r.team1.criticals()
+	battle.Pokemon2Criticals = r.team2.criticals()
+	battle.ItemUses = itemUses(append(r.team1.members, r.team2.members...))
+	battle.Conditions = r.f.conditionHistory()
+	battle.Log = r.f.log
 
 	return battle
 }
 
-func attack(dice Dice, attacker *models.Pokemon, defender *models.Pokemon) {
-	// Calculate attack value (base attack + dice roll)
-	attackRoll := dice.Roll()
-	totalAttack := attacker.Attack + attackRoll
+// record añade un evento del turno actual al registro de la batalla
+// y se lo envía a la función de eventos, si la hay.
+func (f *fight) record(event models.BattleEvent) {
+	event.Turn = f.turn
+	f.log = append(f.log, event)
+	if f.cfg.eventHandler != nil {
+		f.cfg.eventHandler(event)
+	}
+}
+
+// attack resuelve el ataque de un Pokémon contra otro, registrando
+// las tiradas y el daño causado. Si el atacante conoce movimientos,
+// usa el de la posición indicada, o uno al azar si la posición es negativa.
+func (f *fight) attack(attacker *Combatant, defender *Combatant, position int) {
+	move, hasMove := f.selectMove(&attacker.Pokemon, position)
+	f.strike(attacker, defender, move, hasMove, 1)
+}
+
+// strike resuelve el ataque con un movimiento concreto, o sin movimiento si
+// hasMove es false. El modificador multiplica el daño causado, como en los
+// movimientos que alcanzan a varios objetivos.
+func (f *fight) strike(attacker *Combatant, defender *Combatant, move models.Move, hasMove bool, modifier float64) {
+	event := models.BattleEvent{
+		Type:      models.EventAttack,
+		PokemonID: attacker.ID,
+		TargetID:  defender.ID,
+	}
+
+	attack := &Attack{
+		Attacker:    attacker,
+		Defender:    defender,
+		Move:        move,
+		HasMove:     hasMove,
+		Type:        move.Type,
+		AttackStat:  attacker.Attack,
+		DefenseStat: defender.Defense,
+	}
+	if !hasMove {
+		attack.Type = f.cfg.typeChart.BestType(attacker.Type, defender.Type)
+	}
+	f.beforeAttack(attack)
+
+	if hasMove {
+		event.MoveID = move.ID
+		event.Move = move.Name
+	}
+
+	// an ability may make the defender immune to the attack
+	if attack.Immune {
+		event.HP = attacker.HP
+		event.TargetHP = defender.HP
+		f.record(event)
+		return
+	}
+
+	if hasMove {
+		event.Missed = !f.hits(move)
+
+		// missed and status moves don't cause any damage
+		if event.Missed || move.Category == models.MoveCategoryStatus {
+			event.HP = attacker.HP
+			event.TargetHP = defender.HP
+			f.record(event)
+
+			if !event.Missed {
+				if move.Condition != "" {
+					f.setCondition(move.Condition, conditionTurns, attacker)
+				}
+				f.applyMoveEffect(defender, move)
+			}
+			return
+		}
+	}
+
+	f.itemBeforeAttack(attack)
+
+	// Calculate attack value (base attack + dice roll).
+	// An exploding attack roll is a critical hit
+	event.Roll = f.attackDice.Roll()
+	totalAttack := attack.AttackStat + event.Roll
+	critical := exploded(f.attackDice)
 
 	// Calculate defense value (base defense + dice roll)
-	defenseRoll := dice.Roll()
-	totalDefense := defender.Defense + defenseRoll
+	event.TargetRoll = f.attackDice.Roll()
+	totalDefense := attack.DefenseStat + event.TargetRoll
 
-	// If attack beats defense, reduce defender's HP
+	// If attack beats defense, reduce defender's HP, applying the power of the move,
+	// the field condition and its type effectiveness against the defender
 	if totalAttack > totalDefense {
-		damage := totalAttack - totalDefense
-		defender.HP -= damage
+		power := float64(totalAttack - totalDefense)
+		if hasMove {
+			power = power * float64(move.Power) / baseMovePower
+		}
+		event.Effectiveness = f.cfg.typeChart.Effectiveness(attack.Type, ParseTypes(defender.Type))
+		power *= f.conditionMultiplier(attack.Type)
+		if critical {
+			event.Critical = true
+			attacker.criticals++
+			power *= criticalMultiplier
+		}
+
+		event.Damage = int(math.Round(power * event.Effectiveness * modifier))
+		defender.HP -= event.Damage
 	}
+
+	event.HP = attacker.HP
+	event.TargetHP = defender.HP
+
+	f.record(event)
+
+	if event.Damage > 0 {
+		attack.Damage = event.Damage
+		f.afterDamage(attack)
+
+		if defender.HP > 0 {
+			f.itemAfterDamage(defender)
+		} else {
+			f.fainted(defender, attacker)
+		}
+	}
+
+	// a successful hit may cause a status condition on the defender
+	if event.Damage > 0 && defender.HP > 0 {
+		if hasMove && move.Effect != "" {
+			f.applyMoveEffect(defender, move)
+		} else {
+			f.procStatus(attacker, defender, move, hasMove)
+		}
+	}
+}
+
+// exploded indica si el dado ha explotado en su última tirada.
+// Los dados que no pueden explotar nunca causan golpes críticos.
+func exploded(dice Dice) bool {
+	e, ok := dice.(Exploder)
+	return ok && e.Exploded()
+}
+
+// faint registra que un Pokémon se ha quedado sin HP.
+func (f *fight) faint(pokemon *Combatant) {
+	f.record(models.BattleEvent{
+		Type:      models.EventFaint,
+		PokemonID: pokemon.ID,
+		HP:        pokemon.HP,
+	})
 }
@@ -2,6 +2,8 @@ package business_test
 
 import (
 	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+	"reflect"
 	"testing"
 )
 
@@ -56,3 +58,217 @@ func TestFight(t *testing.T) {
 		}
 	})
 }
+
+func TestFight_log(t *testing.T) {
+	rival := strongPokemon
+	rival.ID = 3
+
+	battle := business.Fight(10, strongPokemon, rival)
+
+	if len(battle.Log) == 0 {
+		t.Fatal("expected the battle log not to be empty")
+	}
+
+	first := battle.Log[0]
+	if first.Type != models.EventInitiative || first.Turn != 1 {
+		t.Fatalf("expected first event to be the initiative of turn 1, got %s of turn %d", first.Type, first.Turn)
+	}
+	if first.Roll <= first.TargetRoll {
+		t.Fatalf("expected initiative roll %d to be greater than %d", first.Roll, first.TargetRoll)
+	}
+
+	last := battle.Log[len(battle.Log)-1]
+	if last.Type != models.EventFaint || last.Turn != battle.Turns {
+		t.Fatalf("expected last event to be a faint in turn %d, got %s in turn %d", battle.Turns, last.Type, last.Turn)
+	}
+	if last.HP > 0 {
+		t.Fatalf("expected fainted pokemon HP to be 0 or less, got %d", last.HP)
+	}
+
+	// el daño de cada ataque debe coincidir con el HP restante del objetivo
+	hp := map[int]int{strongPokemon.ID: strongPokemon.HP, rival.ID: rival.HP}
+	for _, event := range battle.Log {
+		if event.Type != models.EventAttack {
+			continue
+		}
+		if event.Damage < 0 {
+			t.Fatalf("expected damage to be positive, got %d", event.Damage)
+		}
+		hp[event.TargetID] -= event.Damage
+		if event.TargetHP != hp[event.TargetID] {
+			t.Fatalf("expected target HP to be %d, got %d", hp[event.TargetID], event.TargetHP)
+		}
+	}
+}
+
+func TestFight_eventHandler(t *testing.T) {
+	rival := strongPokemon
+	rival.ID = 3
+
+	var events []models.BattleEvent
+	battle := business.Fight(10, strongPokemon, rival, business.WithSeed(42),
+		business.WithEventHandler(func(event models.BattleEvent) {
+			events = append(events, event)
+		}))
+
+	// la función recibe los mismos eventos que el registro, en el mismo orden
+	if !reflect.DeepEqual(events, battle.Log) {
+		t.Fatalf("expected the handler to receive the %d events of the log, got %d", len(battle.Log), len(events))
+	}
+}
+
+func TestFight_seed(t *testing.T) {
+	rival := strongPokemon
+	rival.ID = 3
+
+	t.Run("same-seed/same-battle", func(t *testing.T) {
+		battle1 := business.Fight(10, strongPokemon, rival, business.WithSeed(42))
+		battle2 := business.Fight(10, strongPokemon, rival, business.WithSeed(42))
+
+		if battle1.Seed != 42 {
+			t.Fatalf("expected seed to be 42, got %d", battle1.Seed)
+		}
+		if !reflect.DeepEqual(battle1, battle2) {
+			t.Fatalf("expected battles to be equal, got %+v and %+v", battle1, battle2)
+		}
+	})
+
+	t.Run("random-seed/reproducible", func(t *testing.T) {
+		battle1 := business.Fight(10, strongPokemon, rival)
+		battle2 := business.Fight(10, strongPokemon, rival, business.WithSeed(battle1.Seed))
+
+		if !reflect.DeepEqual(battle1, battle2) {
+			t.Fatalf("expected battles to be equal, got %+v and %+v", battle1, battle2)
+		}
+	})
+}
+
+func TestFight_speed(t *testing.T) {
+	// Aerodactyl y Snorlax se derrotan de un solo golpe, por lo que gana
+	// el que ataque primero
+	fast := models.Pokemon{ID: 1, Name: "Aerodactyl", Type: "Rock/Flying", HP: 1, Attack: 105, Defense: 0, Speed: 130}
+	slow := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 1, Attack: 110, Defense: 0, Speed: 30}
+
+	t.Run("faster-attacks-first", func(t *testing.T) {
+		for seed := int64(0); seed < 10; seed++ {
+			battle := business.Fight(10, slow, fast, business.WithSeed(seed))
+			if battle.WinnerID != fast.ID {
+				t.Fatalf("expected winner ID to be %d, got %d", fast.ID, battle.WinnerID)
+			}
+			if battle.Log[0].Type != models.EventInitiative || battle.Log[0].PokemonID != fast.ID {
+				t.Fatalf("expected %s to win the initiative, got %+v", fast.Name, battle.Log[0])
+			}
+		}
+	})
+
+	t.Run("initiative-dice", func(t *testing.T) {
+		// con un dado de iniciativa mucho mayor que la diferencia de velocidad,
+		// Snorlax debe atacar primero alguna vez
+		for seed := int64(0); seed < 100; seed++ {
+			battle := business.Fight(10, slow, fast, business.WithSeed(seed), business.WithInitiativeDice(1000))
+			if battle.Settings.InitiativeDiceSides != 1000 {
+				t.Fatalf("expected initiative dice sides to be 1000, got %d", battle.Settings.InitiativeDiceSides)
+			}
+			if battle.WinnerID == slow.ID {
+				return
+			}
+		}
+		t.Fatalf("expected %s to win at least once", slow.Name)
+	})
+
+	t.Run("initiative-dice/one-side", func(t *testing.T) {
+		// un dado de una cara no podría deshacer el empate entre dos Pokémon
+		// igual de rápidos, por lo que se usa el dado por defecto
+		rival := fast
+		rival.ID = 2
+		battle := business.Fight(10, fast, rival, business.WithSeed(1), business.WithInitiativeDice(1))
+		if battle.Settings.InitiativeDiceSides != business.DefaultInitiativeDiceSides {
+			t.Fatalf("expected initiative dice sides to be %d, got %d", business.DefaultInitiativeDiceSides, battle.Settings.InitiativeDiceSides)
+		}
+	})
+}
+
+func TestFight_criticals(t *testing.T) {
+	// con un dado de una cara, el dado de ataque siempre explota
+	attacker := models.Pokemon{ID: 1, Name: "Snorlax", Type: "Normal", HP: 160, Attack: 10, Defense: 0, Speed: 30}
+	defender := models.Pokemon{ID: 2, Name: "Chansey", Type: "Normal", HP: 250, Attack: 0, Defense: 0, Speed: 50}
+
+	t.Run("explosions", func(t *testing.T) {
+		battle := business.Fight(1, attacker, defender, business.WithMaxExplosions(3))
+
+		for _, event := range battle.Log {
+			if event.Type != models.EventAttack || event.PokemonID != attacker.ID {
+				continue
+			}
+
+			if !event.Critical {
+				t.Fatalf("expected attack to be a critical hit, got %+v", event)
+			}
+			// (10 + 3 - 3) * 1.5
+			if event.Damage != 15 {
+				t.Fatalf("expected damage to be 15, got %d", event.Damage)
+			}
+		}
+
+		if battle.Pokemon1Criticals == 0 {
+			t.Fatal("expected critical hits to be reported")
+		}
+		if battle.Settings.MaxExplosions != 3 {
+			t.Fatalf("expected max explosions to be 3, got %d", battle.Settings.MaxExplosions)
+		}
+	})
+
+	t.Run("one-explosion", func(t *testing.T) {
+		// con una sola explosión, el dado vuelve a tirar una vez y el ataque es crítico
+		battle := business.Fight(1, attacker, defender, business.WithMaxExplosions(1))
+
+		if battle.Pokemon1Criticals == 0 {
+			t.Fatal("expected critical hits with a single explosion")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		battle := business.Fight(1, attacker, defender, business.WithMaxExplosions(0))
+
+		for _, event := range battle.Log {
+			if event.Critical {
+				t.Fatalf("expected no critical hits, got %+v", event)
+			}
+		}
+
+		if battle.Pokemon1Criticals != 0 || battle.Pokemon2Criticals != 0 {
+			t.Fatalf("expected no critical hits, got %d and %d", battle.Pokemon1Criticals, battle.Pokemon2Criticals)
+		}
+	})
+}
+
+func TestFight_draw(t *testing.T) {
+	// Steelix y Wobbuffet no pueden superar la defensa del otro,
+	// por lo que la batalla solo puede terminar en empate
+	steelix := models.Pokemon{ID: 1, Name: "Steelix", Type: "Steel/Ground", HP: 75, Attack: 85, Defense: 200, Speed: 30}
+	wobbuffet := models.Pokemon{ID: 2, Name: "Wobbuffet", Type: "Psychic", HP: 190, Attack: 33, Defense: 200, Speed: 33}
+
+	battle := business.Fight(4, steelix, wobbuffet, business.WithMaxTurns(20), business.WithMaxExplosions(0))
+
+	if !battle.IsDraw() {
+		t.Fatalf("expected battle to end in a draw, got winner ID %d", battle.WinnerID)
+	}
+	if battle.Turns != 20 {
+		t.Fatalf("expected turns to be 20, got %d", battle.Turns)
+	}
+	if err := battle.Validate(); err != nil {
+		t.Fatalf("expected a draw to be valid, got %v", err)
+	}
+
+	last := battle.Log[len(battle.Log)-1]
+	if last.Type != models.EventDraw {
+		t.Fatalf("expected last event to be a draw, got %s", last.Type)
+	}
+
+	t.Run("default-max-turns", func(t *testing.T) {
+		battle := business.Fight(4, steelix, wobbuffet)
+		if !battle.IsDraw() || battle.Turns != business.DefaultMaxTurns {
+			t.Fatalf("expected a draw after %d turns, got winner ID %d after %d turns", business.DefaultMaxTurns, battle.WinnerID, battle.Turns)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package business
+
+import (
+	"pokemon-battle/internal/models"
+)
+
+// conditionTurns es el número de turnos que dura una condición del campo
+// establecida por un movimiento, contando el turno en el que se establece.
+const conditionTurns = 5
+
+// conditionDamageDivisor es la fracción del HP máximo que pierden al final
+// de cada turno los Pokémon afectados por una tormenta de arena o granizo,
+// y que recuperan con el campo de hierba.
+const conditionDamageDivisor = 16
+
+// conditionBoosts son los multiplicadores de daño de los tipos de movimiento
+// que cada condición del campo potencia o debilita.
+var conditionBoosts = map[string]map[string]float64{
+	models.ConditionRain:            {"water": 1.5, "fire": 0.5},
+	models.ConditionSun:             {"fire": 1.5, "water": 0.5},
+	models.ConditionElectricTerrain: {"electric": 1.3},
+	models.ConditionGrassyTerrain:   {"grass": 1.3},
+}
+
+// conditionImmunities son los tipos de Pokémon que no sufren el daño de final
+// de turno de cada condición del campo.
+var conditionImmunities = map[string][]string{
+	models.ConditionSandstorm: {"rock", "ground", "steel"},
+	models.ConditionHail:      {"ice"},
+}
+
+// setCondition establece la condición del campo durante el número de turnos
+// indicado, o hasta el final de la batalla si es 0, sustituyendo la condición
+// en curso. El Pokémon que la establece es nil si la condición es la inicial.
+// Una condición que ya está en curso no se renueva.
+func (f *fight) setCondition(condition string, turns int, source *Combatant) {
+	if condition == f.condition {
+		return
+	}
+	if f.condition != "" {
+		f.endCondition()
+	}
+
+	f.condition = condition
+	f.conditionTurns = turns
+	f.conditions = append(f.conditions, models.FieldCondition{
+		Condition: condition,
+		StartTurn: f.turn,
+	})
+
+	event := models.BattleEvent{
+		Type:      models.EventCondition,
+		Condition: condition,
+	}
+	if source != nil {
+		event.PokemonID = source.ID
+		event.HP = source.HP
+	}
+	f.record(event)
+}
+
+// endCondition termina la condición del campo en curso.
+func (f *fight) endCondition() {
+	f.record(models.BattleEvent{
+		Type:      models.EventConditionEnd,
+		Condition: f.condition,
+	})
+	f.conditions[len(f.conditions)-1].Turns = f.turn - f.conditions[len(f.conditions)-1].StartTurn + 1
+	f.condition = ""
+	f.conditionTurns = 0
+}
+
+// conditionMultiplier devuelve el multiplicador de daño de la condición del campo
+// en curso para un ataque del tipo indicado.
+func (f *fight) conditionMultiplier(moveType string) float64 {
+	if multiplier, ok := conditionBoosts[f.condition][normalizeType(moveType)]; ok {
+		return multiplier
+	}
+	return 1
+}
+
+// conditionResidual aplica el efecto de final de turno de la condición del campo
+// en curso: el daño de la tormenta de arena y el granizo a los Pokémon que no
+// son inmunes, o la curación del campo de hierba.
+func (f *fight) conditionResidual(c *Combatant) {
+	if c.HP <= 0 {
+		return
+	}
+
+	amount := max(c.maxHP/conditionDamageDivisor, 1)
+
+	if f.condition == models.ConditionGrassyTerrain {
+		healed := min(amount, c.maxHP-c.HP)
+		if healed <= 0 {
+			return
+		}
+		c.HP += healed
+		f.record(models.BattleEvent{
+			Type:      models.EventResidual,
+			PokemonID: c.ID,
+			Condition: f.condition,
+			Healed:    healed,
+			HP:        c.HP,
+		})
+		return
+	}
+
+	immunities, ok := conditionImmunities[f.condition]
+	if !ok {
+		return
+	}
+	for _, t := range ParseTypes(c.Type) {
+		for _, immune := range immunities {
+			if normalizeType(t) == immune {
+				return
+			}
+		}
+	}
+
+	c.HP -= amount
+	f.record(models.BattleEvent{
+		Type:      models.EventResidual,
+		PokemonID: c.ID,
+		Condition: f.condition,
+		Damage:    amount,
+		HP:        c.HP,
+	})
+
+	if c.HP > 0 {
+		f.itemAfterDamage(c)
+	} else {
+		f.fainted(c, nil)
+	}
+}
+
+// conditionEndOfTurn descuenta un turno de la condición del campo en curso
+// y la termina cuando se agota. Las condiciones iniciales no se agotan.
+func (f *fight) conditionEndOfTurn() {
+	if f.conditionTurns == 0 {
+		return
+	}
+	f.conditionTurns--
+	if f.conditionTurns == 0 {
+		f.endCondition()
+	}
+}
+
+// conditionHistory devuelve las condiciones del campo que ha habido en la batalla,
+// con los turnos que ha durado cada una hasta el turno en curso.
+func (f *fight) conditionHistory() []models.FieldCondition {
+	if len(f.conditions) == 0 {
+		return nil
+	}
+
+	history := append([]models.FieldCondition(nil), f.conditions...)
+	if f.condition != "" {
+		last := &history[len(history)-1]
+		last.Turns = f.turn - last.StartTurn + 1
+	}
+	return history
+}
@@ -0,0 +1,208 @@
+package business_test
+
+import (
+	"reflect"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+var (
+	rainDance = models.Move{ID: 7, Name: "Rain Dance", Type: "Water", Accuracy: 100, Category: models.MoveCategoryStatus, Condition: models.ConditionRain}
+	surf      = models.Move{ID: 8, Name: "Surf", Type: "Water", Power: 90, Accuracy: 100, Category: models.MoveCategorySpecial}
+)
+
+func TestFight_condition(t *testing.T) {
+	t.Run("sandstorm", func(t *testing.T) {
+		// ninguno puede causar daño: Geodude es de tipo roca y no sufre la tormenta
+		// de arena, que quita a Snorlax 1/16 de su HP máximo cada turno
+		pokemon1 := models.Pokemon{ID: 1, Name: "Geodude", Type: "Rock/Ground", HP: 40, Attack: 80, Defense: 100, Moves: []models.Move{growl}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 16, Attack: 110, Defense: 65, Moves: []models.Move{growl}}
+
+		battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(1), business.WithCondition(models.ConditionSandstorm))
+
+		if battle.WinnerID != pokemon1.ID {
+			t.Fatalf("expected winner ID to be %d, got %d", pokemon1.ID, battle.WinnerID)
+		}
+		if battle.Settings.Condition != models.ConditionSandstorm {
+			t.Fatalf("expected condition setting to be %q, got %q", models.ConditionSandstorm, battle.Settings.Condition)
+		}
+		if first := battle.Log[0]; first.Type != models.EventCondition || first.Condition != models.ConditionSandstorm {
+			t.Fatalf("expected the battle to start with the sandstorm, got %+v", first)
+		}
+
+		for _, event := range battle.Log {
+			if event.Type == models.EventResidual && (event.PokemonID != pokemon2.ID || event.Damage != 1) {
+				t.Fatalf("expected only %s to lose 1 HP each turn, got %+v", pokemon2.Name, event)
+			}
+		}
+
+		// la condición inicial dura toda la batalla
+		expected := []models.FieldCondition{{Condition: models.ConditionSandstorm, StartTurn: 1, Turns: battle.Turns}}
+		if !reflect.DeepEqual(battle.Conditions, expected) {
+			t.Fatalf("expected conditions to be %+v, got %+v", expected, battle.Conditions)
+		}
+	})
+
+	t.Run("grassy-terrain", func(t *testing.T) {
+		// el campo de hierba cura al Pokémon envenenado, que aguanta más turnos
+		pokemon1 := models.Pokemon{ID: 1, Name: "Ekans", Type: "Poison", HP: 35, Attack: 60, Defense: 44, Moves: []models.Move{toxic}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 16, Attack: 110, Defense: 65, Moves: []models.Move{growl}}
+
+		battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(1), business.WithMaxTurns(20), business.WithCondition(models.ConditionGrassyTerrain))
+
+		var healed int
+		for _, event := range battle.Log {
+			if event.Type == models.EventResidual && event.Condition == models.ConditionGrassyTerrain {
+				healed += event.Healed
+			}
+		}
+		if healed == 0 {
+			t.Fatal("expected the grassy terrain to heal the poisoned pokemon")
+		}
+	})
+
+	t.Run("move", func(t *testing.T) {
+		// la lluvia de Danza Lluvia dura 5 turnos, y no se renueva mientras dura
+		pokemon1 := models.Pokemon{ID: 1, Name: "Politoed", Type: "Water", HP: 90, Attack: 75, Defense: 75, Moves: []models.Move{rainDance}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 160, Attack: 110, Defense: 65, Moves: []models.Move{growl}}
+
+		battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(1), business.WithMaxTurns(10))
+
+		expected := []models.FieldCondition{
+			{Condition: models.ConditionRain, StartTurn: 1, Turns: 5},
+			{Condition: models.ConditionRain, StartTurn: 6, Turns: 5},
+		}
+		if !reflect.DeepEqual(battle.Conditions, expected) {
+			t.Fatalf("expected conditions to be %+v, got %+v", expected, battle.Conditions)
+		}
+
+		var started, ended int
+		for _, event := range battle.Log {
+			switch event.Type {
+			case models.EventCondition:
+				if event.PokemonID != pokemon1.ID {
+					t.Fatalf("expected %s to start the rain, got %+v", pokemon1.Name, event)
+				}
+				started++
+			case models.EventConditionEnd:
+				ended++
+			}
+		}
+		if started != 2 || ended != 2 {
+			t.Fatalf("expected the rain to start and end twice, got %d and %d", started, ended)
+		}
+	})
+
+	t.Run("damage", func(t *testing.T) {
+		// la lluvia potencia los movimientos de agua y el sol los debilita
+		pokemon1 := models.Pokemon{ID: 1, Name: "Lapras", Type: "Water", HP: 130, Attack: 85, Defense: 80, Moves: []models.Move{surf}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 160, Attack: 110, Defense: 65, Moves: []models.Move{growl}}
+
+		firstDamage := func(opts ...business.Option) int {
+			battle := business.Fight(10, pokemon1, pokemon2, append(opts, business.WithSeed(3), business.WithMaxExplosions(0))...)
+			for _, event := range battle.Log {
+				if event.Type == models.EventAttack && event.PokemonID == pokemon1.ID {
+					return event.Damage
+				}
+			}
+			t.Fatal("expected an attack in the battle log")
+			return 0
+		}
+
+		base := firstDamage()
+		if base == 0 {
+			t.Fatal("expected the first attack to cause damage")
+		}
+		if rain := firstDamage(business.WithCondition(models.ConditionRain)); rain <= base {
+			t.Fatalf("expected the rain to increase the damage from %d, got %d", base, rain)
+		}
+		if sun := firstDamage(business.WithCondition(models.ConditionSun)); sun >= base {
+			t.Fatalf("expected the sun to decrease the damage from %d, got %d", base, sun)
+		}
+	})
+
+	t.Run("damage-without-move", func(t *testing.T) {
+		// sin movimientos, el Pokémon ataca con su tipo, que la condición también potencia o debilita
+		pokemon1 := models.Pokemon{ID: 1, Name: "Vaporeon", Type: "Water", HP: 130, Attack: 85, Defense: 80}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 160, Attack: 110, Defense: 65, Moves: []models.Move{growl}}
+
+		firstDamage := func(opts ...business.Option) int {
+			battle := business.Fight(10, pokemon1, pokemon2, append(opts, business.WithSeed(3), business.WithMaxExplosions(0))...)
+			for _, event := range battle.Log {
+				if event.Type == models.EventAttack && event.PokemonID == pokemon1.ID {
+					return event.Damage
+				}
+			}
+			t.Fatal("expected an attack in the battle log")
+			return 0
+		}
+
+		base := firstDamage()
+		if base == 0 {
+			t.Fatal("expected the first attack to cause damage")
+		}
+		if rain := firstDamage(business.WithCondition(models.ConditionRain)); rain <= base {
+			t.Fatalf("expected the rain to increase the damage from %d, got %d", base, rain)
+		}
+		if sun := firstDamage(business.WithCondition(models.ConditionSun)); sun >= base {
+			t.Fatalf("expected the sun to decrease the damage from %d, got %d", base, sun)
+		}
+
+		// con dos tipos ataca con el más eficaz contra el defensor, y solo ese tipo cuenta
+		// para la condición: la lluvia no potencia el ataque de hielo contra un dragón
+		pokemon1.Type = "Water/Ice"
+		pokemon2.Type = "Dragon"
+		if base = firstDamage(); base == 0 {
+			t.Fatal("expected the first attack to cause damage")
+		}
+		if rain := firstDamage(business.WithCondition(models.ConditionRain)); rain != base {
+			t.Fatalf("expected the rain not to change the damage %d, got %d", base, rain)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		battle := business.Fight(10, strongPokemon, weakPokemon, business.WithSeed(1), business.WithCondition("fog"))
+
+		if battle.Settings.Condition != "" || battle.Conditions != nil {
+			t.Fatalf("expected no condition, got %q and %+v", battle.Settings.Condition, battle.Conditions)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		pokemon1 := models.Pokemon{ID: 1, Name: "Lapras", Type: "Water", HP: 130, Attack: 85, Defense: 80, Moves: []models.Move{surf, rainDance}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Cloyster", Type: "Water/Ice", HP: 50, Attack: 95, Defense: 180, Moves: []models.Move{surf}}
+
+		battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(5), business.WithCondition(models.ConditionHail))
+
+		replay, err := business.Replay(battle)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !replay.Matches {
+			t.Fatalf("expected the replay to match the battle, got %+v", replay)
+		}
+	})
+}
+
+func TestFightDoubles_condition(t *testing.T) {
+	team1 := []models.Pokemon{
+		{ID: 1, Name: "Geodude", Type: "Rock", HP: 40, Attack: 80, Defense: 100, Moves: []models.Move{growl}},
+		{ID: 2, Name: "Onix", Type: "Rock", HP: 35, Attack: 45, Defense: 160, Moves: []models.Move{growl}},
+	}
+	team2 := []models.Pokemon{
+		{ID: 3, Name: "Snorlax", Type: "Normal", HP: 16, Attack: 110, Defense: 65, Moves: []models.Move{growl}},
+		{ID: 4, Name: "Jigglypuff", Type: "Normal", HP: 16, Attack: 45, Defense: 20, Moves: []models.Move{growl}},
+	}
+
+	battle := business.FightDoubles(10, team1, team2, business.WithSeed(1), business.WithCondition(models.ConditionSandstorm))
+
+	// solo los Pokémon de tipo normal sufren la tormenta de arena
+	if battle.WinnerID != 1 && battle.WinnerID != 2 {
+		t.Fatalf("expected the first team to win, got winner ID %d", battle.WinnerID)
+	}
+	if len(battle.Conditions) != 1 || battle.Conditions[0].Turns != battle.Turns {
+		t.Fatalf("expected the sandstorm to last the whole battle, got %+v", battle.Conditions)
+	}
+}
@@ -6,15 +6,41 @@ import (
 
 const DefaultDiceSides = 6
 
+// DefaultMaxExplosions es el número máximo de explosiones por defecto
+// de los dados de ataque y defensa.
+const DefaultMaxExplosions = 3
+
+// Randomizer es una interfaz que representa la fuente de números aleatorios
+// de un dado. *rand.Rand cumple esta interfaz.
+type Randomizer interface {
+	// Intn devuelve un número aleatorio en el intervalo [0, n).
+	Intn(n int) int
+}
+
+// NewRandomizer devuelve una fuente de números aleatorios determinista
+// a partir de una semilla: la misma semilla produce siempre la misma secuencia.
+func NewRandomizer(seed int64) Randomizer {
+	return rand.New(rand.NewSource(seed))
+}
+
 // Dice es una interfaz que representa un dado.
 type Dice interface {
 	Roll() int
 	Result() int
 }
 
+// Exploder es una interfaz que representan los dados que pueden explotar,
+// repitiendo la tirada al sacar el máximo valor.
+type Exploder interface {
+	// Exploded indica si el dado ha explotado en la última tirada.
+	Exploded() bool
+}
+
 // BaseDice es una implementación de Dice que representa un dado base.
+// Si no se define la fuente de números aleatorios, se usa la fuente global.
 type BaseDice struct {
 	Sides  int
+	Rand   Randomizer
 	result int
 }
 
@@ -33,34 +59,33 @@ type SavageDice struct {
 }
 
 func (d *BaseDice) Roll() int {
-	d.result = rand.Intn(d.Sides) + 1
+	d.result = d.roll()
 	return d.result
 }
 
-func (d *SavageDice) Roll() int {
-	sum := 0
-	maxRolls := d.maxExplosions
-	if maxRolls == 0 {
-		// Si no se ha definido el máximo de explosiones,
-		// se asume que el dado no explota.
-		maxRolls = 1
+// roll lanza el dado una vez usando su fuente de números aleatorios.
+func (d *BaseDice) roll() int {
+	if d.Rand == nil {
+		return rand.Intn(d.Sides) + 1
 	}
+	return d.Rand.Intn(d.Sides) + 1
+}
 
-	// Initializar la tirada con el máximo valor
-	// para que entrar a tirar.
-	roll := d.Sides
+func (d *SavageDice) Roll() int {
+	// Un dado explota cuando sale el máximo valor, repitiendo la tirada
+	// hasta que deje de explotar o alcance el máximo de explosiones.
+	// Si no se ha definido el máximo de explosiones, el dado no explota.
+	d.rolls = d.rolls[:0]
+	roll := d.roll()
+	d.rolls = append(d.rolls, roll)
+	sum := roll
 
-	// Un dado explota cuando sale el máximo valor,
-	// repitiendo la tirada hasta que deje de explotar.
 	explosions := 0
-	for roll == d.Sides && maxRolls > 0 {
-		roll = rand.Intn(d.Sides) + 1
+	for roll == d.Sides && explosions < d.maxExplosions {
+		roll = d.roll()
 		d.rolls = append(d.rolls, roll)
 		sum += roll
-		if roll == d.Sides {
-			maxRolls--
-			explosions++
-		}
+		explosions++
 	}
 
 	d.result = sum
@@ -68,3 +93,9 @@ func (d *SavageDice) Roll() int {
 
 	return sum
 }
+
+// Exploded indica si el dado ha explotado en la última tirada,
+// es decir, si se ha vuelto a tirar al sacar el máximo valor.
+func (d *SavageDice) Exploded() bool {
+	return d.Explosions > 0
+}
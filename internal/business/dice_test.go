@@ -73,10 +73,13 @@ func TestSavageDice(t *testing.T) {
 				maxExplosions: 50,
 			}
 			// Porque el dado es de 1 cara, siempre explotará,
-			// llegando al máximo de 50.
+			// llegando al máximo de 50 explosiones tras la primera tirada.
 			roll := oneSidedDice.Roll()
-			if roll != 50 {
-				t.Fatalf("expected roll to be 50, got %d", roll)
+			if roll != 51 {
+				t.Fatalf("expected roll to be 51, got %d", roll)
+			}
+			if oneSidedDice.Explosions != 50 {
+				t.Fatalf("expected explosions to be 50, got %d", oneSidedDice.Explosions)
 			}
 		})
 	})
@@ -117,3 +120,96 @@ func TestSavageDice(t *testing.T) {
 		}
 	})
 }
+
+// fixedRandomizer es una fuente de números aleatorios que devuelve
+// siempre los valores indicados, en orden y de manera cíclica.
+type fixedRandomizer struct {
+	values []int
+	next   int
+}
+
+func (r *fixedRandomizer) Intn(n int) int {
+	v := r.values[r.next%len(r.values)]
+	r.next++
+	return v % n
+}
+
+func TestSeededDice(t *testing.T) {
+	t.Run("same-seed/same-rolls", func(t *testing.T) {
+		dice1 := &BaseDice{Sides: 20, Rand: NewRandomizer(42)}
+		dice2 := &BaseDice{Sides: 20, Rand: NewRandomizer(42)}
+
+		for i := 0; i < 100; i++ {
+			roll1, roll2 := dice1.Roll(), dice2.Roll()
+			if roll1 != roll2 {
+				t.Fatalf("expected roll %d to be the same for both dice, got %d and %d", i, roll1, roll2)
+			}
+		}
+	})
+
+	t.Run("base-dice/fixed", func(t *testing.T) {
+		dice := &BaseDice{Sides: 6, Rand: &fixedRandomizer{values: []int{0, 5, 2}}}
+
+		for _, expected := range []int{1, 6, 3} {
+			if roll := dice.Roll(); roll != expected {
+				t.Fatalf("expected roll to be %d, got %d", expected, roll)
+			}
+			if dice.Result() != expected {
+				t.Fatalf("expected result to be %d, got %d", expected, dice.Result())
+			}
+		}
+	})
+
+	t.Run("savage-dice/fixed-explosions", func(t *testing.T) {
+		// 6 + 6 + 2: el dado explota dos veces y se detiene en el 2
+		dice := &SavageDice{
+			BaseDice:      BaseDice{Sides: 6, Rand: &fixedRandomizer{values: []int{5, 5, 1, 1}}},
+			maxExplosions: 50,
+		}
+
+		roll := dice.Roll()
+		if roll != 14 {
+			t.Fatalf("expected roll to be 14, got %d", roll)
+		}
+		if dice.Explosions != 2 {
+			t.Fatalf("expected explosions to be 2, got %d", dice.Explosions)
+		}
+		if !dice.Exploded() {
+			t.Fatal("expected dice to have exploded")
+		}
+
+		// la siguiente tirada no explota: 2
+		if roll := dice.Roll(); roll != 2 || dice.Exploded() {
+			t.Fatalf("expected roll to be 2 without exploding, got %d", roll)
+		}
+	})
+
+	t.Run("savage-dice/one-explosion", func(t *testing.T) {
+		// 6 + 6: con un máximo de una explosión, el dado vuelve a tirar una sola vez
+		dice := &SavageDice{
+			BaseDice:      BaseDice{Sides: 6, Rand: &fixedRandomizer{values: []int{5, 5, 1}}},
+			maxExplosions: 1,
+		}
+
+		if roll := dice.Roll(); roll != 12 {
+			t.Fatalf("expected roll to be 12, got %d", roll)
+		}
+		if dice.Explosions != 1 || !dice.Exploded() {
+			t.Fatalf("expected dice to have exploded once, got %d explosions", dice.Explosions)
+		}
+	})
+
+	t.Run("savage-dice/no-explosions", func(t *testing.T) {
+		// sin máximo de explosiones, el dado no vuelve a tirar al sacar el máximo
+		dice := &SavageDice{
+			BaseDice: BaseDice{Sides: 6, Rand: &fixedRandomizer{values: []int{5}}},
+		}
+
+		if roll := dice.Roll(); roll != 6 {
+			t.Fatalf("expected roll to be 6, got %d", roll)
+		}
+		if dice.Exploded() {
+			t.Fatal("expected dice not to explode")
+		}
+	})
+}
@@ -0,0 +1,315 @@
+package business
+
+import (
+	"slices"
+	"sort"
+
+	"pokemon-battle/internal/models"
+)
+
+// doublesSlots es el número de Pokémon de cada bando que combaten a la vez en las batallas dobles.
+const doublesSlots = 2
+
+// spreadMultiplier es el multiplicador de daño de los movimientos que alcanzan
+// a los dos Pokémon rivales en las batallas dobles.
+const spreadMultiplier = 0.75
+
+// FightDoubles libra una batalla doble entre dos equipos: los dos primeros Pokémon
+// de cada equipo combaten a la vez, y cuando uno se debilita entra el siguiente
+// del equipo. Los movimientos alcanzan a uno de los rivales, a los dos o al aliado,
+// según su objetivo, y en cada turno actúan los cuatro Pokémon en orden de iniciativa.
+// La batalla termina cuando uno de los equipos se queda sin Pokémon.
+// En las batallas dobles no hay cambios voluntarios y los Pokémon eligen sus
+// movimientos al azar, por lo que las estrategias se ignoran.
+func FightDoubles(diceSides int, team1 []models.Pokemon, team2 []models.Pokemon, opts ...Option) models.Battle {
+	f := newFight(diceSides, append(opts[:len(opts):len(opts)], WithSwitchStrategies(nil, nil), WithStrategies(nil, nil))...)
+
+	battle := f.newBattle(team1, team2)
+	battle.Format = models.FormatDoubles
+	battle.Team1 = teamIDs(team1)
+	battle.Team2 = teamIDs(team2)
+
+	side1, side2 := newDoublesTeam(team1), newDoublesTeam(team2)
+
+	// Battle continues until one team runs out of Pokemon,
+	// or ends in a draw after the maximum number of turns
+	f.turn = 1
+	if f.cfg.condition != "" {
+		f.setCondition(f.cfg.condition, 0, nil)
+	}
+	for {
+		order := f.initiativeOrder(append(side1.actives(), side2.actives()...))
+
+		for _, c := range order {
+			// the pokemon may have fainted earlier in the turn
+			if c.HP <= 0 {
+				continue
+			}
+
+			own, foes := side1, side2
+			if side2.has(c) {
+				own, foes = side2, side1
+			}
+			if len(foes.actives()) == 0 {
+				break
+			}
+
+			if f.canAct(c) {
+				f.act(c, own, foes)
+			}
+		}
+
+		// At the end of the turn, the status conditions, the field condition,
+		// the abilities and the items of the survivors take effect
+		for _, c := range order {
+			if c.HP > 0 && len(side1.actives()) > 0 && len(side2.actives()) > 0 {
+				f.residual(c)
+				f.conditionResidual(c)
+			}
+		}
+		for _, c := range order {
+			if c.HP > 0 && len(side1.actives()) > 0 && len(side2.actives()) > 0 {
+				f.endOfTurn(c)
+				f.itemEndOfTurn(c)
+			}
+		}
+		f.conditionEndOfTurn()
+
+		for _, c := range order {
+			if c.HP <= 0 {
+				f.faint(c)
+			}
+		}
+		f.refill(side1, side2)
+		f.refill(side2, side1)
+
+		// Determine winner, if one of the teams is left without Pokemon
+		defeated1, defeated2 := side1.defeated(), side2.defeated()
+		if defeated1 || defeated2 {
+			switch {
+			case defeated1 && defeated2:
+				f.draw(side1, side2)
+			case defeated1:
+				battle.WinnerID = side2.actives()[0].ID
+			default:
+				battle.WinnerID = side1.actives()[0].ID
+			}
+			break
+		}
+
+		if f.turn == f.cfg.maxTurns {
+			f.draw(side1, side2)
+			break
+		}
+
+		f.turn++
+	}
+
+	battle.Turns = f.turn
+	battle.Pokemon1Criticals = totalCriticals(side1.members)
+	battle.Pokemon2Criticals = totalCriticals(side2.members)
+	battle.ItemUses = itemUses(append(side1.members, side2.members...))
+	battle.Conditions = f.conditionHistory()
+	battle.Log = f.log
+
+	return battle
+}
+
+// doublesTeam es uno de los bandos de una batalla doble: sus Pokémon en orden
+// de salida y la posición en el equipo de los que ocupan cada puesto de combate,
+// o -1 si el puesto está vacío.
+type doublesTeam struct {
+	members []*Combatant
+	slots   [doublesSlots]int
+}
+
+func newDoublesTeam(pokemons []models.Pokemon) *doublesTeam {
+	t := &doublesTeam{}
+	for _, pokemon := range pokemons {
+		t.members = append(t.members, newCombatant(pokemon))
+	}
+	for slot := range t.slots {
+		t.slots[slot] = -1
+		if slot < len(t.members) {
+			t.slots[slot] = slot
+		}
+	}
+	return t
+}
+
+// actives devuelve los Pokémon del equipo que están combatiendo y no se han debilitado.
+func (t *doublesTeam) actives() []*Combatant {
+	var actives []*Combatant
+	for _, position := range t.slots {
+		if position >= 0 && t.members[position].HP > 0 {
+			actives = append(actives, t.members[position])
+		}
+	}
+	return actives
+}
+
+// has indica si el Pokémon es del equipo.
+func (t *doublesTeam) has(c *Combatant) bool {
+	for _, member := range t.members {
+		if member == c {
+			return true
+		}
+	}
+	return false
+}
+
+// ally devuelve el otro Pokémon del equipo que está combatiendo, o nil si no hay ninguno.
+func (t *doublesTeam) ally(c *Combatant) *Combatant {
+	for _, active := range t.actives() {
+		if active != c {
+			return active
+		}
+	}
+	return nil
+}
+
+// defeated indica si todos los Pokémon del equipo se han debilitado.
+func (t *doublesTeam) defeated() bool {
+	for _, member := range t.members {
+		if member.HP > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// next devuelve la posición del siguiente Pokémon del equipo que puede entrar
+// en combate, o -1 si no queda ninguno.
+func (t *doublesTeam) next() int {
+	for i, member := range t.members {
+		if member.HP > 0 && t.slots[0] != i && t.slots[1] != i {
+			return i
+		}
+	}
+	return -1
+}
+
+// initiativeOrder decide el orden en el que actúan los Pokémon en el turno,
+// de mayor a menor velocidad más la tirada de iniciativa, y registra la
+// iniciativa de cada uno. Los Pokémon empatados vuelven a tirar entre ellos
+// para deshacer el empate, hasta que ninguno tiene el mismo resultado que otro.
+func (f *fight) initiativeOrder(combatants []*Combatant) []*Combatant {
+	rolls := make(map[*Combatant]int, len(combatants))
+	scores := make(map[*Combatant][]int, len(combatants))
+	for _, c := range combatants {
+		rolls[c] = f.initiativeDice.Roll()
+		scores[c] = []int{c.Speed + rolls[c]}
+	}
+
+	for {
+		var tied []*Combatant
+		for _, c := range combatants {
+			for _, other := range combatants {
+				if c != other && slices.Equal(scores[c], scores[other]) {
+					tied = append(tied, c)
+					break
+				}
+			}
+		}
+		if len(tied) == 0 {
+			break
+		}
+		for _, c := range tied {
+			scores[c] = append(scores[c], f.initiativeDice.Roll())
+		}
+	}
+
+	order := append([]*Combatant(nil), combatants...)
+	sort.Slice(order, func(i, j int) bool {
+		return slices.Compare(scores[order[i]], scores[order[j]]) > 0
+	})
+
+	for _, c := range order {
+		f.record(models.BattleEvent{
+			Type:      models.EventInitiative,
+			PokemonID: c.ID,
+			Roll:      rolls[c],
+			HP:        c.HP,
+		})
+	}
+	return order
+}
+
+// act resuelve la acción de un Pokémon en una batalla doble: elige un movimiento
+// y ataca a sus objetivos.
+func (f *fight) act(c *Combatant, own *doublesTeam, foes *doublesTeam) {
+	move, hasMove := f.chooseMove(&c.Pokemon)
+
+	target := models.MoveTargetSingle
+	if hasMove && move.Target != "" {
+		target = move.Target
+	}
+
+	var targets []*Combatant
+	switch target {
+	case models.MoveTargetAllFoes:
+		targets = foes.actives()
+	case models.MoveTargetAlly:
+		// the move fails without an ally
+		if ally := own.ally(c); ally != nil {
+			targets = []*Combatant{ally}
+		}
+	default:
+		targets = foes.actives()
+		if len(targets) > 1 {
+			targets = targets[f.random.Intn(len(targets)):][:1]
+		}
+	}
+
+	modifier := 1.0
+	if len(targets) > 1 {
+		modifier = spreadMultiplier
+	}
+	for _, defender := range targets {
+		f.strike(c, defender, move, hasMove, modifier)
+	}
+}
+
+// refill hace entrar a los siguientes Pokémon del equipo en los puestos
+// de los que se han debilitado, mientras queden Pokémon.
+func (f *fight) refill(t *doublesTeam, opponent *doublesTeam) {
+	for slot, position := range t.slots {
+		if position < 0 || t.members[position].HP > 0 {
+			continue
+		}
+
+		next := t.next()
+		if next < 0 {
+			t.slots[slot] = -1
+			continue
+		}
+		t.slots[slot] = next
+
+		event := models.BattleEvent{
+			Type:       models.EventSwitch,
+			PokemonID:  t.members[next].ID,
+			ReplacedID: t.members[position].ID,
+			Forced:     true,
+			HP:         t.members[next].HP,
+		}
+		if foes := opponent.actives(); len(foes) > 0 {
+			event.TargetID = foes[0].ID
+			event.TargetHP = foes[0].HP
+		}
+		f.record(event)
+	}
+}
+
+// draw registra el final de una batalla doble en empate.
+func (f *fight) draw(side1 *doublesTeam, side2 *doublesTeam) {
+	event := models.BattleEvent{Type: models.EventDraw}
+	if actives := side1.actives(); len(actives) > 0 {
+		event.PokemonID = actives[0].ID
+		event.HP = actives[0].HP
+	}
+	if actives := side2.actives(); len(actives) > 0 {
+		event.TargetID = actives[0].ID
+		event.TargetHP = actives[0].HP
+	}
+	f.record(event)
+}
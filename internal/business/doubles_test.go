@@ -0,0 +1,129 @@
+package business_test
+
+import (
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestFightDoubles(t *testing.T) {
+	t.Run("winner", func(t *testing.T) {
+		team1 := []models.Pokemon{strongPokemon, strongPokemon, strongPokemon}
+		for i := range team1 {
+			team1[i].ID = i + 1
+		}
+		team2 := []models.Pokemon{weakPokemon, weakPokemon, weakPokemon}
+		for i := range team2 {
+			team2[i].ID = i + 4
+		}
+
+		battle := business.FightDoubles(10, team1, team2, business.WithSeed(1))
+
+		if battle.Format != models.FormatDoubles {
+			t.Fatalf("expected format to be doubles, got %s", battle.Format)
+		}
+		if battle.Side(battle.WinnerID) != 1 {
+			t.Fatalf("expected the first team to win, got winner ID %d", battle.WinnerID)
+		}
+		if err := battle.Validate(); err != nil {
+			t.Fatalf("expected battle to be valid, got %v", err)
+		}
+
+		// los cuatro Pokémon tiran la iniciativa en el primer turno
+		var initiatives int
+		for _, event := range battle.Log {
+			if event.Turn == 1 && event.Type == models.EventInitiative {
+				initiatives++
+			}
+		}
+		if initiatives != 4 {
+			t.Fatalf("expected 4 initiative events in the first turn, got %d", initiatives)
+		}
+
+		replay, err := business.Replay(battle)
+		if err != nil {
+			t.Fatalf("expected Replay() to return nil, got %v", err)
+		}
+		if !replay.Matches {
+			t.Fatalf("expected replay to match, got %+v", replay)
+		}
+	})
+
+	t.Run("initiative-ties", func(t *testing.T) {
+		// los cuatro Pokémon son igual de rápidos y el dado de iniciativa tiene
+		// menos caras que Pokémon, por lo que solo los empatados vuelven a tirar
+		team1 := []models.Pokemon{strongPokemon, strongPokemon}
+		team2 := []models.Pokemon{strongPokemon, strongPokemon}
+		for i := range team1 {
+			team1[i].ID = i + 1
+			team2[i].ID = i + 3
+		}
+
+		battle := business.FightDoubles(10, team1, team2, business.WithSeed(1), business.WithInitiativeDice(2))
+
+		var initiatives int
+		for _, event := range battle.Log {
+			if event.Turn == 1 && event.Type == models.EventInitiative {
+				initiatives++
+			}
+		}
+		if initiatives != 4 {
+			t.Fatalf("expected 4 initiative events in the first turn, got %d", initiatives)
+		}
+	})
+
+	t.Run("all-foes", func(t *testing.T) {
+		earthquake := models.Move{ID: 1, Name: "Earthquake", Type: "Ground", Power: 100, Accuracy: 100, Category: models.MoveCategoryPhysical, Target: models.MoveTargetAllFoes}
+
+		team1 := []models.Pokemon{
+			{ID: 1, Name: "Golem", Type: "Rock", HP: 80, Attack: 110, Defense: 130, Speed: 200, Moves: []models.Move{earthquake}},
+			{ID: 2, Name: "Onix", Type: "Rock", HP: 35, Attack: 45, Defense: 160, Speed: 70, Moves: []models.Move{earthquake}},
+		}
+		team2 := []models.Pokemon{
+			{ID: 3, Name: "Snorlax", Type: "Normal", HP: 500, Attack: 0, Defense: 0},
+			{ID: 4, Name: "Chansey", Type: "Normal", HP: 500, Attack: 0, Defense: 0},
+		}
+
+		battle := business.FightDoubles(6, team1, team2, business.WithSeed(1), business.WithMaxTurns(1))
+
+		targets := map[int]bool{}
+		for _, event := range battle.Log {
+			if event.Type == models.EventAttack && event.PokemonID == 1 {
+				targets[event.TargetID] = true
+			}
+		}
+		if !targets[3] || !targets[4] {
+			t.Fatalf("expected Earthquake to hit both foes, got %v", targets)
+		}
+	})
+
+	t.Run("ally", func(t *testing.T) {
+		toxicAlly := toxic
+		toxicAlly.Target = models.MoveTargetAlly
+
+		team1 := []models.Pokemon{
+			{ID: 1, Name: "Ekans", Type: "Poison", HP: 35, Attack: 60, Defense: 44, Moves: []models.Move{toxicAlly}},
+			{ID: 2, Name: "Snorlax", Type: "Normal", HP: 160, Attack: 0, Defense: 65},
+		}
+		team2 := []models.Pokemon{
+			{ID: 3, Name: "Chansey", Type: "Normal", HP: 250, Attack: 0, Defense: 10},
+			{ID: 4, Name: "Blissey", Type: "Normal", HP: 255, Attack: 0, Defense: 10},
+		}
+
+		battle := business.FightDoubles(6, team1, team2, business.WithSeed(1), business.WithMaxTurns(1))
+
+		var poisoned bool
+		for _, event := range battle.Log {
+			if event.Type == models.EventStatus {
+				if event.PokemonID != 2 {
+					t.Fatalf("expected only the ally to be poisoned, got %+v", event)
+				}
+				poisoned = true
+			}
+		}
+		if !poisoned {
+			t.Fatal("expected the ally to be poisoned")
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+
+	"pokemon-battle/internal/models"
+)
+
+// ErrEvolutionNotReady se devuelve cuando un Pokémon no cumple la condición de su evolución.
+var ErrEvolutionNotReady = errors.New("evolution condition not met")
+
+// Evolve evoluciona un Pokémon a la especie de la entrada de destino de una
+// evolución, si cumple su condición con su nivel y sus victorias. El Pokémon
+// conserva su ID, su nivel, su experiencia y sus movimientos, por lo que sus
+// batallas siguen siendo suyas, y toma el nombre, el tipo y las estadísticas
+// de la especie evolucionada. Las estadísticas de la entrada de destino son
+// las de su nivel, y crecen con la especie hasta el nivel del Pokémon.
+func Evolve(pokemon models.Pokemon, target models.Pokemon, evolution models.Evolution, wins int) (models.Pokemon, error) {
+	if level := levelOf(pokemon); level < evolution.MinLevel {
+		return models.Pokemon{}, fmt.Errorf("%w: level %d of %d", ErrEvolutionNotReady, level, evolution.MinLevel)
+	}
+	if wins < evolution.MinWins {
+		return models.Pokemon{}, fmt.Errorf("%w: %d of %d wins", ErrEvolutionNotReady, wins, evolution.MinWins)
+	}
+
+	growth := DefaultGrowthTable().Of(target.Name)
+	levels := max(levelOf(pokemon)-levelOf(target), 0)
+
+	evolved := pokemon
+	evolved.Name = target.Name
+	evolved.Type = target.Type
+	evolved.HP = target.HP + levels*growth.HP
+	evolved.Attack = target.Attack + levels*growth.Attack
+	evolved.Defense = target.Defense + levels*growth.Defense
+	evolved.Speed = target.Speed
+	return evolved, nil
+}
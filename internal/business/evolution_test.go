@@ -0,0 +1,58 @@
+package business_test
+
+import (
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestEvolve(t *testing.T) {
+	charmander := models.Pokemon{ID: 2, Name: "Charmander", Type: "Fire", HP: 90, Attack: 62, Defense: 58, Speed: 65, Level: 36, Experience: 56000}
+	charizard := models.Pokemon{ID: 10, Name: "Charizard", Type: "Fire/Flying", HP: 105, Attack: 84, Defense: 78, Speed: 100, Level: 30}
+	evolution := models.Evolution{FromPokemonID: 2, ToPokemonID: 10, MinLevel: 36, MinWins: 3}
+
+	t.Run("success", func(t *testing.T) {
+		evolved, err := business.Evolve(charmander, charizard, evolution, 3)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// Charizard grows 3/3/2 per level, for the 6 levels over the entry
+		expected := models.Pokemon{ID: 2, Name: "Charizard", Type: "Fire/Flying", HP: 123, Attack: 102, Defense: 90, Speed: 100, Level: 36, Experience: 56000}
+		if evolved.ID != expected.ID || evolved.Name != expected.Name || evolved.Type != expected.Type ||
+			evolved.HP != expected.HP || evolved.Attack != expected.Attack || evolved.Defense != expected.Defense ||
+			evolved.Speed != expected.Speed || evolved.Level != expected.Level || evolved.Experience != expected.Experience {
+			t.Fatalf("expected %+v, got %+v", expected, evolved)
+		}
+	})
+
+	t.Run("target of a higher level", func(t *testing.T) {
+		target := charizard
+		target.Level = 50
+
+		evolved, err := business.Evolve(charmander, target, evolution, 3)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if evolved.HP != target.HP || evolved.Attack != target.Attack || evolved.Defense != target.Defense {
+			t.Fatalf("expected the stats of the entry, got %+v", evolved)
+		}
+	})
+
+	t.Run("level too low", func(t *testing.T) {
+		young := charmander
+		young.Level = 20
+
+		if _, err := business.Evolve(young, charizard, evolution, 3); !errors.Is(err, business.ErrEvolutionNotReady) {
+			t.Fatalf("expected ErrEvolutionNotReady, got %v", err)
+		}
+	})
+
+	t.Run("not enough wins", func(t *testing.T) {
+		if _, err := business.Evolve(charmander, charizard, evolution, 2); !errors.Is(err, business.ErrEvolutionNotReady) {
+			t.Fatalf("expected ErrEvolutionNotReady, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package business
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"pokemon-battle/internal/models"
+)
+
+// Curvas de experiencia: la experiencia necesaria para alcanzar cada nivel.
+const (
+	CurveFast       = "fast"        // 4n³/5
+	CurveMediumFast = "medium-fast" // n³
+	CurveMediumSlow = "medium-slow" // 6n³/5 - 15n² + 100n - 140
+	CurveSlow       = "slow"        // 5n³/4
+)
+
+//go:embed growth.json
+var defaultGrowthJSON []byte
+
+// Growth es el crecimiento de una especie: su curva de experiencia y lo que
+// suben sus puntos de salud, su ataque y su defensa en cada nivel.
+type Growth struct {
+	Curve   string `json:"curve"`
+	HP      int    `json:"hp"`
+	Attack  int    `json:"attack"`
+	Defense int    `json:"defense"`
+}
+
+// GrowthTable guarda el crecimiento de cada especie, por nombre, y el
+// crecimiento por defecto de las especies que no aparecen en la tabla.
+type GrowthTable struct {
+	Default Growth            `json:"default"`
+	Species map[string]Growth `json:"species"`
+}
+
+// DefaultGrowthTable devuelve la tabla de crecimiento incluida en el binario.
+// La tabla se carga una única vez y se comparte, por lo que no debe modificarse.
+var DefaultGrowthTable = sync.OnceValue(func() GrowthTable {
+	table, err := LoadGrowthTable(bytes.NewReader(defaultGrowthJSON))
+	if err != nil {
+		// la tabla embebida forma parte del código, por lo que un error
+		// aquí es un error de programación.
+		panic(err)
+	}
+	return table
+})
+
+// LoadGrowthTable lee una tabla de crecimiento en formato JSON, con la forma
+// {"default": {"curve": "medium-fast", "hp": 2, ...}, "species": {"Pikachu": {...}}}.
+func LoadGrowthTable(r io.Reader) (GrowthTable, error) {
+	var table GrowthTable
+	if err := json.NewDecoder(r).Decode(&table); err != nil {
+		return GrowthTable{}, fmt.Errorf("invalid growth table: %w", err)
+	}
+
+	growths := map[string]Growth{"default": table.Default}
+	for species, growth := range table.Species {
+		growths[species] = growth
+	}
+	for species, growth := range growths {
+		if !isValidCurve(growth.Curve) {
+			return GrowthTable{}, fmt.Errorf("invalid growth table: unknown curve %q for %s", growth.Curve, species)
+		}
+		if growth.HP < 0 || growth.Attack < 0 || growth.Defense < 0 {
+			return GrowthTable{}, fmt.Errorf("invalid growth table: negative growth for %s", species)
+		}
+	}
+
+	return table, nil
+}
+
+// Of devuelve el crecimiento de una especie, o el crecimiento por defecto.
+func (t GrowthTable) Of(species string) Growth {
+	if growth, ok := t.Species[species]; ok {
+		return growth
+	}
+	return t.Default
+}
+
+func isValidCurve(curve string) bool {
+	return curve == CurveFast || curve == CurveMediumFast || curve == CurveMediumSlow || curve == CurveSlow
+}
+
+// ExperienceForLevel devuelve la experiencia total necesaria para alcanzar un
+// nivel con una curva de experiencia. El nivel 1 no necesita experiencia.
+func ExperienceForLevel(curve string, level int) int {
+	if level <= 1 {
+		return 0
+	}
+	level = min(level, models.MaxLevel)
+
+	n := float64(level)
+	var experience float64
+	switch curve {
+	case CurveFast:
+		experience = 4 * n * n * n / 5
+	case CurveMediumSlow:
+		experience = 6*n*n*n/5 - 15*n*n + 100*n - 140
+	case CurveSlow:
+		experience = 5 * n * n * n / 4
+	default:
+		experience = n * n * n
+	}
+	return int(experience)
+}
+
+// ExperienceYield devuelve la experiencia que gana un Pokémon al derrotar a otro.
+// Crece con las estadísticas y el nivel del derrotado, y es mayor cuando el
+// derrotado tiene más nivel que el ganador y menor cuando tiene menos.
+func ExperienceYield(winner, loser models.Pokemon) int {
+	base := float64(loser.HP+loser.Attack+loser.Defense+loser.Speed) / 3
+	winnerLevel, loserLevel := float64(levelOf(winner)), float64(levelOf(loser))
+
+	scale := math.Pow((2*loserLevel+10)/(loserLevel+winnerLevel+10), 2.5)
+	return int(base*loserLevel/5*scale) + 1
+}
+
+// GrantExperience reparte la experiencia de una batalla entre los Pokémon del
+// equipo ganador, a partes iguales, y les sube de nivel con el crecimiento de
+// su especie. Devuelve los ganadores actualizados y sus subidas de nivel, a partir
+// de su estado actual. Los empates no dan experiencia.
+func GrantExperience(battle models.Battle, pokemons map[int]models.Pokemon) ([]models.Pokemon, []models.LevelUp) {
+	if battle.IsDraw() {
+		return nil, nil
+	}
+
+	winners, losers := []int{battle.Pokemon1ID}, []int{battle.Pokemon2ID}
+	if battle.IsTeamBattle() {
+		winners, losers = battle.Team1, battle.Team2
+	}
+	if battle.Side(battle.WinnerID) == 2 {
+		winners, losers = losers, winners
+	}
+
+	now := time.Now()
+	var updated []models.Pokemon
+	var levelUps []models.LevelUp
+	for _, id := range winners {
+		winner, ok := pokemons[id]
+		if !ok {
+			continue
+		}
+
+		experience := 0
+		for _, loserID := range losers {
+			if loser, ok := pokemons[loserID]; ok {
+				experience += ExperienceYield(winner, loser)
+			}
+		}
+		winner.Experience += max(experience/len(winners), 1)
+
+		for _, level := range levelUp(&winner, DefaultGrowthTable().Of(winner.Name)) {
+			levelUps = append(levelUps, models.LevelUp{
+				PokemonID: winner.ID,
+				BattleID:  battle.ID,
+				Level:     level.Level,
+				HP:        level.HP,
+				Attack:    level.Attack,
+				Defense:   level.Defense,
+				CreatedAt: now,
+			})
+		}
+		updated = append(updated, winner)
+	}
+
+	return updated, levelUps
+}
+
+// levelUp sube de nivel al Pokémon mientras tenga experiencia suficiente, y
+// devuelve su estado en cada nuevo nivel. Al llegar al nivel máximo, la
+// experiencia deja de acumularse.
+func levelUp(pokemon *models.Pokemon, growth Growth) []models.Pokemon {
+	pokemon.Level = levelOf(*pokemon)
+
+	var levels []models.Pokemon
+	for pokemon.Level < models.MaxLevel && pokemon.Experience >= ExperienceForLevel(growth.Curve, pokemon.Level+1) {
+		pokemon.Level++
+		pokemon.HP += growth.HP
+		pokemon.Attack += growth.Attack
+		pokemon.Defense += growth.Defense
+		levels = append(levels, *pokemon)
+	}
+
+	if pokemon.Level == models.MaxLevel {
+		pokemon.Experience = min(pokemon.Experience, ExperienceForLevel(growth.Curve, models.MaxLevel))
+	}
+	return levels
+}
+
+// levelOf devuelve el nivel de un Pokémon, que es 1 si no tiene nivel.
+func levelOf(pokemon models.Pokemon) int {
+	return max(pokemon.Level, 1)
+}
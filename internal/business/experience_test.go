@@ -0,0 +1,121 @@
+package business_test
+
+import (
+	"strings"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestExperienceForLevel(t *testing.T) {
+	testCases := []struct {
+		curve    string
+		level    int
+		expected int
+	}{
+		{curve: business.CurveMediumFast, level: 1, expected: 0},
+		{curve: business.CurveMediumFast, level: 10, expected: 1000},
+		{curve: business.CurveFast, level: 100, expected: 800000},
+		{curve: business.CurveMediumSlow, level: 100, expected: 1059860},
+		{curve: business.CurveSlow, level: 100, expected: 1250000},
+	}
+
+	for _, testCase := range testCases {
+		experience := business.ExperienceForLevel(testCase.curve, testCase.level)
+		if experience != testCase.expected {
+			t.Errorf("expected %d experience for level %d of the %s curve, got %d", testCase.expected, testCase.level, testCase.curve, experience)
+		}
+	}
+}
+
+func TestExperienceYield(t *testing.T) {
+	weak := models.Pokemon{HP: 30, Attack: 30, Defense: 30, Level: 5}
+	strong := models.Pokemon{HP: 100, Attack: 100, Defense: 100, Level: 5}
+
+	if business.ExperienceYield(weak, strong) <= business.ExperienceYield(strong, weak) {
+		t.Fatal("expected more experience for defeating a stronger pokemon")
+	}
+
+	higher := strong
+	higher.Level = 20
+	if business.ExperienceYield(weak, higher) <= business.ExperienceYield(weak, strong) {
+		t.Fatal("expected more experience for defeating a pokemon of a higher level")
+	}
+}
+
+func TestGrantExperience(t *testing.T) {
+	t.Run("level-up", func(t *testing.T) {
+		pikachu := models.Pokemon{ID: 1, Name: "Pikachu", HP: 35, Attack: 55, Defense: 40, Level: 1}
+		mewtwo := models.Pokemon{ID: 2, Name: "Mewtwo", HP: 106, Attack: 110, Defense: 90, Speed: 130, Level: 50}
+		battle := models.Battle{ID: 7, Pokemon1ID: 1, Pokemon2ID: 2, WinnerID: 1}
+
+		updated, levelUps := business.GrantExperience(battle, map[int]models.Pokemon{1: pikachu, 2: mewtwo})
+		if len(updated) != 1 || updated[0].ID != 1 {
+			t.Fatalf("expected only the winner to be updated, got %+v", updated)
+		}
+
+		winner := updated[0]
+		if winner.Level <= 1 || len(levelUps) != winner.Level-1 {
+			t.Fatalf("expected a level-up per new level, got level %d and %d level-ups", winner.Level, len(levelUps))
+		}
+
+		// Pikachu gana 2 de HP, 2 de ataque y 1 de defensa por nivel
+		gained := winner.Level - 1
+		if winner.HP != 35+2*gained || winner.Attack != 55+2*gained || winner.Defense != 40+gained {
+			t.Fatalf("expected the stats to grow with the Pikachu growth, got %+v", winner)
+		}
+		last := levelUps[len(levelUps)-1]
+		if last.BattleID != 7 || last.Level != winner.Level || last.HP != winner.HP {
+			t.Fatalf("expected the last level-up to match the winner, got %+v", last)
+		}
+		if winner.Experience < business.ExperienceForLevel(business.CurveMediumFast, winner.Level) {
+			t.Fatalf("expected enough experience for level %d, got %d", winner.Level, winner.Experience)
+		}
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		pokemons := map[int]models.Pokemon{}
+		for id := 1; id <= 4; id++ {
+			pokemons[id] = models.Pokemon{ID: id, Name: "Rattata", HP: 30, Attack: 56, Defense: 35, Level: 10, Experience: 1000}
+		}
+		battle := models.Battle{Pokemon1ID: 1, Pokemon2ID: 3, WinnerID: 4, Team1: []int{1, 2}, Team2: []int{3, 4}}
+
+		updated, _ := business.GrantExperience(battle, pokemons)
+		if len(updated) != 2 || updated[0].ID != 3 || updated[1].ID != 4 {
+			t.Fatalf("expected the second team to gain experience, got %+v", updated)
+		}
+		if updated[0].Experience != updated[1].Experience || updated[0].Experience <= 1000 {
+			t.Fatalf("expected the experience to be shared equally, got %+v", updated)
+		}
+	})
+
+	t.Run("draw", func(t *testing.T) {
+		battle := models.Battle{Pokemon1ID: 1, Pokemon2ID: 2}
+		updated, levelUps := business.GrantExperience(battle, map[int]models.Pokemon{1: {ID: 1}, 2: {ID: 2}})
+		if updated != nil || levelUps != nil {
+			t.Fatalf("expected no experience for a draw, got %+v and %+v", updated, levelUps)
+		}
+	})
+
+	t.Run("max-level", func(t *testing.T) {
+		mew := models.Pokemon{ID: 1, Name: "Mew", HP: 300, Attack: 300, Defense: 300, Level: models.MaxLevel, Experience: business.ExperienceForLevel(business.CurveMediumSlow, models.MaxLevel)}
+		battle := models.Battle{Pokemon1ID: 1, Pokemon2ID: 2, WinnerID: 1}
+
+		updated, levelUps := business.GrantExperience(battle, map[int]models.Pokemon{1: mew, 2: strongPokemon})
+		if len(levelUps) != 0 || updated[0].Level != models.MaxLevel || updated[0].Experience != mew.Experience {
+			t.Fatalf("expected a pokemon at the maximum level to stay the same, got %+v", updated[0])
+		}
+	})
+}
+
+func TestLoadGrowthTable(t *testing.T) {
+	if growth := business.DefaultGrowthTable().Of("Missingno"); growth.Curve != business.CurveMediumFast {
+		t.Fatalf("expected the default growth for an unknown species, got %+v", growth)
+	}
+
+	_, err := business.LoadGrowthTable(strings.NewReader(`{"default": {"curve": "erratic"}}`))
+	if err == nil {
+		t.Fatal("expected an error for an unknown curve")
+	}
+}
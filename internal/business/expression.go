@@ -0,0 +1,330 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"pokemon-battle/internal/models"
+)
+
+const (
+	// maxExpressionDice es el número máximo de dados de un término,
+	// y maxExpressionSides el máximo de caras de cada dado.
+	maxExpressionDice  = 100
+	maxExpressionSides = 1000
+
+	// maxExpressionTerms es el número máximo de términos de una expresión.
+	maxExpressionTerms = 20
+)
+
+// ErrInvalidDiceExpression se devuelve cuando una expresión de dados no es válida.
+var ErrInvalidDiceExpression = errors.New("invalid dice expression")
+
+// diceTerm es un término de una expresión de dados: un grupo de dados
+// iguales, como "4d6kh3", o una constante, como "3".
+type diceTerm struct {
+	sign int // 1 o -1
+
+	// count y sides son el número de dados y sus caras.
+	// Un término sin caras es una constante de valor count.
+	count int
+	sides int
+
+	// explode indica si los dados explotan al sacar el máximo valor
+	explode bool
+
+	// keepHighest y keepLowest indican cuántos dados se conservan,
+	// los más altos o los más bajos. Con 0 se conservan todos.
+	keepHighest int
+	keepLowest  int
+}
+
+func (t diceTerm) String() string {
+	if t.sides == 0 {
+		return strconv.Itoa(t.count)
+	}
+
+	s := fmt.Sprintf("%dd%d", t.count, t.sides)
+	if t.explode {
+		s += "!"
+	}
+	if t.keepHighest > 0 {
+		s += "kh" + strconv.Itoa(t.keepHighest)
+	}
+	if t.keepLowest > 0 {
+		s += "kl" + strconv.Itoa(t.keepLowest)
+	}
+	return s
+}
+
+// DiceExpression es una expresión de dados en notación estándar, como
+// "2d6+3", "1d20!" (dado explosivo), "4d6kh3" (se conservan los 3 más altos),
+// "4d6kl1" (se conserva el más bajo) o "1d8+1d6". Implementa la interfaz Dice,
+// de manera que puede usarse como dado de ataque en las batallas.
+// Si no se define la fuente de números aleatorios, se usa la fuente global.
+type DiceExpression struct {
+	Rand Randomizer
+
+	// MaxExplosions es el número máximo de explosiones de los dados explosivos.
+	MaxExplosions int
+
+	terms    []diceTerm
+	result   int
+	rolls    []models.DiceTermRoll
+	exploded bool
+}
+
+// ParseDice interpreta una expresión de dados. No distingue mayúsculas
+// de minúsculas e ignora los espacios, y "d6" equivale a "1d6".
+func ParseDice(expression string) (*DiceExpression, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(expression), ""))
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidDiceExpression)
+	}
+
+	e := &DiceExpression{MaxExplosions: DefaultMaxExplosions}
+
+	sign := 1
+	if s[0] == '-' || s[0] == '+' {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+		if s == "" {
+			return nil, fmt.Errorf("%w: expression has no terms", ErrInvalidDiceExpression)
+		}
+	}
+	for len(s) > 0 {
+		if len(e.terms) == maxExpressionTerms {
+			return nil, fmt.Errorf("%w: more than %d terms", ErrInvalidDiceExpression, maxExpressionTerms)
+		}
+
+		end := strings.IndexAny(s, "+-")
+		if end == -1 {
+			end = len(s)
+		}
+
+		term, err := parseDiceTerm(s[:end])
+		if err != nil {
+			return nil, err
+		}
+		term.sign = sign
+		e.terms = append(e.terms, term)
+
+		if end == len(s) {
+			break
+		}
+
+		sign = 1
+		if s[end] == '-' {
+			sign = -1
+		}
+		s = s[end+1:]
+		if s == "" {
+			return nil, fmt.Errorf("%w: expression ends with an operator", ErrInvalidDiceExpression)
+		}
+	}
+
+	return e, nil
+}
+
+// parseDiceTerm interpreta un término sin signo, como "3", "d6" o "4d6!kh3".
+func parseDiceTerm(s string) (diceTerm, error) {
+	invalid := func(reason string) (diceTerm, error) {
+		return diceTerm{}, fmt.Errorf("%w: %q %s", ErrInvalidDiceExpression, s, reason)
+	}
+
+	if s == "" {
+		return invalid("is empty")
+	}
+
+	count, rest := leadingNumber(s)
+
+	// constante
+	if rest == "" {
+		if count > maxExpressionDice*maxExpressionSides {
+			return invalid("is too large")
+		}
+		return diceTerm{count: count}, nil
+	}
+
+	if rest[0] != 'd' {
+		return invalid("is not a number nor a dice")
+	}
+	if count == 0 {
+		if rest != s {
+			return invalid("must roll at least one dice")
+		}
+		count = 1
+	}
+
+	sides, rest := leadingNumber(rest[1:])
+	if sides == 0 {
+		return invalid("must have at least one side")
+	}
+
+	term := diceTerm{count: count, sides: sides}
+
+	if strings.HasPrefix(rest, "!") {
+		term.explode = true
+		rest = rest[1:]
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "kh"):
+		term.keepHighest, rest = leadingNumber(rest[2:])
+		if term.keepHighest == 0 {
+			return invalid("must keep at least one dice")
+		}
+	case strings.HasPrefix(rest, "kl"):
+		term.keepLowest, rest = leadingNumber(rest[2:])
+		if term.keepLowest == 0 {
+			return invalid("must keep at least one dice")
+		}
+	}
+
+	if rest != "" {
+		return invalid("has unexpected characters")
+	}
+	if term.count > maxExpressionDice {
+		return invalid(fmt.Sprintf("cannot roll more than %d dice", maxExpressionDice))
+	}
+	if term.sides > maxExpressionSides {
+		return invalid(fmt.Sprintf("cannot have more than %d sides", maxExpressionSides))
+	}
+	if term.keepHighest > term.count || term.keepLowest > term.count {
+		return invalid("cannot keep more dice than rolled")
+	}
+
+	return term, nil
+}
+
+// leadingNumber separa el número al principio de s del resto de la cadena.
+// Si s no empieza por un número, devuelve 0. Los números que no caben en un int
+// se sustituyen por uno mayor que todos los máximos, para que sean rechazados.
+func leadingNumber(s string) (int, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return 0, s
+	}
+
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		n = maxExpressionDice*maxExpressionSides + 1
+	}
+	return n, s[i:]
+}
+
+// String devuelve la expresión en su forma normalizada, como "1d8+1d6-2".
+func (e *DiceExpression) String() string {
+	var b strings.Builder
+	for i, term := range e.terms {
+		if term.sign < 0 {
+			b.WriteString("-")
+		} else if i > 0 {
+			b.WriteString("+")
+		}
+		b.WriteString(term.String())
+	}
+	return b.String()
+}
+
+// Roll tira todos los dados de la expresión y devuelve el total.
+func (e *DiceExpression) Roll() int {
+	e.result = 0
+	e.exploded = false
+	e.rolls = make([]models.DiceTermRoll, 0, len(e.terms))
+
+	for _, term := range e.terms {
+		termRoll := e.rollTerm(term)
+		e.rolls = append(e.rolls, termRoll)
+		e.result += termRoll.Total
+	}
+
+	return e.result
+}
+
+// rollTerm tira los dados de un término, descartando los que no se conservan.
+func (e *DiceExpression) rollTerm(term diceTerm) models.DiceTermRoll {
+	termRoll := models.DiceTermRoll{Term: term.String()}
+
+	if term.sides == 0 {
+		termRoll.Total = term.sign * term.count
+		return termRoll
+	}
+
+	maxExplosions := 0
+	if term.explode {
+		maxExplosions = e.MaxExplosions
+	}
+
+	rolls := make([]int, term.count)
+	for i := range rolls {
+		dice := &SavageDice{
+			BaseDice: BaseDice{
+				Sides: term.sides,
+				Rand:  e.Rand,
+			},
+			maxExplosions: maxExplosions,
+		}
+		rolls[i] = dice.Roll()
+		if dice.Exploded() {
+			e.exploded = true
+		}
+	}
+
+	kept := rolls
+	if term.keepHighest > 0 || term.keepLowest > 0 {
+		sorted := append([]int(nil), rolls...)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		if term.keepHighest > 0 {
+			kept, termRoll.Dropped = sorted[:term.keepHighest], sorted[term.keepHighest:]
+		} else {
+			split := len(sorted) - term.keepLowest
+			kept, termRoll.Dropped = sorted[split:], sorted[:split]
+		}
+	}
+
+	termRoll.Rolls = rolls
+	for _, roll := range kept {
+		termRoll.Total += term.sign * roll
+	}
+	return termRoll
+}
+
+// Result devuelve el resultado total de la última tirada.
+func (e *DiceExpression) Result() int {
+	return e.result
+}
+
+// Exploded indica si alguno de los dados explosivos ha explotado en la última tirada.
+func (e *DiceExpression) Exploded() bool {
+	return e.exploded
+}
+
+// LastRoll devuelve el detalle de la última tirada: los dados de cada término,
+// los descartados y el total.
+func (e *DiceExpression) LastRoll() models.DiceRoll {
+	return models.DiceRoll{
+		Expression: e.String(),
+		Terms:      e.rolls,
+		Total:      e.result,
+	}
+}
+
+// withRand devuelve una copia de la expresión que usa la fuente de números
+// aleatorios y el máximo de explosiones indicados, de manera que una misma
+// expresión puede compartirse entre batallas concurrentes.
+func (e *DiceExpression) withRand(random Randomizer, maxExplosions int) *DiceExpression {
+	return &DiceExpression{
+		Rand:          random,
+		MaxExplosions: maxExplosions,
+		terms:         e.terms,
+	}
+}
@@ -0,0 +1,166 @@
+package business_test
+
+import (
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestParseDice(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		expected   string
+		min, max   int
+	}{
+		{name: "single", expression: "1d6", expected: "1d6", min: 1, max: 6},
+		{name: "implicit-count", expression: "d20", expected: "1d20", min: 1, max: 20},
+		{name: "modifier", expression: "2d6+3", expected: "2d6+3", min: 5, max: 15},
+		{name: "negative-modifier", expression: "1d8-1", expected: "1d8-1", min: 0, max: 7},
+		{name: "sum", expression: "1d8+1d6", expected: "1d8+1d6", min: 2, max: 14},
+		{name: "keep-highest", expression: "4d6kh3", expected: "4d6kh3", min: 3, max: 18},
+		{name: "keep-lowest", expression: "2d20kl1", expected: "2d20kl1", min: 1, max: 20},
+		{name: "spaces-and-case", expression: " 2D6 + 3 ", expected: "2d6+3", min: 5, max: 15},
+		{name: "constant", expression: "5", expected: "5", min: 5, max: 5},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dice, err := business.ParseDice(testCase.expression)
+			if err != nil {
+				t.Fatalf("expected ParseDice() to return nil, got %v", err)
+			}
+
+			if dice.String() != testCase.expected {
+				t.Fatalf("expected expression to be %s, got %s", testCase.expected, dice.String())
+			}
+
+			for i := 0; i < 100; i++ {
+				roll := dice.Roll()
+				if roll < testCase.min || roll > testCase.max {
+					t.Fatalf("expected roll to be between %d and %d, got %d", testCase.min, testCase.max, roll)
+				}
+				if dice.Result() != roll {
+					t.Fatalf("expected result to be %d, got %d", roll, dice.Result())
+				}
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, expression := range []string{"", "-", "+", "d", "2d", "0d6", "1d0", "2d6+", "abc", "1d6x", "4d6kh5", "4d6kh0", "1000d6", "1d100000", "99999999999999999999"} {
+			_, err := business.ParseDice(expression)
+			if !errors.Is(err, business.ErrInvalidDiceExpression) {
+				t.Fatalf("expected ParseDice(%q) to return ErrInvalidDiceExpression, got %v", expression, err)
+			}
+		}
+	})
+}
+
+func TestDiceExpression(t *testing.T) {
+	var _ business.Dice = &business.DiceExpression{}
+
+	t.Run("last-roll", func(t *testing.T) {
+		dice, err := business.ParseDice("4d6kh3+2")
+		if err != nil {
+			t.Fatalf("expected ParseDice() to return nil, got %v", err)
+		}
+		dice.Rand = business.NewRandomizer(42)
+
+		total := dice.Roll()
+		roll := dice.LastRoll()
+
+		if roll.Expression != "4d6kh3+2" || roll.Total != total {
+			t.Fatalf("expected roll of 4d6kh3+2 with total %d, got %+v", total, roll)
+		}
+		if len(roll.Terms) != 2 {
+			t.Fatalf("expected 2 terms, got %d", len(roll.Terms))
+		}
+		if len(roll.Terms[0].Rolls) != 4 || len(roll.Terms[0].Dropped) != 1 {
+			t.Fatalf("expected 4 rolls and 1 dropped, got %+v", roll.Terms[0])
+		}
+
+		sum := 0
+		for _, r := range roll.Terms[0].Rolls {
+			sum += r
+		}
+		if dropped := roll.Terms[0].Dropped[0]; roll.Terms[0].Total != sum-dropped {
+			t.Fatalf("expected term total to be %d, got %d", sum-dropped, roll.Terms[0].Total)
+		}
+		if roll.Terms[1].Total != 2 {
+			t.Fatalf("expected constant term to be 2, got %d", roll.Terms[1].Total)
+		}
+	})
+
+	t.Run("exploding", func(t *testing.T) {
+		// un dado de una cara siempre explota, hasta el máximo de explosiones
+		// tras la primera tirada
+		dice, err := business.ParseDice("1d1!")
+		if err != nil {
+			t.Fatalf("expected ParseDice() to return nil, got %v", err)
+		}
+		dice.MaxExplosions = 5
+
+		if roll := dice.Roll(); roll != 6 {
+			t.Fatalf("expected roll to be 6, got %d", roll)
+		}
+		if !dice.Exploded() {
+			t.Fatal("expected dice to have exploded")
+		}
+
+		// sin "!" el dado no explota
+		dice, err = business.ParseDice("1d1")
+		if err != nil {
+			t.Fatalf("expected ParseDice() to return nil, got %v", err)
+		}
+		if roll := dice.Roll(); roll != 1 || dice.Exploded() {
+			t.Fatalf("expected roll to be 1 without exploding, got %d", roll)
+		}
+	})
+
+	t.Run("seeded", func(t *testing.T) {
+		dice1, _ := business.ParseDice("3d20+1d8")
+		dice2, _ := business.ParseDice("3d20+1d8")
+		dice1.Rand = business.NewRandomizer(7)
+		dice2.Rand = business.NewRandomizer(7)
+
+		for i := 0; i < 100; i++ {
+			if roll1, roll2 := dice1.Roll(), dice2.Roll(); roll1 != roll2 {
+				t.Fatalf("expected roll %d to be the same for both dice, got %d and %d", i, roll1, roll2)
+			}
+		}
+	})
+}
+
+func TestFight_attackDice(t *testing.T) {
+	dice, err := business.ParseDice("2d6+3")
+	if err != nil {
+		t.Fatalf("expected ParseDice() to return nil, got %v", err)
+	}
+
+	battle := business.Fight(6, strongPokemon, weakPokemon, business.WithAttackDice(dice), business.WithSeed(3))
+
+	if battle.Settings.DiceExpression != "2d6+3" {
+		t.Fatalf("expected dice expression to be 2d6+3, got %s", battle.Settings.DiceExpression)
+	}
+
+	for _, event := range battle.Log {
+		if event.Type != models.EventAttack {
+			continue
+		}
+		if event.Roll < 5 || event.Roll > 15 {
+			t.Fatalf("expected attack roll to be between 5 and 15, got %d", event.Roll)
+		}
+	}
+
+	// la batalla debe poder repetirse con la misma expresión de dados
+	replay, err := business.Replay(battle)
+	if err != nil {
+		t.Fatalf("expected Replay() to return nil, got %v", err)
+	}
+	if !replay.Matches {
+		t.Fatalf("expected replay to match, got %+v", replay)
+	}
+}
@@ -0,0 +1,99 @@
+package business
+
+import (
+	"pokemon-battle/internal/models"
+)
+
+// useItem registra que se ha activado el objeto de un Pokémon, con el HP
+// recuperado, y lo gasta si es consumible.
+func (f *fight) useItem(c *Combatant, healed int) {
+	c.itemUse.Uses++
+	c.itemUse.Healed += healed
+
+	f.record(models.BattleEvent{
+		Type:      models.EventItem,
+		PokemonID: c.ID,
+		Item:      c.item.Name,
+		Healed:    healed,
+		HP:        c.HP,
+	})
+
+	if c.item.Consumable {
+		c.itemUse.Consumed = true
+		c.item = nil
+	}
+}
+
+// heal recupera HP de un Pokémon, sin superar su HP máximo, y devuelve el HP recuperado.
+func heal(c *Combatant, amount int) int {
+	healed := max(min(amount, c.maxHP-c.HP), 0)
+	c.HP += healed
+	return healed
+}
+
+// itemBeforeAttack aplica el objeto del atacante que sube su ataque.
+func (f *fight) itemBeforeAttack(attack *Attack) {
+	c := attack.Attacker
+	if c.item == nil || c.item.Effect != models.ItemEffectAttackBoost {
+		return
+	}
+
+	attack.AttackStat = attack.AttackStat * (100 + c.item.Value) / 100
+	f.useItem(c, 0)
+}
+
+// itemAfterDamage aplica el objeto que cura a un Pokémon que ha perdido HP,
+// si su HP ha bajado del umbral del objeto. Los Pokémon debilitados no lo usan.
+func (f *fight) itemAfterDamage(c *Combatant) {
+	if c.item == nil || c.item.Effect != models.ItemEffectHeal || c.HP <= 0 {
+		return
+	}
+	if c.HP*100 > c.maxHP*c.item.Threshold {
+		return
+	}
+
+	f.useItem(c, heal(c, c.item.Value))
+}
+
+// itemEndOfTurn aplica los objetos de final de turno de un Pokémon que sigue
+// combatiendo: los que recuperan HP y los que curan problemas de estado.
+func (f *fight) itemEndOfTurn(c *Combatant) {
+	if c.item == nil {
+		return
+	}
+
+	switch c.item.Effect {
+	case models.ItemEffectRegen:
+		if c.HP < c.maxHP {
+			f.useItem(c, heal(c, max(c.maxHP*c.item.Value/100, 1)))
+		}
+	case models.ItemEffectCure:
+		if c.status != "" {
+			f.cure(c)
+			f.useItem(c, 0)
+		}
+	}
+}
+
+// useHeldItem usa el objeto que lleva un Pokémon por decisión de su estrategia,
+// que solo puede usar los objetos que curan HP o problemas de estado.
+func (f *fight) useHeldItem(c *Combatant) {
+	switch c.item.Effect {
+	case models.ItemEffectHeal:
+		f.useItem(c, heal(c, c.item.Value))
+	case models.ItemEffectCure:
+		f.cure(c)
+		f.useItem(c, 0)
+	}
+}
+
+// itemUses devuelve el resumen del uso de los objetos de los Pokémon que llevaban uno.
+func itemUses(members []*Combatant) []models.ItemUse {
+	var uses []models.ItemUse
+	for _, member := range members {
+		if member.itemUse != nil {
+			uses = append(uses, *member.itemUse)
+		}
+	}
+	return uses
+}
@@ -0,0 +1,98 @@
+package business_test
+
+import (
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+// itemEvents devuelve los eventos del objeto activado por el Pokémon.
+func itemEvents(battle models.Battle, pokemonID int) []models.BattleEvent {
+	var events []models.BattleEvent
+	for _, event := range battle.Log {
+		if event.Type == models.EventItem && event.PokemonID == pokemonID {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestItems(t *testing.T) {
+	// hitter causa entre 1 y unos pocos puntos de daño por turno al muro
+	hitter := models.Pokemon{ID: 1, Name: "Machamp", Type: "Normal", HP: 1000, Attack: 30, Defense: 1000, Speed: 100}
+	target := models.Pokemon{ID: 2, Name: "Chansey", Type: "Normal", HP: 100, Defense: 25}
+
+	t.Run("potion", func(t *testing.T) {
+		holder := target
+		holder.Item = &models.Item{ID: 1, Name: "Potion", Effect: models.ItemEffectHeal, Value: 20, Threshold: 90, Consumable: true}
+
+		battle := business.Fight(6, hitter, holder, business.WithSeed(42), business.WithMaxTurns(20))
+		events := itemEvents(battle, holder.ID)
+		if len(events) != 1 || events[0].Item != "Potion" || events[0].Healed <= 0 {
+			t.Fatalf("expected the potion to heal once, got %+v", events)
+		}
+
+		if len(battle.ItemUses) != 1 {
+			t.Fatalf("expected the use of the item in the result, got %+v", battle.ItemUses)
+		}
+		use := battle.ItemUses[0]
+		if use.PokemonID != holder.ID || use.ItemID != 1 || use.Uses != 1 || !use.Consumed || use.Healed != events[0].Healed {
+			t.Fatalf("expected the potion to be consumed, got %+v", use)
+		}
+	})
+
+	t.Run("leftovers", func(t *testing.T) {
+		holder := target
+		holder.Item = &models.Item{ID: 2, Name: "Leftovers", Effect: models.ItemEffectRegen, Value: 10}
+
+		battle := business.Fight(6, hitter, holder, business.WithSeed(42), business.WithMaxTurns(10))
+		use := battle.ItemUses[0]
+		if use.Uses == 0 || use.Consumed {
+			t.Fatalf("expected the leftovers to heal without being consumed, got %+v", use)
+		}
+
+		// el HP nunca supera el máximo
+		for _, event := range itemEvents(battle, holder.ID) {
+			if event.HP > holder.HP {
+				t.Fatalf("expected the HP not to go over %d, got %d", holder.HP, event.HP)
+			}
+		}
+	})
+
+	t.Run("choice-band", func(t *testing.T) {
+		attacker := hitter
+		plain := business.Fight(6, attacker, target, business.WithSeed(42), business.WithMaxTurns(5))
+
+		attacker.Item = &models.Item{ID: 3, Name: "Choice Band", Effect: models.ItemEffectAttackBoost, Value: 50}
+		boosted := business.Fight(6, attacker, target, business.WithSeed(42), business.WithMaxTurns(5))
+
+		if damageTo(boosted, target.ID) <= damageTo(plain, target.ID) {
+			t.Fatalf("expected the choice band to increase the damage, got %d with it and %d without it", damageTo(boosted, target.ID), damageTo(plain, target.ID))
+		}
+		if events := itemEvents(boosted, attacker.ID); len(events) != 5 {
+			t.Fatalf("expected the choice band to be used in every attack, got %d times", len(events))
+		}
+	})
+
+	t.Run("lum-berry", func(t *testing.T) {
+		toxic := models.Move{ID: 1, Name: "Toxic", Type: "Poison", Accuracy: 100, Category: models.MoveCategoryStatus, Effect: models.StatusPoison, EffectChance: 100}
+		poisoner := hitter
+		poisoner.Moves = []models.Move{toxic}
+		holder := target
+		holder.Item = &models.Item{ID: 4, Name: "Lum Berry", Effect: models.ItemEffectCure, Consumable: true}
+
+		battle := business.Fight(6, poisoner, holder, business.WithSeed(42), business.WithMaxTurns(3))
+		events := itemEvents(battle, holder.ID)
+		if len(events) != 1 || events[0].Turn != 1 {
+			t.Fatalf("expected the berry to cure the poison at the end of the first turn, got %+v", events)
+		}
+	})
+
+	t.Run("no-item", func(t *testing.T) {
+		battle := business.Fight(6, hitter, target, business.WithSeed(42), business.WithMaxTurns(5))
+		if len(battle.ItemUses) != 0 {
+			t.Fatalf("expected no item uses, got %+v", battle.ItemUses)
+		}
+	})
+}
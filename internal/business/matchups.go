@@ -0,0 +1,132 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"pokemon-battle/internal/models"
+)
+
+// DefaultMatchupRuns es el número de batallas simuladas por defecto
+// en cada enfrentamiento de la matriz de enfrentamientos.
+const DefaultMatchupRuns = 100
+
+// ComputeMatchups simula runs batallas entre cada pareja de Pokémon y devuelve
+// un enfrentamiento por pareja, con el Pokémon de menor ID como primer Pokémon.
+// Las parejas se reparten entre workers goroutines, de manera que nunca hay más
+// de workers batallas a la vez. Si el contexto se cancela, el cálculo se detiene
+// y devuelve el error del contexto.
+func ComputeMatchups(ctx context.Context, pokemons []models.Pokemon, runs int, workers int, diceSides int, opts ...Option) ([]models.Matchup, error) {
+	if runs <= 0 || runs > MaxSimulationRuns {
+		return nil, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidSimulationRuns, MaxSimulationRuns)
+	}
+	workers = max(workers, 1)
+
+	var pairs [][2]models.Pokemon
+	for i := range pokemons {
+		for j := i + 1; j < len(pokemons); j++ {
+			p1, p2 := pokemons[i], pokemons[j]
+			if p2.ID < p1.ID {
+				p1, p2 = p2, p1
+			}
+			pairs = append(pairs, [2]models.Pokemon{p1, p2})
+		}
+	}
+
+	matchups := make([]models.Matchup, len(pairs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				matchups[i] = matchup(runs, diceSides, pairs[i][0], pairs[i][1], opts...)
+			}
+		}()
+	}
+
+	var err error
+	for i := range pairs {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+	return matchups, nil
+}
+
+// matchup simula runs batallas entre dos Pokémon y cuenta las victorias de cada uno.
+func matchup(runs int, diceSides int, pokemon1 models.Pokemon, pokemon2 models.Pokemon, opts ...Option) models.Matchup {
+	m := models.Matchup{
+		Pokemon1ID: pokemon1.ID,
+		Pokemon2ID: pokemon2.ID,
+		Runs:       runs,
+	}
+
+	for range runs {
+		battle := Fight(diceSides, pokemon1, pokemon2, opts...)
+		switch battle.WinnerID {
+		case 0:
+			m.Draws++
+		case pokemon1.ID:
+			m.Pokemon1Wins++
+		default:
+			m.Pokemon2Wins++
+		}
+	}
+
+	m.ComputedAt = time.Now()
+	return m
+}
+
+// NewMatchupMatrix construye la matriz de enfrentamientos de los Pokémon a partir
+// de los enfrentamientos calculados. Los Pokémon sin enfrentamientos, como los
+// creados después del cálculo, no aparecen en la matriz. El enfrentamiento de un
+// Pokémon consigo mismo se considera equilibrado, con probabilidad 0.5.
+func NewMatchupMatrix(pokemons []models.Pokemon, matchups []models.Matchup) models.MatchupMatrix {
+	computed := make(map[int]bool)
+	rates := make(map[[2]int]float64, 2*len(matchups))
+	matrix := models.MatchupMatrix{Pokemons: []models.MatchupPokemon{}}
+
+	for _, m := range matchups {
+		computed[m.Pokemon1ID] = true
+		computed[m.Pokemon2ID] = true
+		rates[[2]int{m.Pokemon1ID, m.Pokemon2ID}] = m.WinRate(m.Pokemon1ID)
+		rates[[2]int{m.Pokemon2ID, m.Pokemon1ID}] = m.WinRate(m.Pokemon2ID)
+
+		matrix.Runs = m.Runs
+		if m.ComputedAt.After(matrix.ComputedAt) {
+			matrix.ComputedAt = m.ComputedAt
+		}
+	}
+
+	for _, pokemon := range pokemons {
+		if computed[pokemon.ID] {
+			matrix.Pokemons = append(matrix.Pokemons, models.MatchupPokemon{ID: pokemon.ID, Name: pokemon.Name})
+		}
+	}
+
+	matrix.WinRates = make([][]float64, len(matrix.Pokemons))
+	for i, row := range matrix.Pokemons {
+		matrix.WinRates[i] = make([]float64, len(matrix.Pokemons))
+		for j, column := range matrix.Pokemons {
+			if i == j {
+				matrix.WinRates[i][j] = 0.5
+				continue
+			}
+			matrix.WinRates[i][j] = rates[[2]int{row.ID, column.ID}]
+		}
+	}
+
+	return matrix
+}
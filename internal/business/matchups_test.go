@@ -0,0 +1,74 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestComputeMatchups(t *testing.T) {
+	rival := strongPokemon
+	rival.ID = 3
+	pokemons := []models.Pokemon{rival, weakPokemon, strongPokemon}
+
+	t.Run("pairs", func(t *testing.T) {
+		matchups, err := business.ComputeMatchups(context.Background(), pokemons, 50, 2, 10)
+		if err != nil {
+			t.Fatalf("expected ComputeMatchups() to return nil, got %v", err)
+		}
+
+		// 3 Pokémon forman 3 parejas, con el menor ID primero
+		if len(matchups) != 3 {
+			t.Fatalf("expected 3 matchups, got %d", len(matchups))
+		}
+		for _, m := range matchups {
+			if m.Pokemon1ID >= m.Pokemon2ID {
+				t.Fatalf("expected the lowest ID first, got %d and %d", m.Pokemon1ID, m.Pokemon2ID)
+			}
+			if m.Runs != 50 || m.Pokemon1Wins+m.Pokemon2Wins+m.Draws != 50 {
+				t.Fatalf("expected 50 battles, got %+v", m)
+			}
+			if m.ComputedAt.IsZero() {
+				t.Fatal("expected the matchup to have a computation time")
+			}
+			if m.Pokemon1ID == weakPokemon.ID && m.Pokemon2Wins != 50 {
+				t.Fatalf("expected weakPokemon to lose every battle, got %+v", m)
+			}
+		}
+
+		matrix := business.NewMatchupMatrix(pokemons, matchups)
+		if len(matrix.Pokemons) != 3 || len(matrix.WinRates) != 3 || matrix.Runs != 50 {
+			t.Fatalf("expected a 3x3 matrix of 50 runs, got %+v", matrix)
+		}
+		for i := range matrix.Pokemons {
+			if matrix.WinRates[i][i] != 0.5 {
+				t.Fatalf("expected the diagonal to be 0.5, got %v", matrix.WinRates[i][i])
+			}
+		}
+
+		// strongPokemon (columna 2) siempre gana a weakPokemon (fila 1)
+		if matrix.Pokemons[1].ID != weakPokemon.ID || matrix.WinRates[1][2] != 0 || matrix.WinRates[2][1] != 1 {
+			t.Fatalf("expected strongPokemon to always beat weakPokemon, got %v", matrix.WinRates)
+		}
+	})
+
+	t.Run("invalid-runs", func(t *testing.T) {
+		_, err := business.ComputeMatchups(context.Background(), pokemons, 0, 2, 10)
+		if !errors.Is(err, business.ErrInvalidSimulationRuns) {
+			t.Fatalf("expected ErrInvalidSimulationRuns, got %v", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := business.ComputeMatchups(ctx, pokemons, 50, 2, 10)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
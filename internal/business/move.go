@@ -0,0 +1,32 @@
+package business
+
+import (
+	"pokemon-battle/internal/models"
+)
+
+// baseMovePower es la potencia de referencia de un movimiento: un movimiento
+// con esta potencia causa el mismo daño que un ataque sin movimiento.
+const baseMovePower = 50
+
+// chooseMove elige al azar uno de los movimientos del Pokémon.
+// Devuelve false si el Pokémon no conoce ningún movimiento.
+func (f *fight) chooseMove(pokemon *models.Pokemon) (models.Move, bool) {
+	if len(pokemon.Moves) == 0 {
+		return models.Move{}, false
+	}
+	return pokemon.Moves[f.random.Intn(len(pokemon.Moves))], true
+}
+
+// selectMove devuelve el movimiento del Pokémon en la posición indicada,
+// o uno al azar si la posición no es válida.
+func (f *fight) selectMove(pokemon *models.Pokemon, position int) (models.Move, bool) {
+	if position >= 0 && position < len(pokemon.Moves) {
+		return pokemon.Moves[position], true
+	}
+	return f.chooseMove(pokemon)
+}
+
+// hits indica si un movimiento acierta, según su precisión.
+func (f *fight) hits(move models.Move) bool {
+	return f.random.Intn(100) < move.Accuracy
+}
@@ -0,0 +1,91 @@
+package business_test
+
+import (
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+var (
+	thunderbolt = models.Move{ID: 1, Name: "Thunderbolt", Type: "Electric", Power: 90, Accuracy: 100, Category: models.MoveCategorySpecial}
+	earthquake  = models.Move{ID: 2, Name: "Earthquake", Type: "Ground", Power: 100, Accuracy: 100, Category: models.MoveCategoryPhysical}
+	growl       = models.Move{ID: 3, Name: "Growl", Type: "Normal", Power: 0, Accuracy: 100, Category: models.MoveCategoryStatus}
+)
+
+func TestFight_moves(t *testing.T) {
+	t.Run("uses-known-moves", func(t *testing.T) {
+		pokemon1 := models.Pokemon{ID: 1, Name: "Raichu", Type: "Electric", HP: 90, Attack: 90, Defense: 55, Moves: []models.Move{thunderbolt}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Squirtle", Type: "Water", HP: 90, Attack: 48, Defense: 65, Moves: []models.Move{growl}}
+
+		battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(1))
+
+		for _, event := range battle.Log {
+			if event.Type != models.EventAttack {
+				continue
+			}
+
+			switch event.PokemonID {
+			case pokemon1.ID:
+				if event.MoveID != thunderbolt.ID || event.Move != thunderbolt.Name {
+					t.Fatalf("expected %s to use %s, got %s", pokemon1.Name, thunderbolt.Name, event.Move)
+				}
+			case pokemon2.ID:
+				if event.MoveID != growl.ID {
+					t.Fatalf("expected %s to use %s, got %s", pokemon2.Name, growl.Name, event.Move)
+				}
+				if event.Damage != 0 {
+					t.Fatalf("expected status move not to cause damage, got %d", event.Damage)
+				}
+			}
+		}
+
+		// Squirtle solo conoce un movimiento de estado, por lo que no puede ganar
+		if battle.WinnerID != pokemon1.ID {
+			t.Fatalf("expected winner ID to be %d, got %d", pokemon1.ID, battle.WinnerID)
+		}
+	})
+
+	t.Run("move-type-effectiveness", func(t *testing.T) {
+		// Dugtrio es inmune a Thunderbolt, por lo que Pikachu nunca le causa daño,
+		// aunque Dugtrio apenas tenga defensa ni HP.
+		pokemon1 := models.Pokemon{ID: 1, Name: "Pikachu", Type: "Electric", HP: 100, Attack: 55, Defense: 40, Moves: []models.Move{thunderbolt}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Dugtrio", Type: "Ground", HP: 1, Attack: 100, Defense: 0, Moves: []models.Move{earthquake}}
+
+		for seed := int64(0); seed < 10; seed++ {
+			battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(seed))
+			if battle.WinnerID != pokemon2.ID {
+				t.Fatalf("expected winner ID to be %d, got %d", pokemon2.ID, battle.WinnerID)
+			}
+		}
+	})
+
+	t.Run("accuracy", func(t *testing.T) {
+		inaccurate := thunderbolt
+		inaccurate.Accuracy = 50
+
+		pokemon1 := models.Pokemon{ID: 1, Name: "Raichu", Type: "Electric", HP: 90, Attack: 90, Defense: 55, Moves: []models.Move{inaccurate}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Squirtle", Type: "Water", HP: 1000, Attack: 0, Defense: 0, Moves: []models.Move{growl}}
+
+		battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(1))
+
+		var hits, misses int
+		for _, event := range battle.Log {
+			if event.Type != models.EventAttack || event.PokemonID != pokemon1.ID {
+				continue
+			}
+			if event.Missed {
+				misses++
+				if event.Damage != 0 {
+					t.Fatalf("expected missed attack not to cause damage, got %d", event.Damage)
+				}
+			} else {
+				hits++
+			}
+		}
+
+		if hits == 0 || misses == 0 {
+			t.Fatalf("expected a 50%% accuracy move to both hit and miss, got %d hits and %d misses", hits, misses)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package business
+
+import (
+	"math/rand"
+
+	"pokemon-battle/internal/models"
+)
+
+// fightConfig contiene la configuración opcional de una batalla.
+type fightConfig struct {
+	typeChart           TypeChart
+	seed                int64
+	initiativeDiceSides int
+	maxExplosions       int
+	attackDice          *DiceExpression
+	maxTurns            int
+	switchStrategy1     SwitchStrategy
+	switchStrategy2     SwitchStrategy
+	strategy1           Strategy
+	strategy2           Strategy
+	abilities           map[string]Ability
+	eventHandler        EventHandler
+	condition           string
+}
+
+// Option es una función que modifica la configuración de una batalla.
+type Option func(*fightConfig)
+
+// WithTypeChart establece la tabla de tipos usada para calcular el daño.
+// Si la tabla es nil, se usa la tabla por defecto.
+func WithTypeChart(chart TypeChart) Option {
+	return func(c *fightConfig) {
+		if chart != nil {
+			c.typeChart = chart
+		}
+	}
+}
+
+// WithSeed establece la semilla de los dados de la batalla, de manera que
+// la misma semilla y los mismos Pokémon producen siempre la misma batalla.
+func WithSeed(seed int64) Option {
+	return func(c *fightConfig) {
+		c.seed = seed
+	}
+}
+
+// WithInitiativeDice establece el número de caras del dado que se suma
+// a la velocidad para decidir quién ataca primero en cada turno.
+// Si el número de caras es menor que MinInitiativeDiceSides, se usa el dado por defecto.
+func WithInitiativeDice(sides int) Option {
+	return func(c *fightConfig) {
+		if sides >= MinInitiativeDiceSides {
+			c.initiativeDiceSides = sides
+		}
+	}
+}
+
+// WithMaxExplosions establece el número máximo de explosiones de los dados
+// de ataque y defensa, es decir, de veces que vuelven a tirar al sacar el máximo.
+// Con 0 los dados no explotan y no hay golpes críticos.
+// Los valores negativos se ignoran.
+func WithMaxExplosions(maxExplosions int) Option {
+	return func(c *fightConfig) {
+		if maxExplosions >= 0 {
+			c.maxExplosions = maxExplosions
+		}
+	}
+}
+
+// WithAttackDice establece una expresión de dados, como "2d6+3", para las tiradas
+// de ataque y defensa, en lugar del dado con el número de caras de la batalla.
+// Solo explotan los dados marcados con "!", hasta el máximo de explosiones de la batalla.
+// Si la expresión es nil, se usa el dado con el número de caras de la batalla.
+func WithAttackDice(expression *DiceExpression) Option {
+	return func(c *fightConfig) {
+		c.attackDice = expression
+	}
+}
+
+// WithMaxTurns establece el número máximo de turnos de la batalla.
+// Si ningún Pokémon se queda sin HP antes, la batalla termina en empate.
+// Si el número de turnos no es positivo, se usa el máximo por defecto.
+func WithMaxTurns(maxTurns int) Option {
+	return func(c *fightConfig) {
+		if maxTurns > 0 {
+			c.maxTurns = maxTurns
+		}
+	}
+}
+
+// WithSwitchStrategies establece las estrategias con las que cada equipo decide
+// si cambia de Pokémon voluntariamente en las batallas por equipos.
+// Con una estrategia nil, el equipo solo cambia cuando se debilita su Pokémon.
+func WithSwitchStrategies(team1 SwitchStrategy, team2 SwitchStrategy) Option {
+	return func(c *fightConfig) {
+		c.switchStrategy1 = team1
+		c.switchStrategy2 = team2
+	}
+}
+
+// WithStrategies establece las estrategias con las que cada bando decide su acción
+// en cada turno de las batallas individuales y por equipos. Un bando con estrategia
+// ignora su estrategia de cambios. Con una estrategia nil, el bando ataca con un
+// movimiento al azar.
+func WithStrategies(side1 Strategy, side2 Strategy) Option {
+	return func(c *fightConfig) {
+		c.strategy1 = side1
+		c.strategy2 = side2
+	}
+}
+
+// WithAbilities añade habilidades a la batalla, además de las disponibles.
+// Los Pokémon cuya habilidad tiene el nombre de una de ellas la usan en lugar
+// de la disponible con el mismo nombre.
+func WithAbilities(abilities ...Ability) Option {
+	return func(c *fightConfig) {
+		if c.abilities == nil {
+			c.abilities = make(map[string]Ability, len(abilities))
+		}
+		for _, ability := range abilities {
+			c.abilities[ability.Name()] = ability
+		}
+	}
+}
+
+// WithCondition establece la condición del campo al empezar la batalla, como la
+// lluvia o la tormenta de arena, que dura hasta que un movimiento la sustituye.
+// Si la condición no es una de las conocidas, la batalla empieza sin condición.
+func WithCondition(condition string) Option {
+	return func(c *fightConfig) {
+		if models.IsValidCondition(condition) {
+			c.condition = condition
+		}
+	}
+}
+
+func newFightConfig(opts ...Option) *fightConfig {
+	cfg := &fightConfig{
+		typeChart: DefaultTypeChart(),
+		// si no se define una semilla, se genera una aleatoria
+		// para poder reproducir la batalla más tarde.
+		seed:                rand.Int63(),
+		initiativeDiceSides: DefaultInitiativeDiceSides,
+		maxExplosions:       DefaultMaxExplosions,
+		maxTurns:            DefaultMaxTurns,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// EventHandler recibe cada evento de una batalla en el momento en que se resuelve,
+// antes de que termine la batalla.
+type EventHandler func(event models.BattleEvent)
+
+// WithEventHandler establece la función que recibe los eventos de la batalla a
+// medida que se resuelven, como las tiradas de iniciativa, los ataques o los
+// Pokémon debilitados. Los eventos son los mismos que los del registro de la batalla.
+func WithEventHandler(handler EventHandler) Option {
+	return func(c *fightConfig) {
+		c.eventHandler = handler
+	}
+}
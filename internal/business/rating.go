@@ -0,0 +1,173 @@
+package business
+
+import (
+	"math"
+	"time"
+
+	"pokemon-battle/internal/models"
+)
+
+const (
+	// DefaultRating, DefaultRatingDeviation y DefaultRatingVolatility son la
+	// puntuación Glicko-2 de un Pokémon que todavía no ha librado ninguna batalla.
+	DefaultRating           = 1500
+	DefaultRatingDeviation  = 350
+	DefaultRatingVolatility = 0.06
+
+	// ratingTau limita el cambio de la volatilidad en cada batalla.
+	ratingTau = 0.5
+
+	// glicko2Scale convierte la escala de Glicko a la de Glicko-2.
+	glicko2Scale = 173.7178
+
+	// volatilityEpsilon es la tolerancia del cálculo de la nueva volatilidad.
+	volatilityEpsilon = 0.000001
+)
+
+// NewRating devuelve la puntuación inicial de un Pokémon.
+func NewRating(pokemonID int) models.Rating {
+	return models.Rating{
+		PokemonID:  pokemonID,
+		Rating:     DefaultRating,
+		Deviation:  DefaultRatingDeviation,
+		Volatility: DefaultRatingVolatility,
+	}
+}
+
+// RateBattle devuelve las nuevas puntuaciones de los Pokémon de una batalla,
+// primero los del primer equipo y después los del segundo. Cada batalla es un
+// periodo de Glicko-2 en el que cada Pokémon se enfrenta a todos los Pokémon
+// del otro equipo, con el resultado de su equipo. Las puntuaciones actuales que
+// falten se consideran las iniciales.
+func RateBattle(battle models.Battle, ratings map[int]models.Rating) []models.Rating {
+	team1, team2 := []int{battle.Pokemon1ID}, []int{battle.Pokemon2ID}
+	if battle.IsTeamBattle() {
+		team1, team2 = battle.Team1, battle.Team2
+	}
+
+	current := func(id int) models.Rating {
+		if rating, ok := ratings[id]; ok {
+			return rating
+		}
+		return NewRating(id)
+	}
+
+	// the score of the first team, 1 for a win, 0 for a loss and 0.5 for a draw
+	score := 0.5
+	switch battle.Side(battle.WinnerID) {
+	case 1:
+		score = 1
+	case 2:
+		score = 0
+	}
+
+	now := time.Now()
+	rated := make([]models.Rating, 0, len(team1)+len(team2))
+	for _, side := range []struct {
+		team, opponents []int
+		score           float64
+	}{
+		{team1, team2, score},
+		{team2, team1, 1 - score},
+	} {
+		opponents := make([]models.Rating, len(side.opponents))
+		for i, id := range side.opponents {
+			opponents[i] = current(id)
+		}
+
+		for _, id := range side.team {
+			rating := updateRating(current(id), opponents, side.score)
+			rating.Battles++
+			rating.UpdatedAt = now
+			rated = append(rated, rating)
+		}
+	}
+
+	return rated
+}
+
+// updateRating aplica el algoritmo de Glicko-2 a la puntuación de un Pokémon
+// que ha obtenido el mismo resultado contra todos los rivales.
+func updateRating(player models.Rating, opponents []models.Rating, score float64) models.Rating {
+	scores := make([]float64, len(opponents))
+	for i := range scores {
+		scores[i] = score
+	}
+	return glicko2(player, opponents, scores)
+}
+
+// glicko2 aplica el algoritmo de Glicko-2 a la puntuación de un Pokémon con los
+// resultados de un periodo, tal y como lo describe Mark Glickman en
+// "Example of the Glicko-2 system".
+func glicko2(player models.Rating, opponents []models.Rating, scores []float64) models.Rating {
+	mu := (player.Rating - DefaultRating) / glicko2Scale
+	phi := player.Deviation / glicko2Scale
+	sigma := player.Volatility
+
+	if len(opponents) == 0 {
+		player.Deviation = math.Min(math.Sqrt(phi*phi+sigma*sigma)*glicko2Scale, DefaultRatingDeviation)
+		return player
+	}
+
+	// estimated variance and improvement
+	var vInverse, improvement float64
+	for i, opponent := range opponents {
+		muJ := (opponent.Rating - DefaultRating) / glicko2Scale
+		phiJ := opponent.Deviation / glicko2Scale
+
+		g := 1 / math.Sqrt(1+3*phiJ*phiJ/(math.Pi*math.Pi))
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+
+		vInverse += g * g * e * (1 - e)
+		improvement += g * (scores[i] - e)
+	}
+	v := 1 / vInverse
+	delta := v * improvement
+
+	sigma = newVolatility(phi, sigma, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * improvement
+
+	player.Rating = mu*glicko2Scale + DefaultRating
+	player.Deviation = math.Min(phi*glicko2Scale, DefaultRatingDeviation)
+	player.Volatility = sigma
+	return player
+}
+
+// newVolatility calcula la nueva volatilidad con el método de Illinois.
+func newVolatility(phi, sigma, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(ratingTau*ratingTau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*ratingTau) < 0 {
+			k++
+		}
+		B = a - k*ratingTau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > volatilityEpsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
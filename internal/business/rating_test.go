@@ -0,0 +1,90 @@
+package business
+
+import (
+	"math"
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+func TestGlicko2(t *testing.T) {
+	// el ejemplo de "Example of the Glicko-2 system", de Mark Glickman
+	player := models.Rating{PokemonID: 1, Rating: 1500, Deviation: 200, Volatility: 0.06}
+	opponents := []models.Rating{
+		{PokemonID: 2, Rating: 1400, Deviation: 30},
+		{PokemonID: 3, Rating: 1550, Deviation: 100},
+		{PokemonID: 4, Rating: 1700, Deviation: 300},
+	}
+
+	rating := glicko2(player, opponents, []float64{1, 0, 0})
+
+	if math.Abs(rating.Rating-1464.06) > 0.01 {
+		t.Errorf("expected rating 1464.06, got %.2f", rating.Rating)
+	}
+	if math.Abs(rating.Deviation-151.52) > 0.01 {
+		t.Errorf("expected deviation 151.52, got %.2f", rating.Deviation)
+	}
+	if math.Abs(rating.Volatility-0.05999) > 0.00001 {
+		t.Errorf("expected volatility 0.05999, got %.5f", rating.Volatility)
+	}
+}
+
+func TestRateBattle(t *testing.T) {
+	t.Run("winner", func(t *testing.T) {
+		battle := models.Battle{Pokemon1ID: 1, Pokemon2ID: 2, WinnerID: 1}
+
+		ratings := RateBattle(battle, map[int]models.Rating{})
+		if len(ratings) != 2 {
+			t.Fatalf("expected 2 ratings, got %d", len(ratings))
+		}
+
+		winner, loser := ratings[0], ratings[1]
+		if winner.PokemonID != 1 || loser.PokemonID != 2 {
+			t.Fatalf("expected the ratings of the winner and the loser, got %+v", ratings)
+		}
+		if winner.Rating <= DefaultRating || loser.Rating >= DefaultRating {
+			t.Fatalf("expected the winner to gain and the loser to lose rating, got %.2f and %.2f", winner.Rating, loser.Rating)
+		}
+		// ambos empiezan igual, así que lo que gana uno lo pierde el otro
+		if math.Abs(winner.Rating-DefaultRating-(DefaultRating-loser.Rating)) > 0.0001 {
+			t.Fatalf("expected symmetric changes, got %.2f and %.2f", winner.Rating, loser.Rating)
+		}
+		if winner.Deviation >= DefaultRatingDeviation || winner.Battles != 1 || winner.UpdatedAt.IsZero() {
+			t.Fatalf("expected a more certain rating after one battle, got %+v", winner)
+		}
+	})
+
+	t.Run("draw", func(t *testing.T) {
+		battle := models.Battle{Pokemon1ID: 1, Pokemon2ID: 2}
+		ratings := RateBattle(battle, map[int]models.Rating{
+			1: {PokemonID: 1, Rating: 1800, Deviation: 50, Volatility: 0.06, Battles: 20},
+		})
+
+		// empatar contra un Pokémon peor resta puntos
+		if ratings[0].Rating >= 1800 || ratings[1].Rating <= DefaultRating {
+			t.Fatalf("expected the favourite to lose rating in a draw, got %+v", ratings)
+		}
+		if ratings[0].Battles != 21 {
+			t.Fatalf("expected 21 battles, got %d", ratings[0].Battles)
+		}
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		battle := models.Battle{Pokemon1ID: 1, Pokemon2ID: 4, WinnerID: 5, Team1: []int{1, 2, 3}, Team2: []int{4, 5}}
+
+		ratings := RateBattle(battle, map[int]models.Rating{})
+		if len(ratings) != 5 {
+			t.Fatalf("expected 5 ratings, got %d", len(ratings))
+		}
+		for _, rating := range ratings[:3] {
+			if rating.Rating >= DefaultRating {
+				t.Fatalf("expected the first team to lose rating, got %+v", rating)
+			}
+		}
+		for _, rating := range ratings[3:] {
+			if rating.Rating <= DefaultRating {
+				t.Fatalf("expected the second team to gain rating, got %+v", rating)
+			}
+		}
+	})
+}
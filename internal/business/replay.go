@@ -0,0 +1,94 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+
+	"pokemon-battle/internal/models"
+)
+
+// ErrNotReplayable se devuelve cuando una batalla no guarda la información
+// necesaria para repetirla, como las batallas creadas antes de guardar
+// la configuración y los participantes.
+var ErrNotReplayable = errors.New("battle cannot be replayed: missing settings or participants")
+
+// Replay vuelve a ejecutar una batalla guardada con la misma semilla,
+// configuración y estadísticas de los participantes, e indica si el
+// ganador y el número de turnos coinciden con los guardados.
+// Las opciones permiten indicar la configuración que no se guarda con
+// la batalla, como la tabla de tipos.
+func Replay(battle models.Battle, opts ...Option) (models.BattleReplay, error) {
+	participants := 2
+	if battle.IsTeamBattle() {
+		participants = len(battle.Team1) + len(battle.Team2)
+	}
+	if len(battle.Participants) != participants || len(battle.Team1) == 0 != (len(battle.Team2) == 0) || battle.Settings.DiceSides <= 0 {
+		return models.BattleReplay{}, ErrNotReplayable
+	}
+
+	settingsOpts, err := settingsOptions(battle.Seed, battle.Settings)
+	if err != nil {
+		return models.BattleReplay{}, fmt.Errorf("%w: %w", ErrNotReplayable, err)
+	}
+	opts = append(opts[:len(opts):len(opts)], settingsOpts...)
+
+	strategy1, err := StrategyByName(battle.Settings.Strategy1)
+	if err != nil {
+		return models.BattleReplay{}, fmt.Errorf("%w: %w", ErrNotReplayable, err)
+	}
+	strategy2, err := StrategyByName(battle.Settings.Strategy2)
+	if err != nil {
+		return models.BattleReplay{}, fmt.Errorf("%w: %w", ErrNotReplayable, err)
+	}
+	opts = append(opts, WithStrategies(strategy1, strategy2))
+
+	var replayed models.Battle
+	if battle.Format == models.FormatDoubles {
+		split := len(battle.Team1)
+		replayed = FightDoubles(battle.Settings.DiceSides, battle.Participants[:split], battle.Participants[split:], opts...)
+	} else if battle.IsTeamBattle() {
+		switch1, err := SwitchStrategyByName(battle.Settings.SwitchStrategy1)
+		if err != nil {
+			return models.BattleReplay{}, fmt.Errorf("%w: %w", ErrNotReplayable, err)
+		}
+		switch2, err := SwitchStrategyByName(battle.Settings.SwitchStrategy2)
+		if err != nil {
+			return models.BattleReplay{}, fmt.Errorf("%w: %w", ErrNotReplayable, err)
+		}
+		opts = append(opts, WithSwitchStrategies(switch1, switch2))
+
+		split := len(battle.Team1)
+		replayed = FightTeams(battle.Settings.DiceSides, battle.Participants[:split], battle.Participants[split:], opts...)
+	} else {
+		replayed = Fight(battle.Settings.DiceSides, battle.Participants[0], battle.Participants[1], opts...)
+	}
+
+	return models.BattleReplay{
+		BattleID:       battle.ID,
+		WinnerID:       battle.WinnerID,
+		Turns:          battle.Turns,
+		ReplayWinnerID: replayed.WinnerID,
+		ReplayTurns:    replayed.Turns,
+		Matches:        replayed.WinnerID == battle.WinnerID && replayed.Turns == battle.Turns,
+	}, nil
+}
+
+// settingsOptions devuelve las opciones con las que se vuelve a librar una
+// batalla con la semilla y la configuración guardadas.
+func settingsOptions(seed int64, settings models.BattleSettings) ([]Option, error) {
+	opts := []Option{
+		WithSeed(seed),
+		WithInitiativeDice(settings.InitiativeDiceSides),
+		WithMaxExplosions(settings.MaxExplosions),
+		WithMaxTurns(settings.MaxTurns),
+		WithCondition(settings.Condition),
+	}
+	if settings.DiceExpression != "" {
+		attackDice, err := ParseDice(settings.DiceExpression)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithAttackDice(attackDice))
+	}
+	return opts, nil
+}
@@ -0,0 +1,57 @@
+package business_test
+
+import (
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestReplay(t *testing.T) {
+	rival := strongPokemon
+	rival.ID = 3
+
+	t.Run("matches", func(t *testing.T) {
+		battle := business.Fight(10, strongPokemon, rival)
+		battle.ID = 1
+
+		replay, err := business.Replay(battle)
+		if err != nil {
+			t.Fatalf("expected Replay() to return nil, got %v", err)
+		}
+
+		if !replay.Matches {
+			t.Fatalf("expected replay to match, got %+v", replay)
+		}
+		if replay.BattleID != battle.ID {
+			t.Fatalf("expected battle ID to be %d, got %d", battle.ID, replay.BattleID)
+		}
+		if replay.ReplayWinnerID != battle.WinnerID || replay.ReplayTurns != battle.Turns {
+			t.Fatalf("expected replay to be won by %d in %d turns, got %d in %d", battle.WinnerID, battle.Turns, replay.ReplayWinnerID, replay.ReplayTurns)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		battle := business.Fight(10, strongPokemon, rival)
+		battle.Turns += 100
+
+		replay, err := business.Replay(battle)
+		if err != nil {
+			t.Fatalf("expected Replay() to return nil, got %v", err)
+		}
+
+		if replay.Matches {
+			t.Fatalf("expected replay not to match, got %+v", replay)
+		}
+	})
+
+	t.Run("not-replayable", func(t *testing.T) {
+		battle := models.Battle{ID: 1, Pokemon1ID: 1, Pokemon2ID: 2, WinnerID: 1, Turns: 10}
+
+		_, err := business.Replay(battle)
+		if !errors.Is(err, business.ErrNotReplayable) {
+			t.Fatalf("expected Replay() to return ErrNotReplayable, got %v", err)
+		}
+	})
+}
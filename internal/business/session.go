@@ -0,0 +1,206 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+
+	"pokemon-battle/internal/models"
+)
+
+var (
+	// ErrSessionFinished se devuelve al jugar un turno de una sesión de batalla que ha terminado.
+	ErrSessionFinished = errors.New("battle session is finished")
+
+	// ErrInvalidAction se devuelve cuando la acción de un bando no es posible en el turno en curso.
+	ErrInvalidAction = errors.New("invalid action")
+)
+
+// TrainerStrategy es el nombre con el que se guarda la estrategia de los bandos
+// controlados por un entrenador. Las batallas con entrenadores no se pueden
+// repetir con Replay, ya que dependen de sus decisiones.
+const TrainerStrategy = "trainer"
+
+// trainer es la estrategia de un bando controlado por un entrenador, que
+// devuelve la acción enviada por el entrenador para el turno.
+type trainer struct {
+	action Action
+}
+
+func (*trainer) Name() string {
+	return TrainerStrategy
+}
+
+func (t *trainer) Choose(BattleState) Action {
+	return t.action
+}
+
+// Session es una batalla individual o por equipos que se libra turno a turno,
+// con las acciones que envía el entrenador de cada bando. Los bandos con una
+// estrategia, indicada con WithStrategies, no tienen entrenador y la estrategia
+// decide sus acciones.
+type Session struct {
+	run      *singlesRun
+	trainers [2]*trainer // entrenador de cada bando, nil si lo controla una estrategia
+}
+
+// NewSession prepara una sesión de batalla entre dos equipos, antes de su primer turno.
+func NewSession(diceSides int, team1 []models.Pokemon, team2 []models.Pokemon, opts ...Option) *Session {
+	f := newFight(diceSides, opts...)
+
+	s := &Session{}
+	if f.cfg.strategy1 == nil {
+		s.trainers[0] = &trainer{}
+		f.cfg.strategy1 = s.trainers[0]
+	}
+	if f.cfg.strategy2 == nil {
+		s.trainers[1] = &trainer{}
+		f.cfg.strategy2 = s.trainers[1]
+	}
+
+	s.run = f.start(team1, team2)
+	if len(team1) > 1 || len(team2) > 1 {
+		s.run.battle.Team1 = teamIDs(team1)
+		s.run.battle.Team2 = teamIDs(team2)
+	}
+	return s
+}
+
+// RestoreSession reconstruye una sesión de batalla guardada, volviendo a jugar
+// sus turnos resueltos con la misma semilla, configuración y participantes.
+// Las opciones permiten indicar la configuración que no se guarda con la sesión,
+// como la tabla de tipos.
+func RestoreSession(session models.BattleSession, opts ...Option) (*Session, error) {
+	if err := session.Validate(); err != nil {
+		return nil, err
+	}
+
+	settingsOpts, err := settingsOptions(session.Seed, session.Settings)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts[:len(opts):len(opts)], settingsOpts...)
+
+	strategy1, err := sessionStrategy(session.Settings.Strategy1)
+	if err != nil {
+		return nil, err
+	}
+	strategy2, err := sessionStrategy(session.Settings.Strategy2)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, WithStrategies(strategy1, strategy2))
+
+	split := len(session.Team1)
+	s := NewSession(session.Settings.DiceSides, session.Participants[:split], session.Participants[split:], opts...)
+	for i, turn := range session.Turns {
+		if err := s.Play(turn.Action1, turn.Action2); err != nil {
+			return nil, fmt.Errorf("turn %d: %w", i+1, err)
+		}
+	}
+	return s, nil
+}
+
+// sessionStrategy devuelve la estrategia de un bando de una sesión guardada,
+// nil si lo controla un entrenador.
+func sessionStrategy(name string) (Strategy, error) {
+	if name == TrainerStrategy {
+		return nil, nil
+	}
+	return StrategyByName(name)
+}
+
+// IsTrainer indica si el bando (1 o 2) lo controla un entrenador.
+func (s *Session) IsTrainer(side int) bool {
+	return (side == 1 || side == 2) && s.trainers[side-1] != nil
+}
+
+// Finished indica si la batalla ha terminado.
+func (s *Session) Finished() bool {
+	return s.run.finished
+}
+
+// Check comprueba que el entrenador de un bando (1 o 2) puede realizar la acción
+// en el turno en curso.
+func (s *Session) Check(side int, action models.BattleAction) error {
+	if s.run.finished {
+		return ErrSessionFinished
+	}
+	if !s.IsTrainer(side) {
+		return fmt.Errorf("%w: side %d is not controlled by a trainer", ErrInvalidAction, side)
+	}
+	if !models.IsValidAction(action.Type) {
+		return fmt.Errorf("%w: type must be attack, switch or item", ErrInvalidAction)
+	}
+
+	own := s.run.team1
+	if side == 2 {
+		own = s.run.team2
+	}
+	moves := own.active().Moves
+	if action.Type == ActionAttack && len(moves) > 0 && action.Move >= len(moves) {
+		return fmt.Errorf("%w: the pokemon knows %d moves", ErrInvalidAction, len(moves))
+	}
+	if !isPossible(own.sideState(), sessionAction(action)) {
+		return fmt.Errorf("%w: %s is not possible in this turn", ErrInvalidAction, action.Type)
+	}
+	return nil
+}
+
+// Play libra el turno en curso con las acciones de los dos bandos. Las acciones
+// de los bandos que controla una estrategia se ignoran.
+func (s *Session) Play(action1 models.BattleAction, action2 models.BattleAction) error {
+	for i, action := range []models.BattleAction{action1, action2} {
+		if s.trainers[i] == nil {
+			continue
+		}
+		if err := s.Check(i+1, action); err != nil {
+			return err
+		}
+		s.trainers[i].action = sessionAction(action)
+	}
+
+	s.run.playTurn()
+	return nil
+}
+
+// sessionAction convierte la acción enviada por un entrenador en la de su estrategia.
+func sessionAction(action models.BattleAction) Action {
+	return Action{Type: action.Type, Move: action.Move, Switch: action.Switch}
+}
+
+// Battle devuelve el registro de la batalla, con los turnos librados hasta el momento.
+func (s *Session) Battle() models.Battle {
+	return s.run.result()
+}
+
+// Fill completa el estado de la batalla de una sesión: el turno en curso,
+// el ganador, el estado de los participantes y el registro.
+func (s *Session) Fill(session *models.BattleSession) {
+	battle := s.run.result()
+
+	session.Turn = battle.Turns
+	session.WinnerID = battle.WinnerID
+	session.Log = battle.Log
+	session.Pokemons = nil
+	for side, t := range []*team{s.run.team1, s.run.team2} {
+		for i, member := range t.members {
+			pokemon := models.SessionPokemon{
+				PokemonID: member.ID,
+				Side:      side + 1,
+				Active:    i == t.current,
+				HP:        member.HP,
+				MaxHP:     member.maxHP,
+				Status:    member.status,
+			}
+			if member.item != nil {
+				pokemon.Item = member.item.Name
+			}
+			session.Pokemons = append(session.Pokemons, pokemon)
+		}
+	}
+
+	session.Status = models.SessionInProgress
+	if s.run.finished {
+		session.Status = models.SessionFinished
+	}
+}
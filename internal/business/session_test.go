@@ -0,0 +1,130 @@
+package business_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestSession(t *testing.T) {
+	tackle := models.Move{ID: 1, Name: "Tackle", Type: "Normal", Power: 40, Accuracy: 100, Category: models.MoveCategoryPhysical}
+	ember := models.Move{ID: 2, Name: "Ember", Type: "Fire", Power: 40, Accuracy: 100, Category: models.MoveCategorySpecial}
+
+	team1 := []models.Pokemon{
+		{ID: 1, Name: "Charmander", Type: "Fire", HP: 40, Attack: 40, Defense: 30, Speed: 50, Moves: []models.Move{tackle, ember}},
+		{ID: 2, Name: "Squirtle", Type: "Water", HP: 40, Attack: 30, Defense: 40, Speed: 40},
+	}
+	team2 := []models.Pokemon{
+		{ID: 3, Name: "Bulbasaur", Type: "Grass", HP: 40, Attack: 30, Defense: 30, Speed: 45},
+	}
+	attack := func(move int) models.BattleAction {
+		return models.BattleAction{Type: models.ActionAttack, Move: move}
+	}
+
+	t.Run("play", func(t *testing.T) {
+		session := business.NewSession(6, team1, team2, business.WithSeed(42))
+		if !session.IsTrainer(1) || !session.IsTrainer(2) {
+			t.Fatal("expected both sides to be controlled by a trainer")
+		}
+
+		// el primer bando usa siempre Ember, muy eficaz contra Bulbasaur
+		var turns []models.SessionTurn
+		for !session.Finished() {
+			turn := models.SessionTurn{Action1: attack(1), Action2: attack(-1)}
+			if err := session.Play(turn.Action1, turn.Action2); err != nil {
+				t.Fatalf("expected Play() to return nil, got %v", err)
+			}
+			turns = append(turns, turn)
+		}
+
+		battle := session.Battle()
+		if battle.WinnerID == 0 || battle.Turns != len(turns) {
+			t.Fatalf("expected a winner after %d turns, got %+v", len(turns), battle)
+		}
+		if battle.Settings.Strategy1 != business.TrainerStrategy || len(battle.Team1) != 2 || len(battle.Participants) != 3 {
+			t.Fatalf("expected a team battle between trainers, got %+v", battle)
+		}
+		if err := battle.Validate(); err != nil {
+			t.Fatalf("expected battle to be valid, got %v", err)
+		}
+		for _, event := range battle.Log {
+			if event.Type == models.EventAttack && event.PokemonID == 1 && event.MoveID != ember.ID {
+				t.Fatalf("expected Charmander to always use Ember, got %+v", event)
+			}
+		}
+
+		if err := session.Play(attack(0), attack(0)); !errors.Is(err, business.ErrSessionFinished) {
+			t.Fatalf("expected Play() to return ErrSessionFinished, got %v", err)
+		}
+
+		// la sesión guardada se vuelve a jugar a partir de sus acciones
+		saved := models.BattleSession{
+			Team1:        battle.Team1,
+			Team2:        battle.Team2,
+			Seed:         battle.Seed,
+			Settings:     battle.Settings,
+			Participants: battle.Participants,
+			Turns:        turns,
+		}
+		restored, err := business.RestoreSession(saved)
+		if err != nil {
+			t.Fatalf("expected RestoreSession() to return nil, got %v", err)
+		}
+		if !reflect.DeepEqual(restored.Battle(), battle) {
+			t.Fatal("expected the restored session to reach the same battle")
+		}
+
+		restored.Fill(&saved)
+		if saved.Status != models.SessionFinished || saved.WinnerID != battle.WinnerID || len(saved.Pokemons) != 3 {
+			t.Fatalf("expected the state of the finished battle, got %+v", saved)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		session := business.NewSession(6, team1, team2, business.WithSeed(42))
+
+		for _, action := range []models.BattleAction{
+			{Type: "run"},
+			attack(2),
+			{Type: models.ActionSwitch, Switch: 0},
+			{Type: models.ActionSwitch, Switch: 5},
+			{Type: models.ActionItem},
+		} {
+			if err := session.Check(1, action); !errors.Is(err, business.ErrInvalidAction) {
+				t.Fatalf("expected Check(%+v) to return ErrInvalidAction, got %v", action, err)
+			}
+		}
+		if err := session.Check(1, models.BattleAction{Type: models.ActionSwitch, Switch: 1}); err != nil {
+			t.Fatalf("expected the switch to Squirtle to be possible, got %v", err)
+		}
+		if err := session.Check(2, models.BattleAction{Type: models.ActionSwitch, Switch: 1}); !errors.Is(err, business.ErrInvalidAction) {
+			t.Fatalf("expected a team of one not to switch, got %v", err)
+		}
+
+		// una acción que no es posible no resuelve el turno
+		if err := session.Play(attack(7), attack(0)); err == nil || session.Battle().Log != nil {
+			t.Fatalf("expected the turn not to be played, got %v", err)
+		}
+	})
+
+	t.Run("strategy", func(t *testing.T) {
+		session := business.NewSession(6, team1, team2, business.WithSeed(42), business.WithStrategies(nil, business.GreedyStrategy{}))
+		if !session.IsTrainer(1) || session.IsTrainer(2) {
+			t.Fatal("expected only the first side to be controlled by a trainer")
+		}
+
+		// la acción del segundo bando se ignora
+		if err := session.Play(attack(0), models.BattleAction{Type: "ignored"}); err != nil {
+			t.Fatalf("expected Play() to return nil, got %v", err)
+		}
+		if err := session.Check(2, attack(0)); !errors.Is(err, business.ErrInvalidAction) {
+			t.Fatalf("expected Check() to reject the actions of the strategy side, got %v", err)
+		}
+		if battle := session.Battle(); battle.Settings.Strategy2 != "greedy" || battle.Turns != 2 {
+			t.Fatalf("expected the greedy strategy to play the first turn, got %+v", battle)
+		}
+	})
+}
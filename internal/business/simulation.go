@@ -0,0 +1,150 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	"pokemon-battle/internal/models"
+)
+
+const (
+	// DefaultSimulationRuns es el número de batallas simuladas por defecto.
+	DefaultSimulationRuns = 10000
+
+	// MaxSimulationRuns es el número máximo de batallas de una simulación.
+	MaxSimulationRuns = 100000
+
+	// confidenceLevel es el nivel de confianza de los intervalos de las probabilidades
+	// de victoria, y confidenceZ el cuantil de la normal correspondiente.
+	confidenceLevel = 0.95
+	confidenceZ     = 1.959963984540054
+)
+
+// ErrInvalidSimulationRuns se devuelve cuando el número de batallas de una simulación no es válido.
+var ErrInvalidSimulationRuns = errors.New("invalid number of simulation runs")
+
+// Simulate libra runs batallas entre dos Pokémon, repartidas entre todos los
+// procesadores, y devuelve las probabilidades estimadas de victoria de cada uno
+// y la distribución del número de turnos. Las batallas no se guardan.
+// La batalla i usa la semilla de las opciones más i, por lo que la misma semilla
+// produce siempre el mismo resultado. Si el contexto se cancela, la simulación
+// se detiene y devuelve el error del contexto.
+func Simulate(ctx context.Context, runs int, diceSides int, pokemon1 models.Pokemon, pokemon2 models.Pokemon, opts ...Option) (models.SimulationResult, error) {
+	if runs <= 0 || runs > MaxSimulationRuns {
+		return models.SimulationResult{}, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidSimulationRuns, MaxSimulationRuns)
+	}
+
+	// the seed of the options, or a random one, is the seed of the first battle
+	seed := newFightConfig(opts...).seed
+
+	winners := make([]int, runs)
+	turns := make([]int, runs)
+	var settings models.BattleSettings
+
+	workers := min(runtime.GOMAXPROCS(0), runs)
+	var wg sync.WaitGroup
+	var once sync.Once
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			// each worker fights every workers-th battle
+			for i := w; i < runs; i += workers {
+				if ctx.Err() != nil {
+					return
+				}
+
+				battle := Fight(diceSides, pokemon1, pokemon2, append(opts[:len(opts):len(opts)], WithSeed(seed+int64(i)))...)
+				winners[i] = battle.WinnerID
+				turns[i] = battle.Turns
+				once.Do(func() { settings = battle.Settings })
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return models.SimulationResult{}, err
+	}
+
+	result := models.SimulationResult{
+		Pokemon1ID:       pokemon1.ID,
+		Pokemon2ID:       pokemon2.ID,
+		Runs:             runs,
+		Seed:             seed,
+		Settings:         settings,
+		TurnDistribution: make(map[int]int),
+	}
+	for i := range runs {
+		switch winners[i] {
+		case 0:
+			result.Draws++
+		case pokemon1.ID:
+			result.Pokemon1Wins++
+		default:
+			result.Pokemon2Wins++
+		}
+		result.TurnDistribution[turns[i]]++
+	}
+
+	result.Pokemon1WinRate = float64(result.Pokemon1Wins) / float64(runs)
+	result.Pokemon2WinRate = float64(result.Pokemon2Wins) / float64(runs)
+	result.DrawRate = float64(result.Draws) / float64(runs)
+	result.Pokemon1WinCI = wilsonInterval(result.Pokemon1Wins, runs)
+	result.Pokemon2WinCI = wilsonInterval(result.Pokemon2Wins, runs)
+	result.Turns = turnStats(turns)
+
+	return result, nil
+}
+
+// wilsonInterval devuelve el intervalo de confianza de Wilson de una probabilidad
+// estimada a partir de los éxitos de n pruebas, que a diferencia del intervalo
+// normal no se sale de [0, 1] con probabilidades cercanas a 0 o a 1.
+func wilsonInterval(successes int, n int) models.ConfidenceInterval {
+	p := float64(successes) / float64(n)
+	z2 := confidenceZ * confidenceZ
+	nf := float64(n)
+
+	denominator := 1 + z2/nf
+	center := (p + z2/(2*nf)) / denominator
+	margin := confidenceZ * math.Sqrt(p*(1-p)/nf+z2/(4*nf*nf)) / denominator
+
+	return models.ConfidenceInterval{
+		Level: confidenceLevel,
+		Lower: math.Max(0, center-margin),
+		Upper: math.Min(1, center+margin),
+	}
+}
+
+// turnStats devuelve las estadísticas del número de turnos de las batallas.
+// Los percentiles se calculan con el método del rango más cercano.
+func turnStats(turns []int) models.TurnStats {
+	sorted := append([]int(nil), turns...)
+	sort.Ints(sorted)
+
+	total := 0
+	for _, t := range sorted {
+		total += t
+	}
+
+	percentile := func(p float64) int {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		return sorted[max(rank, 1)-1]
+	}
+
+	return models.TurnStats{
+		Min:  sorted[0],
+		Max:  sorted[len(sorted)-1],
+		Mean: float64(total) / float64(len(sorted)),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+	}
+}
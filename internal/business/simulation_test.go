@@ -0,0 +1,98 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"pokemon-battle/internal/business"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Run("odds", func(t *testing.T) {
+		result, err := business.Simulate(context.Background(), 1000, 10, strongPokemon, weakPokemon)
+		if err != nil {
+			t.Fatalf("expected Simulate() to return nil, got %v", err)
+		}
+
+		if result.Runs != 1000 || result.Pokemon1Wins != 1000 {
+			t.Fatalf("expected strongPokemon to win the 1000 battles, got %+v", result)
+		}
+		if result.Pokemon1WinRate != 1 || result.Pokemon2WinRate != 0 || result.DrawRate != 0 {
+			t.Fatalf("expected win rates 1, 0 and 0, got %v, %v and %v", result.Pokemon1WinRate, result.Pokemon2WinRate, result.DrawRate)
+		}
+
+		ci := result.Pokemon1WinCI
+		if ci.Level != 0.95 || ci.Upper != 1 || ci.Lower < 0.99 || ci.Lower >= 1 {
+			t.Fatalf("expected a 95%% interval just below 1, got %+v", ci)
+		}
+	})
+
+	t.Run("turns", func(t *testing.T) {
+		rival := strongPokemon
+		rival.ID = 3
+
+		result, err := business.Simulate(context.Background(), 500, 10, strongPokemon, rival, business.WithSeed(1))
+		if err != nil {
+			t.Fatalf("expected Simulate() to return nil, got %v", err)
+		}
+
+		if result.Pokemon1Wins+result.Pokemon2Wins+result.Draws != 500 {
+			t.Fatalf("expected 500 results, got %+v", result)
+		}
+
+		total := 0
+		for _, count := range result.TurnDistribution {
+			total += count
+		}
+		if total != 500 {
+			t.Fatalf("expected the turn distribution to add up to 500, got %d", total)
+		}
+
+		turns := result.Turns
+		if turns.Min > turns.P50 || turns.P50 > turns.P90 || turns.P90 > turns.P95 || turns.P95 > turns.P99 || turns.P99 > turns.Max {
+			t.Fatalf("expected ordered percentiles, got %+v", turns)
+		}
+		if turns.Mean < float64(turns.Min) || turns.Mean > float64(turns.Max) {
+			t.Fatalf("expected mean between min and max, got %+v", turns)
+		}
+	})
+
+	t.Run("seeded", func(t *testing.T) {
+		rival := strongPokemon
+		rival.ID = 3
+
+		result1, err := business.Simulate(context.Background(), 200, 10, strongPokemon, rival, business.WithSeed(42))
+		if err != nil {
+			t.Fatalf("expected Simulate() to return nil, got %v", err)
+		}
+		result2, err := business.Simulate(context.Background(), 200, 10, strongPokemon, rival, business.WithSeed(42))
+		if err != nil {
+			t.Fatalf("expected Simulate() to return nil, got %v", err)
+		}
+
+		if !reflect.DeepEqual(result1, result2) {
+			t.Fatalf("expected the same result for the same seed, got %+v and %+v", result1, result2)
+		}
+	})
+
+	t.Run("invalid-runs", func(t *testing.T) {
+		for _, runs := range []int{0, -1, business.MaxSimulationRuns + 1} {
+			_, err := business.Simulate(context.Background(), runs, 10, strongPokemon, weakPokemon)
+			if !errors.Is(err, business.ErrInvalidSimulationRuns) {
+				t.Fatalf("expected ErrInvalidSimulationRuns for %d runs, got %v", runs, err)
+			}
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := business.Simulate(ctx, 1000, 10, strongPokemon, weakPokemon)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
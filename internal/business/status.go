@@ -0,0 +1,180 @@
+package business
+
+import (
+	"pokemon-battle/internal/models"
+)
+
+const (
+	// poisonDamageDivisor y burnDamageDivisor indican la fracción del HP máximo
+	// que se pierde al final de cada turno por envenenamiento y quemadura.
+	poisonDamageDivisor = 8
+	burnDamageDivisor   = 16
+
+	// paralysisChance es la probabilidad de perder el turno por parálisis.
+	paralysisChance = 25
+
+	// thawChance es la probabilidad de descongelarse al inicio de cada turno.
+	thawChance = 20
+
+	// maxSleepTurns es el máximo de turnos que un Pokémon puede estar dormido.
+	maxSleepTurns = 3
+
+	// statusProcChance es la probabilidad de que un ataque sin efecto
+	// propio cause el problema de estado asociado a su tipo.
+	statusProcChance = 10
+)
+
+// typeStatus es el problema de estado que pueden causar los ataques de cada tipo.
+var typeStatus = map[string]string{
+	"poison":   models.StatusPoison,
+	"fire":     models.StatusBurn,
+	"electric": models.StatusParalysis,
+	"psychic":  models.StatusSleep,
+	"ice":      models.StatusFreeze,
+}
+
+// statusImmunities son los tipos inmunes a cada problema de estado.
+var statusImmunities = map[string][]string{
+	models.StatusPoison:    {"poison", "steel"},
+	models.StatusBurn:      {"fire"},
+	models.StatusParalysis: {"electric"},
+	models.StatusFreeze:    {"ice"},
+}
+
+// chance devuelve true con la probabilidad indicada, en porcentaje.
+func (f *fight) chance(percent int) bool {
+	return f.random.Intn(100) < percent
+}
+
+// isImmune indica si el Pokémon es inmune al problema de estado por su tipo.
+func isImmune(pokemon *Combatant, status string) bool {
+	for _, t := range ParseTypes(pokemon.Type) {
+		for _, immune := range statusImmunities[status] {
+			if normalizeType(t) == immune {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inflict causa un problema de estado a un Pokémon, si no sufre ya uno
+// y no es inmune a él.
+func (f *fight) inflict(target *Combatant, status string) {
+	if target.status != "" || isImmune(target, status) {
+		return
+	}
+
+	target.status = status
+	if status == models.StatusSleep {
+		target.statusTurns = 1 + f.random.Intn(maxSleepTurns)
+	}
+
+	f.record(models.BattleEvent{
+		Type:      models.EventStatus,
+		PokemonID: target.ID,
+		Status:    status,
+		HP:        target.HP,
+	})
+}
+
+// applyMoveEffect aplica el problema de estado de un movimiento, según su probabilidad.
+func (f *fight) applyMoveEffect(target *Combatant, move models.Move) {
+	if move.Effect == "" || !f.chance(move.EffectChance) {
+		return
+	}
+	f.inflict(target, move.Effect)
+}
+
+// procStatus aplica, con una pequeña probabilidad, el problema de estado
+// asociado al tipo del ataque: el del movimiento o el primer tipo
+// del atacante que tenga un problema de estado asociado.
+func (f *fight) procStatus(attacker *Combatant, defender *Combatant, move models.Move, hasMove bool) {
+	attackTypes := ParseTypes(attacker.Type)
+	if hasMove {
+		attackTypes = []string{move.Type}
+	}
+
+	for _, t := range attackTypes {
+		if status, ok := typeStatus[normalizeType(t)]; ok {
+			if f.chance(statusProcChance) {
+				f.inflict(defender, status)
+			}
+			return
+		}
+	}
+}
+
+// canAct indica si un Pokémon puede atacar este turno, según su problema de estado.
+// Los Pokémon dormidos o congelados pueden recuperarse al inicio del turno.
+func (f *fight) canAct(c *Combatant) bool {
+	switch c.status {
+	case models.StatusSleep:
+		if c.statusTurns == 0 {
+			f.cure(c)
+			return true
+		}
+		c.statusTurns--
+	case models.StatusFreeze:
+		if f.chance(thawChance) {
+			f.cure(c)
+			return true
+		}
+	case models.StatusParalysis:
+		if !f.chance(paralysisChance) {
+			return true
+		}
+	default:
+		return true
+	}
+
+	f.record(models.BattleEvent{
+		Type:      models.EventImmobile,
+		PokemonID: c.ID,
+		Status:    c.status,
+		HP:        c.HP,
+	})
+	return false
+}
+
+// cure recupera a un Pokémon de su problema de estado.
+func (f *fight) cure(c *Combatant) {
+	f.record(models.BattleEvent{
+		Type:      models.EventCured,
+		PokemonID: c.ID,
+		Status:    c.status,
+		HP:        c.HP,
+	})
+	c.status = ""
+	c.statusTurns = 0
+}
+
+// residual aplica el daño de final de turno del envenenamiento y la quemadura.
+func (f *fight) residual(c *Combatant) {
+	var divisor int
+	switch c.status {
+	case models.StatusPoison:
+		divisor = poisonDamageDivisor
+	case models.StatusBurn:
+		divisor = burnDamageDivisor
+	default:
+		return
+	}
+
+	damage := max(c.maxHP/divisor, 1)
+	c.HP -= damage
+
+	f.record(models.BattleEvent{
+		Type:      models.EventResidual,
+		PokemonID: c.ID,
+		Status:    c.status,
+		Damage:    damage,
+		HP:        c.HP,
+	})
+
+	if c.HP > 0 {
+		f.itemAfterDamage(c)
+	} else {
+		f.fainted(c, nil)
+	}
+}
@@ -0,0 +1,102 @@
+package business_test
+
+import (
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+var (
+	toxic     = models.Move{ID: 4, Name: "Toxic", Type: "Poison", Accuracy: 100, Category: models.MoveCategoryStatus, Effect: models.StatusPoison, EffectChance: 100}
+	willOWisp = models.Move{ID: 5, Name: "Will-O-Wisp", Type: "Fire", Accuracy: 100, Category: models.MoveCategoryStatus, Effect: models.StatusBurn, EffectChance: 100}
+	hypnosis  = models.Move{ID: 6, Name: "Hypnosis", Type: "Psychic", Accuracy: 100, Category: models.MoveCategoryStatus, Effect: models.StatusSleep, EffectChance: 100}
+)
+
+func TestFight_status(t *testing.T) {
+	t.Run("poison", func(t *testing.T) {
+		// Snorlax no puede causar daño, por lo que solo el veneno puede decidir la batalla
+		pokemon1 := models.Pokemon{ID: 1, Name: "Ekans", Type: "Poison", HP: 35, Attack: 60, Defense: 44, Moves: []models.Move{toxic}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 16, Attack: 110, Defense: 65, Moves: []models.Move{growl}}
+
+		battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(1))
+
+		if battle.WinnerID != pokemon1.ID {
+			t.Fatalf("expected winner ID to be %d, got %d", pokemon1.ID, battle.WinnerID)
+		}
+
+		var poisoned bool
+		var residualDamage int
+		for _, event := range battle.Log {
+			switch event.Type {
+			case models.EventStatus:
+				if event.PokemonID != pokemon2.ID || event.Status != models.StatusPoison {
+					t.Fatalf("expected %s to be poisoned, got %+v", pokemon2.Name, event)
+				}
+				poisoned = true
+			case models.EventResidual:
+				// el veneno quita 1/8 del HP máximo cada turno
+				if event.Damage != 2 {
+					t.Fatalf("expected residual damage to be 2, got %d", event.Damage)
+				}
+				residualDamage += event.Damage
+			}
+		}
+
+		if !poisoned {
+			t.Fatal("expected a status event in the battle log")
+		}
+		if residualDamage != pokemon2.HP {
+			t.Fatalf("expected residual damage to be %d, got %d", pokemon2.HP, residualDamage)
+		}
+	})
+
+	t.Run("immune", func(t *testing.T) {
+		// Charmander es de tipo fuego, por lo que no puede quemarse
+		pokemon1 := models.Pokemon{ID: 1, Name: "Vulpix", Type: "Fire", HP: 38, Attack: 41, Defense: 40, Moves: []models.Move{willOWisp}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Charmander", Type: "Fire", HP: 39, Attack: 90, Defense: 43}
+
+		for seed := int64(0); seed < 10; seed++ {
+			battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(seed))
+			for _, event := range battle.Log {
+				if event.Type == models.EventStatus || event.Type == models.EventResidual {
+					t.Fatalf("expected %s not to be burned, got %+v", pokemon2.Name, event)
+				}
+			}
+		}
+	})
+
+	t.Run("sleep", func(t *testing.T) {
+		// Snorlax solo pierde turnos mientras duerme, y acaba despertando y ganando
+		pokemon1 := models.Pokemon{ID: 1, Name: "Drowzee", Type: "Psychic", HP: 1, Attack: 48, Defense: 0, Moves: []models.Move{hypnosis}}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Snorlax", Type: "Normal", HP: 160, Attack: 110, Defense: 65}
+
+		for seed := int64(0); seed < 10; seed++ {
+			battle := business.Fight(10, pokemon1, pokemon2, business.WithSeed(seed))
+			if battle.WinnerID != pokemon2.ID {
+				t.Fatalf("expected winner ID to be %d, got %d", pokemon2.ID, battle.WinnerID)
+			}
+
+			var asleep, immobile, cured bool
+			for _, event := range battle.Log {
+				switch event.Type {
+				case models.EventStatus:
+					asleep = event.Status == models.StatusSleep
+				case models.EventImmobile:
+					immobile = true
+				case models.EventCured:
+					cured = true
+				case models.EventAttack:
+					if asleep && event.PokemonID == pokemon2.ID && !cured {
+						t.Fatalf("expected %s not to attack while asleep", pokemon2.Name)
+					}
+				}
+			}
+
+			// si Snorlax ataca primero, la batalla termina antes de que se duerma
+			if asleep && (!immobile || !cured) {
+				t.Fatalf("expected %s to lose turns while asleep and wake up", pokemon2.Name)
+			}
+		}
+	})
+}
@@ -0,0 +1,521 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"pokemon-battle/internal/models"
+)
+
+// ErrUnknownStrategy se devuelve cuando no existe una estrategia con el nombre indicado.
+var ErrUnknownStrategy = errors.New("unknown strategy")
+
+// Acciones que puede elegir una estrategia en cada turno.
+const (
+	ActionAttack = models.ActionAttack // El Pokémon que está combatiendo ataca
+	ActionSwitch = models.ActionSwitch // El bando cambia de Pokémon y pierde su ataque del turno
+	ActionItem   = models.ActionItem   // El Pokémon usa el objeto que lleva y pierde su ataque del turno
+)
+
+// Action es la acción que elige una estrategia para su bando en un turno.
+type Action struct {
+	Type   string // ActionAttack, ActionSwitch o ActionItem
+	Move   int    // Posición en Moves del movimiento del ataque; si es negativa se elige uno al azar
+	Switch int    // Posición en el equipo del Pokémon que entra en combate en un cambio
+}
+
+// MemberState es un Pokémon de uno de los bandos durante la batalla.
+type MemberState struct {
+	models.Pokemon // Pokémon con su HP actual y el objeto que todavía lleva
+
+	MaxHP  int    // HP del Pokémon al empezar la batalla
+	Status string // Problema de estado que sufre el Pokémon, vacío si no sufre ninguno
+}
+
+// SideState es uno de los bandos de la batalla.
+type SideState struct {
+	Team   []MemberState // Pokémon del bando, en orden de salida
+	Active int           // Posición en Team del Pokémon que está combatiendo
+}
+
+// active devuelve el Pokémon del bando que está combatiendo.
+func (s SideState) active() MemberState {
+	return s.Team[s.Active]
+}
+
+// BattleState es la información que recibe una estrategia al empezar cada turno.
+type BattleState struct {
+	Turn      int        // Turno que empieza
+	Own       SideState  // Bando de la estrategia
+	Opponent  SideState  // Bando rival
+	DiceSides int        // Caras del dado de ataque y defensa
+	TypeChart TypeChart  // Tabla de tipos de la batalla
+	Random    Randomizer // Fuente de la batalla, para que la semilla determine las decisiones aleatorias
+	Condition string     // Condición del campo en curso, vacía si no hay ninguna
+}
+
+// Strategy decide la acción de un bando en cada turno de una batalla individual
+// o por equipos: con qué movimiento ataca, si cambia de Pokémon o si usa su objeto.
+type Strategy interface {
+	// Name es el nombre de la estrategia, que se guarda con la batalla para poder repetirla.
+	Name() string
+
+	// Choose devuelve la acción del bando en el turno. Las acciones que no son
+	// posibles, como cambiar a un Pokémon debilitado, se sustituyen por un ataque
+	// con un movimiento al azar.
+	Choose(state BattleState) Action
+}
+
+// RandomStrategy elige al azar entre todas las acciones posibles.
+type RandomStrategy struct{}
+
+// Name devuelve el nombre de la estrategia.
+func (RandomStrategy) Name() string {
+	return "random"
+}
+
+// Choose elige una de las acciones posibles al azar.
+func (RandomStrategy) Choose(state BattleState) Action {
+	actions := possibleActions(state.Own)
+	return actions[state.Random.Intn(len(actions))]
+}
+
+// GreedyStrategy ataca con el movimiento que más daño causa en el turno.
+// Si ninguno de sus movimientos puede causar daño al rival, cambia al Pokémon
+// del equipo que más daño le puede causar. Es una estrategia determinista,
+// que no consume tiradas de dados.
+type GreedyStrategy struct{}
+
+// Name devuelve el nombre de la estrategia.
+func (GreedyStrategy) Name() string {
+	return "greedy"
+}
+
+// Choose elige el ataque con mayor daño esperado.
+func (GreedyStrategy) Choose(state BattleState) Action {
+	defender := state.Opponent.active()
+	move, damage := bestMove(state.Own.active(), defender, state.DiceSides, state.TypeChart)
+	if damage > 0 {
+		return Action{Type: ActionAttack, Move: move}
+	}
+
+	best := Action{Type: ActionAttack, Move: move}
+	for i, member := range state.Own.Team {
+		if i == state.Own.Active || member.HP <= 0 {
+			continue
+		}
+		if _, d := bestMove(member, defender, state.DiceSides, state.TypeChart); d > damage {
+			best, damage = Action{Type: ActionSwitch, Switch: i}, d
+		}
+	}
+	return best
+}
+
+// DefaultMinimaxDepth es la profundidad por defecto, en turnos, de MinimaxStrategy.
+const DefaultMinimaxDepth = 2
+
+// MaxMinimaxDepth es la profundidad máxima de MinimaxStrategy, ya que el número
+// de turnos simulados crece exponencialmente con ella.
+const MaxMinimaxDepth = 4
+
+// MaxTeamMinimaxDepth es la profundidad máxima de MinimaxStrategy en las batallas
+// por equipos, en las que los cambios multiplican las acciones posibles de cada turno.
+const MaxTeamMinimaxDepth = 2
+
+// minimaxNodeBudget es el número máximo de turnos que simula MinimaxStrategy para
+// elegir una acción. Al agotarlo, los estados pendientes se valoran sin explorarlos.
+const minimaxNodeBudget = 20000
+
+// MinimaxStrategy elige la acción que maximiza el HP restante de su equipo frente
+// al del rival tras Depth turnos, suponiendo que el rival responde con su mejor
+// acción. Los turnos se simulan con el daño esperado de cada ataque, por lo que es
+// una estrategia determinista, que no consume tiradas de dados. La búsqueda usa
+// poda alfa-beta y no simula más de minimaxNodeBudget turnos por decisión.
+type MinimaxStrategy struct {
+	Depth int // Turnos simulados; si no es positivo se usa DefaultMinimaxDepth
+}
+
+// Name devuelve el nombre de la estrategia, con su profundidad si no es la de por defecto.
+func (m MinimaxStrategy) Name() string {
+	if depth := m.depth(); depth != DefaultMinimaxDepth {
+		return "minimax-" + strconv.Itoa(depth)
+	}
+	return "minimax"
+}
+
+func (m MinimaxStrategy) depth() int {
+	if m.Depth <= 0 {
+		return DefaultMinimaxDepth
+	}
+	return min(m.Depth, MaxMinimaxDepth)
+}
+
+// searchDepth devuelve la profundidad de la búsqueda en el estado, limitada
+// a MaxTeamMinimaxDepth si alguno de los bandos tiene más de un Pokémon.
+func (m MinimaxStrategy) searchDepth(state BattleState) int {
+	if len(state.Own.Team) > 1 || len(state.Opponent.Team) > 1 {
+		return min(m.depth(), MaxTeamMinimaxDepth)
+	}
+	return m.depth()
+}
+
+// Choose elige la acción con mejor valoración en el peor caso.
+func (m MinimaxStrategy) Choose(state BattleState) Action {
+	action, _ := m.choose(state)
+	return action
+}
+
+// choose devuelve la acción con mejor valoración en el peor caso y el número
+// de turnos que ha simulado para elegirla.
+func (m MinimaxStrategy) choose(state BattleState) (Action, int) {
+	search := minimaxSearch{nodes: minimaxNodeBudget}
+	depth := m.searchDepth(state)
+
+	var best Action
+	bestValue := math.Inf(-1)
+	for _, own := range possibleActions(state.Own) {
+		if value := search.response(state, own, depth, bestValue, math.Inf(1)); value > bestValue {
+			best, bestValue = own, value
+		}
+	}
+	return best, minimaxNodeBudget - search.nodes
+}
+
+// minimaxSearch es una búsqueda de MinimaxStrategy, con los turnos que todavía puede simular.
+type minimaxSearch struct {
+	nodes int
+}
+
+// response devuelve la valoración de una acción cuando el rival responde con
+// la acción que peor valoración deja. Deja de buscar en cuanto la valoración
+// no supera alpha, la mejor que el bando propio ya tiene asegurada.
+func (s *minimaxSearch) response(state BattleState, own Action, depth int, alpha, beta float64) float64 {
+	worst := math.Inf(1)
+	for _, opponent := range possibleActions(state.Opponent) {
+		if s.nodes <= 0 {
+			// without turns left, the state is valued without simulating the rest of responses
+			return min(worst, evaluate(state))
+		}
+		s.nodes--
+		worst = min(worst, s.search(simulateTurn(state, own, opponent), depth-1, alpha, min(beta, worst)))
+		if worst <= alpha {
+			break
+		}
+	}
+	return worst
+}
+
+// search devuelve la valoración de un estado, explorando depth turnos más.
+// Deja de buscar en cuanto la valoración alcanza beta, la peor que el rival
+// ya tiene asegurada.
+func (s *minimaxSearch) search(state BattleState, depth int, alpha, beta float64) float64 {
+	if depth == 0 || s.nodes <= 0 || defeated(state.Own) || defeated(state.Opponent) {
+		return evaluate(state)
+	}
+
+	best := math.Inf(-1)
+	for _, own := range possibleActions(state.Own) {
+		best = max(best, s.response(state, own, depth, max(alpha, best), beta))
+		if best >= beta {
+			break
+		}
+	}
+	return best
+}
+
+// strategies son las estrategias disponibles, por nombre.
+var strategies = map[string]Strategy{
+	RandomStrategy{}.Name():  RandomStrategy{},
+	GreedyStrategy{}.Name():  GreedyStrategy{},
+	MinimaxStrategy{}.Name(): MinimaxStrategy{},
+}
+
+// StrategyByName devuelve la estrategia con el nombre indicado. La estrategia
+// minimax admite otra profundidad con el nombre "minimax-N", como "minimax-3",
+// que en las batallas por equipos no pasa de MaxTeamMinimaxDepth.
+// Con un nombre vacío devuelve nil, es decir, el bando ataca siempre con un
+// movimiento al azar.
+func StrategyByName(name string) (Strategy, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	if strategy, ok := strategies[name]; ok {
+		return strategy, nil
+	}
+	if depth, ok := strings.CutPrefix(name, "minimax-"); ok {
+		if d, err := strconv.Atoi(depth); err == nil && d > 0 && d <= MaxMinimaxDepth {
+			return MinimaxStrategy{Depth: d}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+}
+
+// possibleActions devuelve las acciones posibles del bando: atacar con cada uno
+// de sus movimientos, cambiar a cada Pokémon que puede combatir y usar su objeto.
+func possibleActions(side SideState) []Action {
+	active := side.active()
+
+	var actions []Action
+	for i := range active.Moves {
+		actions = append(actions, Action{Type: ActionAttack, Move: i})
+	}
+	if len(actions) == 0 {
+		actions = append(actions, Action{Type: ActionAttack, Move: -1})
+	}
+
+	for i, member := range side.Team {
+		if i != side.Active && member.HP > 0 {
+			actions = append(actions, Action{Type: ActionSwitch, Switch: i})
+		}
+	}
+	if canUseItem(active) {
+		actions = append(actions, Action{Type: ActionItem})
+	}
+	return actions
+}
+
+// isPossible indica si la acción es posible para el bando.
+func isPossible(side SideState, action Action) bool {
+	switch action.Type {
+	case ActionAttack:
+		return true
+	case ActionSwitch:
+		return action.Switch >= 0 && action.Switch < len(side.Team) &&
+			action.Switch != side.Active && side.Team[action.Switch].HP > 0
+	case ActionItem:
+		return canUseItem(side.active())
+	}
+	return false
+}
+
+// canUseItem indica si el Pokémon puede usar su objeto durante el turno:
+// los objetos que curan HP cuando ha perdido HP y los que curan problemas
+// de estado cuando sufre uno. El resto de objetos solo actúan por sí mismos.
+func canUseItem(member MemberState) bool {
+	if member.Item == nil {
+		return false
+	}
+	switch member.Item.Effect {
+	case models.ItemEffectHeal:
+		return member.HP < member.MaxHP
+	case models.ItemEffectCure:
+		return member.Status != ""
+	}
+	return false
+}
+
+// expectedDamage devuelve el daño esperado de un ataque, sin contar los golpes
+// críticos: la media del exceso de ataque sobre defensa en todas las tiradas
+// posibles, multiplicado por la potencia, la eficacia y la precisión del movimiento.
+func expectedDamage(attacker MemberState, defender MemberState, move models.Move, hasMove bool, diceSides int, chart TypeChart) float64 {
+	if hasMove && move.Category == models.MoveCategoryStatus {
+		return 0
+	}
+
+	attack := attacker.Attack
+	if attacker.Item != nil && attacker.Item.Effect == models.ItemEffectAttackBoost {
+		attack = attack * (100 + attacker.Item.Value) / 100
+	}
+
+	sides := max(diceSides, 1)
+	excess := 0
+	for roll := 1; roll <= sides; roll++ {
+		for targetRoll := 1; targetRoll <= sides; targetRoll++ {
+			excess += max(attack+roll-defender.Defense-targetRoll, 0)
+		}
+	}
+	damage := float64(excess) / float64(sides*sides)
+
+	if !hasMove {
+		return damage * chart.BestEffectiveness(attacker.Type, defender.Type)
+	}
+	return damage * float64(move.Power) / baseMovePower *
+		chart.Effectiveness(move.Type, ParseTypes(defender.Type)) *
+		float64(move.Accuracy) / 100
+}
+
+// bestMove devuelve la posición del movimiento con mayor daño esperado y su daño,
+// o -1 si el Pokémon no conoce ningún movimiento.
+func bestMove(attacker MemberState, defender MemberState, diceSides int, chart TypeChart) (int, float64) {
+	if len(attacker.Moves) == 0 {
+		return -1, expectedDamage(attacker, defender, models.Move{}, false, diceSides, chart)
+	}
+
+	best, bestDamage := 0, -1.0
+	for i, move := range attacker.Moves {
+		if d := expectedDamage(attacker, defender, move, true, diceSides, chart); d > bestDamage {
+			best, bestDamage = i, d
+		}
+	}
+	return best, bestDamage
+}
+
+// simulateTurn devuelve el estado tras un turno en el que cada bando realiza su
+// acción: primero los cambios y los objetos, y después los ataques por orden de
+// velocidad, con su daño esperado. Los Pokémon debilitados se sustituyen por el
+// siguiente de su equipo.
+func simulateTurn(state BattleState, own Action, opponent Action) BattleState {
+	next := state
+	next.Turn++
+	next.Own = cloneSide(state.Own)
+	next.Opponent = cloneSide(state.Opponent)
+
+	applySimulated(&next.Own, own)
+	applySimulated(&next.Opponent, opponent)
+
+	first, second := &next.Own, &next.Opponent
+	firstAction, secondAction := own, opponent
+	if second.active().Speed > first.active().Speed {
+		first, second = second, first
+		firstAction, secondAction = secondAction, firstAction
+	}
+
+	if firstAction.Type == ActionAttack {
+		strikeSimulated(first, second, firstAction, state.DiceSides, state.TypeChart)
+	}
+	if secondAction.Type == ActionAttack && second.active().HP > 0 {
+		strikeSimulated(second, first, secondAction, state.DiceSides, state.TypeChart)
+	}
+
+	replaceSimulated(first)
+	replaceSimulated(second)
+	return next
+}
+
+// cloneSide copia un bando, para simular un turno sin modificar el original.
+func cloneSide(side SideState) SideState {
+	side.Team = append([]MemberState(nil), side.Team...)
+	return side
+}
+
+// applySimulated aplica un cambio o el uso de un objeto en un turno simulado.
+func applySimulated(side *SideState, action Action) {
+	switch action.Type {
+	case ActionSwitch:
+		side.Active = action.Switch
+	case ActionItem:
+		member := &side.Team[side.Active]
+		if member.Item.Effect == models.ItemEffectHeal {
+			member.HP = min(member.HP+member.Item.Value, member.MaxHP)
+		} else {
+			member.Status = ""
+		}
+		if member.Item.Consumable {
+			member.Item = nil
+		}
+	}
+}
+
+// strikeSimulated aplica el daño esperado de un ataque en un turno simulado.
+func strikeSimulated(attacker *SideState, defender *SideState, action Action, diceSides int, chart TypeChart) {
+	a := attacker.active()
+	move, hasMove := models.Move{}, false
+	if action.Move >= 0 && action.Move < len(a.Moves) {
+		move, hasMove = a.Moves[action.Move], true
+	}
+
+	target := &defender.Team[defender.Active]
+	target.HP -= int(math.Round(expectedDamage(a, *target, move, hasMove, diceSides, chart)))
+}
+
+// replaceSimulated sustituye al Pokémon debilitado de un bando por el siguiente
+// que puede combatir, como en las batallas por equipos.
+func replaceSimulated(side *SideState) {
+	if side.active().HP > 0 {
+		return
+	}
+	for i, member := range side.Team {
+		if member.HP > 0 {
+			side.Active = i
+			return
+		}
+	}
+}
+
+// defeated indica si todos los Pokémon del bando se han debilitado.
+func defeated(side SideState) bool {
+	for _, member := range side.Team {
+		if member.HP > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate valora un estado para el bando propio: la fracción de HP que le queda
+// a cada Pokémon del equipo menos la del equipo rival, con una valoración extrema
+// si uno de los bandos ha perdido.
+func evaluate(state BattleState) float64 {
+	switch {
+	case defeated(state.Opponent):
+		return math.MaxFloat64
+	case defeated(state.Own):
+		return -math.MaxFloat64
+	}
+	return remainingHP(state.Own) - remainingHP(state.Opponent)
+}
+
+// remainingHP devuelve la suma de la fracción de HP que le queda a cada Pokémon del bando.
+func remainingHP(side SideState) float64 {
+	total := 0.0
+	for _, member := range side.Team {
+		if member.HP > 0 && member.MaxHP > 0 {
+			total += float64(member.HP) / float64(member.MaxHP)
+		}
+	}
+	return total
+}
+
+// sideState devuelve la información de un equipo que recibe una estrategia.
+func (t *team) sideState() SideState {
+	side := SideState{
+		Team:   make([]MemberState, len(t.members)),
+		Active: t.current,
+	}
+	for i, member := range t.members {
+		pokemon := member.Pokemon
+		pokemon.Item = member.item
+		side.Team[i] = MemberState{Pokemon: pokemon, MaxHP: member.maxHP, Status: member.status}
+	}
+	return side
+}
+
+// takeAction decide la acción del equipo en el turno y realiza los cambios y el
+// uso de objetos, guardando el movimiento de su ataque. Indica si el equipo ha
+// gastado su turno, por lo que no ataca. Sin estrategia, el equipo solo cambia
+// de Pokémon según su estrategia de cambios y ataca con un movimiento al azar.
+func (f *fight) takeAction(t *team, opponent *team) bool {
+	t.move = -1
+	if t.ai == nil {
+		return f.voluntarySwitch(t, opponent)
+	}
+
+	state := BattleState{
+		Turn:      f.turn,
+		Own:       t.sideState(),
+		Opponent:  opponent.sideState(),
+		DiceSides: f.diceSides,
+		TypeChart: f.cfg.typeChart,
+		Random:    f.random,
+		Condition: f.condition,
+	}
+	action := t.ai.Choose(state)
+	if !isPossible(state.Own, action) {
+		return false
+	}
+
+	switch action.Type {
+	case ActionSwitch:
+		f.switchTo(t, opponent, action.Switch, false)
+		return true
+	case ActionItem:
+		f.useHeldItem(t.active())
+		return true
+	}
+	t.move = action.Move
+	return false
+}
@@ -0,0 +1,55 @@
+package business
+
+import (
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+func TestMinimaxStrategy_budget(t *testing.T) {
+	moves := []models.Move{
+		{ID: 1, Name: "Growl", Type: "Normal", Accuracy: 100, Category: models.MoveCategoryStatus},
+		{ID: 2, Name: "Tackle", Type: "Normal", Power: 40, Accuracy: 100, Category: models.MoveCategoryPhysical},
+		{ID: 3, Name: "Water Gun", Type: "Water", Power: 40, Accuracy: 100, Category: models.MoveCategorySpecial},
+		{ID: 4, Name: "Ember", Type: "Fire", Power: 40, Accuracy: 100, Category: models.MoveCategorySpecial},
+	}
+	// side devuelve un bando de size Pokémon heridos, que pueden usar su poción
+	side := func(firstID int, size int) SideState {
+		team := make([]MemberState, size)
+		for i := range team {
+			pokemon := models.Pokemon{ID: firstID + i, Name: "Eevee", Type: "Normal", HP: 150, Attack: 40, Defense: 30, Speed: 50 + i, Moves: moves,
+				Item: &models.Item{Name: "Potion", Effect: models.ItemEffectHeal, Value: 20, Threshold: 50, Consumable: true}}
+			team[i] = MemberState{Pokemon: pokemon, MaxHP: 200}
+		}
+		return SideState{Team: team}
+	}
+
+	t.Run("teams", func(t *testing.T) {
+		// con seis Pokémon por equipo, cada turno tiene cien combinaciones de acciones:
+		// la profundidad se limita y no se simulan más turnos que los del presupuesto
+		state := BattleState{Turn: 1, Own: side(1, 6), Opponent: side(7, 6), DiceSides: 6, TypeChart: DefaultTypeChart()}
+
+		_, deep := MinimaxStrategy{Depth: MaxMinimaxDepth}.choose(state)
+		_, capped := MinimaxStrategy{Depth: MaxTeamMinimaxDepth}.choose(state)
+		if deep != capped {
+			t.Fatalf("expected the depth to be capped at %d, simulated %d turns instead of %d", MaxTeamMinimaxDepth, deep, capped)
+		}
+		if deep == 0 || deep > minimaxNodeBudget {
+			t.Fatalf("expected at most %d simulated turns, got %d", minimaxNodeBudget, deep)
+		}
+	})
+
+	t.Run("single", func(t *testing.T) {
+		// en las batallas individuales la profundidad no se limita
+		state := BattleState{Turn: 1, Own: side(1, 1), Opponent: side(7, 1), DiceSides: 6, TypeChart: DefaultTypeChart()}
+
+		_, deep := MinimaxStrategy{Depth: MaxMinimaxDepth}.choose(state)
+		_, shallow := MinimaxStrategy{Depth: DefaultMinimaxDepth}.choose(state)
+		if deep <= shallow {
+			t.Fatalf("expected the deeper search to simulate more than %d turns, got %d", shallow, deep)
+		}
+		if deep > minimaxNodeBudget {
+			t.Fatalf("expected at most %d simulated turns, got %d", minimaxNodeBudget, deep)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package business_test
+
+import (
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+// itemStrategy usa el objeto del Pokémon siempre que puede y, si no, ataca.
+type itemStrategy struct{}
+
+func (itemStrategy) Name() string {
+	return "item"
+}
+
+func (itemStrategy) Choose(state business.BattleState) business.Action {
+	active := state.Own.Team[state.Own.Active]
+	if active.Item != nil && active.HP < active.MaxHP {
+		return business.Action{Type: business.ActionItem}
+	}
+	return business.Action{Type: business.ActionAttack, Move: -1}
+}
+
+func TestStrategies(t *testing.T) {
+	growl := models.Move{ID: 1, Name: "Growl", Type: "Normal", Accuracy: 100, Category: models.MoveCategoryStatus}
+	tackle := models.Move{ID: 2, Name: "Tackle", Type: "Normal", Power: 40, Accuracy: 100, Category: models.MoveCategoryPhysical}
+	waterGun := models.Move{ID: 3, Name: "Water Gun", Type: "Water", Power: 40, Accuracy: 100, Category: models.MoveCategorySpecial}
+
+	squirtle := models.Pokemon{ID: 1, Name: "Squirtle", Type: "Water", HP: 100, Attack: 30, Defense: 30, Speed: 40, Moves: []models.Move{growl, tackle, waterGun}}
+	charmander := models.Pokemon{ID: 2, Name: "Charmander", Type: "Fire", HP: 100, Attack: 30, Defense: 30, Speed: 50}
+
+	t.Run("greedy", func(t *testing.T) {
+		battle := business.Fight(6, squirtle, charmander,
+			business.WithSeed(42), business.WithMaxTurns(5),
+			business.WithStrategies(business.GreedyStrategy{}, nil))
+
+		if battle.Settings.Strategy1 != "greedy" || battle.Settings.Strategy2 != "" {
+			t.Fatalf("expected the strategies in the settings, got %+v", battle.Settings)
+		}
+		for _, event := range battle.Log {
+			if event.Type == models.EventAttack && event.PokemonID == squirtle.ID && event.MoveID != waterGun.ID {
+				t.Fatalf("expected Squirtle to always use Water Gun, got %+v", event)
+			}
+		}
+	})
+
+	t.Run("greedy-switch", func(t *testing.T) {
+		// los ataques normales no afectan a los fantasmas
+		snorlax := models.Pokemon{ID: 1, Name: "Snorlax", Type: "Normal", HP: 100, Attack: 30, Defense: 30}
+		gengar := models.Pokemon{ID: 3, Name: "Gengar", Type: "Ghost", HP: 100, Attack: 30, Defense: 30}
+
+		battle := business.FightTeams(6, []models.Pokemon{snorlax, charmander}, []models.Pokemon{gengar},
+			business.WithSeed(42), business.WithMaxTurns(1),
+			business.WithStrategies(business.GreedyStrategy{}, nil))
+
+		if len(battle.Log) == 0 || battle.Log[0].Type != models.EventSwitch || battle.Log[0].Forced || battle.Log[0].PokemonID != charmander.ID {
+			t.Fatalf("expected Charmander to come in at the start of the battle, got %+v", battle.Log)
+		}
+	})
+
+	t.Run("minimax", func(t *testing.T) {
+		state := business.BattleState{
+			Turn:      1,
+			Own:       business.SideState{Team: []business.MemberState{{Pokemon: squirtle, MaxHP: squirtle.HP}}},
+			Opponent:  business.SideState{Team: []business.MemberState{{Pokemon: charmander, MaxHP: charmander.HP}}},
+			DiceSides: 6,
+			TypeChart: business.DefaultTypeChart(),
+		}
+
+		action := business.MinimaxStrategy{}.Choose(state)
+		if action.Type != business.ActionAttack || action.Move != 2 {
+			t.Fatalf("expected minimax to use Water Gun, got %+v", action)
+		}
+
+		// sin compañeros de equipo, el Pokémon herido no puede cambiar
+		wounded := state
+		wounded.Own.Team = []business.MemberState{{Pokemon: squirtle, MaxHP: 200}}
+		wounded.Own.Team[0].Item = &models.Item{Name: "Potion", Effect: models.ItemEffectHeal, Value: 20, Threshold: 50, Consumable: true}
+		if action := (business.MinimaxStrategy{Depth: 3}).Choose(wounded); action.Type == business.ActionSwitch {
+			t.Fatalf("expected minimax not to switch without teammates, got %+v", action)
+		}
+
+		// los ataques normales no afectan a los fantasmas, y los siniestros son eficaces contra ellos
+		umbreon := models.Pokemon{ID: 5, Name: "Umbreon", Type: "Dark", HP: 100, Attack: 30, Defense: 30}
+		snorlax := models.Pokemon{ID: 4, Name: "Snorlax", Type: "Normal", HP: 100, Attack: 30, Defense: 30}
+		gengar := models.Pokemon{ID: 3, Name: "Gengar", Type: "Ghost", HP: 100, Attack: 30, Defense: 30}
+		ghost := state
+		ghost.Own = business.SideState{Team: []business.MemberState{{Pokemon: snorlax, MaxHP: snorlax.HP}, {Pokemon: umbreon, MaxHP: umbreon.HP}}}
+		ghost.Opponent = business.SideState{Team: []business.MemberState{{Pokemon: gengar, MaxHP: gengar.HP}}}
+		if action := (business.MinimaxStrategy{}).Choose(ghost); action.Type != business.ActionSwitch || action.Switch != 1 {
+			t.Fatalf("expected minimax to switch to Umbreon, got %+v", action)
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		opts := []business.Option{business.WithSeed(7), business.WithStrategies(business.RandomStrategy{}, business.RandomStrategy{})}
+		battle := business.Fight(6, squirtle, charmander, opts...)
+		again := business.Fight(6, squirtle, charmander, opts...)
+
+		if battle.WinnerID != again.WinnerID || battle.Turns != again.Turns || len(battle.Log) != len(again.Log) {
+			t.Fatal("expected the seed to determine the decisions of the random strategy")
+		}
+
+		replay, err := business.Replay(battle)
+		if err != nil {
+			t.Fatalf("expected Replay() to return nil, got %v", err)
+		}
+		if !replay.Matches {
+			t.Fatalf("expected the replay to match, got %+v", replay)
+		}
+	})
+
+	t.Run("item", func(t *testing.T) {
+		holder := charmander
+		holder.Item = &models.Item{ID: 1, Name: "Potion", Effect: models.ItemEffectHeal, Value: 20, Threshold: 1, Consumable: true}
+
+		battle := business.Fight(6, squirtle, holder, business.WithSeed(42), business.WithMaxTurns(10),
+			business.WithStrategies(nil, itemStrategy{}))
+
+		events := itemEvents(battle, holder.ID)
+		if len(events) != 1 || events[0].Healed <= 0 {
+			t.Fatalf("expected the potion to be used once, got %+v", events)
+		}
+		// el Pokémon pierde su ataque en el turno en que usa el objeto
+		for _, event := range battle.Log {
+			if event.Type == models.EventAttack && event.PokemonID == holder.ID && event.Turn == events[0].Turn {
+				t.Fatalf("expected Charmander not to attack in turn %d, got %+v", event.Turn, event)
+			}
+		}
+		if len(battle.ItemUses) != 1 || !battle.ItemUses[0].Consumed {
+			t.Fatalf("expected the potion to be consumed, got %+v", battle.ItemUses)
+		}
+	})
+}
+
+func TestStrategyByName(t *testing.T) {
+	for _, name := range []string{"random", "greedy", "minimax", "minimax-3"} {
+		strategy, err := business.StrategyByName(name)
+		if err != nil {
+			t.Fatalf("expected StrategyByName(%q) to return nil, got %v", name, err)
+		}
+		if strategy.Name() != name {
+			t.Fatalf("expected the strategy %q, got %q", name, strategy.Name())
+		}
+	}
+
+	if strategy, err := business.StrategyByName(""); strategy != nil || err != nil {
+		t.Fatalf("expected no strategy for an empty name, got %v, %v", strategy, err)
+	}
+	for _, name := range []string{"unknown", "minimax-0", "minimax-9", "minimax-x"} {
+		if _, err := business.StrategyByName(name); !errors.Is(err, business.ErrUnknownStrategy) {
+			t.Fatalf("expected StrategyByName(%q) to return ErrUnknownStrategy, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package business
+
+import (
+	"errors"
+	"fmt"
+
+	"pokemon-battle/internal/models"
+)
+
+// ErrUnknownSwitchStrategy se devuelve cuando no existe una estrategia de cambios con el nombre indicado.
+var ErrUnknownSwitchStrategy = errors.New("unknown switch strategy")
+
+// SwitchState es la información que recibe una estrategia de cambios al empezar cada turno.
+type SwitchState struct {
+	Team      []models.Pokemon // Pokémon del equipo con su HP actual, en orden de salida
+	Active    int              // Posición en Team del Pokémon que está combatiendo
+	Opponent  models.Pokemon   // Pokémon del equipo rival que está combatiendo
+	TypeChart TypeChart        // Tabla de tipos de la batalla
+}
+
+// SwitchStrategy decide si un equipo cambia voluntariamente de Pokémon al empezar un turno.
+// El equipo que cambia de Pokémon pierde su ataque de ese turno.
+type SwitchStrategy interface {
+	// Name es el nombre de la estrategia, que se guarda con la batalla para poder repetirla.
+	Name() string
+
+	// Switch devuelve la posición en el equipo del Pokémon que debe entrar en combate,
+	// o -1 para no cambiar. Las posiciones de Pokémon debilitados se ignoran.
+	Switch(state SwitchState) int
+}
+
+// TypeAdvantageSwitch cambia de Pokémon cuando el que está combatiendo tiene desventaja
+// de tipo contra el rival y otro del equipo tiene una ventaja mayor.
+// Es una estrategia determinista, que no consume tiradas de dados.
+type TypeAdvantageSwitch struct{}
+
+// Name devuelve el nombre de la estrategia.
+func (TypeAdvantageSwitch) Name() string {
+	return "type-advantage"
+}
+
+// Switch elige el Pokémon del equipo con mayor ventaja de tipo contra el rival,
+// si el que está combatiendo tiene desventaja.
+func (TypeAdvantageSwitch) Switch(state SwitchState) int {
+	advantage := func(pokemon models.Pokemon) float64 {
+		return state.TypeChart.BestEffectiveness(pokemon.Type, state.Opponent.Type) -
+			state.TypeChart.BestEffectiveness(state.Opponent.Type, pokemon.Type)
+	}
+
+	best, bestAdvantage := -1, advantage(state.Team[state.Active])
+	if bestAdvantage >= 0 {
+		return -1
+	}
+
+	for i, pokemon := range state.Team {
+		if i == state.Active || pokemon.HP <= 0 {
+			continue
+		}
+		if a := advantage(pokemon); a > bestAdvantage {
+			best, bestAdvantage = i, a
+		}
+	}
+	return best
+}
+
+// switchStrategies son las estrategias de cambios disponibles, por nombre.
+var switchStrategies = map[string]SwitchStrategy{
+	TypeAdvantageSwitch{}.Name(): TypeAdvantageSwitch{},
+}
+
+// SwitchStrategyByName devuelve la estrategia de cambios con el nombre indicado.
+// Con un nombre vacío devuelve nil, es decir, el equipo solo cambia de Pokémon
+// cuando se debilita el que está combatiendo.
+func SwitchStrategyByName(name string) (SwitchStrategy, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	strategy, ok := switchStrategies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSwitchStrategy, name)
+	}
+	return strategy, nil
+}
+
+// strategyName devuelve el nombre de la estrategia, vacío si no hay estrategia.
+func strategyName(strategy interface{ Name() string }) string {
+	if strategy == nil {
+		return ""
+	}
+	return strategy.Name()
+}
+
+// team es uno de los bandos de una batalla: sus Pokémon en orden de salida
+// y el que está combatiendo.
+type team struct {
+	members  []*Combatant
+	current  int
+	strategy SwitchStrategy
+
+	// ai decide la acción del equipo en cada turno, en lugar de strategy,
+	// y move es la posición del movimiento elegido para el ataque del turno
+	ai   Strategy
+	move int
+}
+
+func newTeam(pokemons []models.Pokemon, strategy SwitchStrategy, ai Strategy) *team {
+	t := &team{strategy: strategy, ai: ai, move: -1}
+	for _, pokemon := range pokemons {
+		t.members = append(t.members, newCombatant(pokemon))
+	}
+	return t
+}
+
+// active devuelve el Pokémon del equipo que está combatiendo.
+func (t *team) active() *Combatant {
+	return t.members[t.current]
+}
+
+// next devuelve la posición del siguiente Pokémon del equipo que puede combatir,
+// o -1 si todos se han debilitado.
+func (t *team) next() int {
+	for i, member := range t.members {
+		if i != t.current && member.HP > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// criticals devuelve el número de golpes críticos causados por el equipo.
+func (t *team) criticals() int {
+	return totalCriticals(t.members)
+}
+
+// totalCriticals devuelve el número de golpes críticos causados por los Pokémon.
+func totalCriticals(members []*Combatant) int {
+	criticals := 0
+	for _, member := range members {
+		criticals += member.criticals
+	}
+	return criticals
+}
+
+// state devuelve la información del equipo que recibe su estrategia de cambios.
+func (t *team) state(opponent *team, chart TypeChart) SwitchState {
+	state := SwitchState{
+		Team:      make([]models.Pokemon, len(t.members)),
+		Active:    t.current,
+		Opponent:  opponent.active().Pokemon,
+		TypeChart: chart,
+	}
+	for i, member := range t.members {
+		state.Team[i] = member.Pokemon
+	}
+	return state
+}
+
+// switchTo hace entrar en combate al Pokémon de la posición indicada, registrando el cambio.
+func (f *fight) switchTo(t *team, opponent *team, position int, forced bool) {
+	replaced := t.active()
+	t.current = position
+	incoming := t.active()
+
+	f.record(models.BattleEvent{
+		Type:       models.EventSwitch,
+		PokemonID:  incoming.ID,
+		TargetID:   opponent.active().ID,
+		ReplacedID: replaced.ID,
+		Forced:     forced,
+		HP:         incoming.HP,
+		TargetHP:   opponent.active().HP,
+	})
+}
+
+// voluntarySwitch pregunta a la estrategia del equipo si quiere cambiar de Pokémon,
+// e indica si lo ha cambiado.
+func (f *fight) voluntarySwitch(t *team, opponent *team) bool {
+	if t.strategy == nil || len(t.members) == 1 {
+		return false
+	}
+
+	position := t.strategy.Switch(t.state(opponent, f.cfg.typeChart))
+	if position < 0 || position >= len(t.members) || position == t.current || t.members[position].HP <= 0 {
+		return false
+	}
+
+	f.switchTo(t, opponent, position, false)
+	return true
+}
+
+// replaceFainted hace entrar al siguiente Pokémon del equipo en lugar del que
+// se ha debilitado. Devuelve false si al equipo no le quedan Pokémon.
+func (f *fight) replaceFainted(t *team, opponent *team) bool {
+	position := t.next()
+	if position < 0 {
+		return false
+	}
+
+	f.switchTo(t, opponent, position, true)
+	return true
+}
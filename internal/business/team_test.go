@@ -0,0 +1,132 @@
+package business_test
+
+import (
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestFightTeams(t *testing.T) {
+	t.Run("replacements", func(t *testing.T) {
+		// el primer equipo no puede aguantar ni un ataque del segundo
+		team1 := []models.Pokemon{
+			{ID: 1, Name: "Caterpie", Type: "Bug", HP: 1},
+			{ID: 2, Name: "Weedle", Type: "Bug", HP: 1},
+			{ID: 3, Name: "Magikarp", Type: "Water", HP: 1},
+		}
+		team2 := []models.Pokemon{strongPokemon}
+		team2[0].ID = 4
+
+		battle := business.FightTeams(10, team1, team2, business.WithSeed(1))
+
+		if battle.WinnerID != team2[0].ID {
+			t.Fatalf("expected winner ID to be %d, got %d", team2[0].ID, battle.WinnerID)
+		}
+		if len(battle.Team1) != 3 || len(battle.Team2) != 1 || battle.Pokemon1ID != 1 || battle.Pokemon2ID != 4 {
+			t.Fatalf("expected rosters [1 2 3] and [4], got %v and %v", battle.Team1, battle.Team2)
+		}
+		if len(battle.Participants) != 4 {
+			t.Fatalf("expected 4 participants, got %d", len(battle.Participants))
+		}
+		if err := battle.Validate(); err != nil {
+			t.Fatalf("expected battle to be valid, got %v", err)
+		}
+
+		// cada Pokémon entra en orden cuando se debilita el anterior
+		var switches, faints []models.BattleEvent
+		for _, event := range battle.Log {
+			switch event.Type {
+			case models.EventSwitch:
+				switches = append(switches, event)
+			case models.EventFaint:
+				faints = append(faints, event)
+			}
+		}
+		if len(faints) != 3 {
+			t.Fatalf("expected 3 faint events, got %d", len(faints))
+		}
+		if len(switches) != 2 {
+			t.Fatalf("expected 2 switch events, got %d", len(switches))
+		}
+		for i, event := range switches {
+			if !event.Forced || event.ReplacedID != team1[i].ID || event.PokemonID != team1[i+1].ID || event.TargetID != team2[0].ID {
+				t.Fatalf("expected %s to replace %s, got %+v", team1[i+1].Name, team1[i].Name, event)
+			}
+		}
+	})
+
+	t.Run("winner", func(t *testing.T) {
+		team1 := []models.Pokemon{strongPokemon, strongPokemon, strongPokemon}
+		for i := range team1 {
+			team1[i].ID = i + 1
+		}
+		team2 := []models.Pokemon{weakPokemon, weakPokemon, weakPokemon}
+		for i := range team2 {
+			team2[i].ID = i + 4
+		}
+
+		battle := business.FightTeams(10, team1, team2)
+
+		if battle.Side(battle.WinnerID) != 1 {
+			t.Fatalf("expected the first team to win, got winner ID %d", battle.WinnerID)
+		}
+	})
+
+	t.Run("switch-strategy", func(t *testing.T) {
+		// Charmander tiene desventaja contra Squirtle, y Pikachu ventaja
+		team1 := []models.Pokemon{
+			{ID: 1, Name: "Charmander", Type: "Fire", HP: 39, Attack: 52, Defense: 43, Speed: 65},
+			{ID: 2, Name: "Pikachu", Type: "Electric", HP: 35, Attack: 55, Defense: 40, Speed: 90},
+		}
+		team2 := []models.Pokemon{
+			{ID: 3, Name: "Squirtle", Type: "Water", HP: 44, Attack: 48, Defense: 65, Speed: 43},
+		}
+
+		battle := business.FightTeams(10, team1, team2,
+			business.WithSeed(1),
+			business.WithSwitchStrategies(business.TypeAdvantageSwitch{}, nil),
+		)
+
+		first := battle.Log[0]
+		if first.Type != models.EventSwitch || first.Forced || first.PokemonID != 2 || first.ReplacedID != 1 {
+			t.Fatalf("expected Pikachu to replace Charmander before the first turn, got %+v", first)
+		}
+		if battle.Settings.SwitchStrategy1 != "type-advantage" || battle.Settings.SwitchStrategy2 != "" {
+			t.Fatalf("expected switch strategies to be stored, got %+v", battle.Settings)
+		}
+
+		// el equipo que cambia pierde su ataque del turno
+		for _, event := range battle.Log {
+			if event.Turn == 1 && event.Type == models.EventAttack && event.PokemonID != 3 {
+				t.Fatalf("expected only Squirtle to attack in the first turn, got %+v", event)
+			}
+		}
+
+		replay, err := business.Replay(battle)
+		if err != nil {
+			t.Fatalf("expected Replay() to return nil, got %v", err)
+		}
+		if !replay.Matches {
+			t.Fatalf("expected replay to match, got %+v", replay)
+		}
+	})
+}
+
+func TestSwitchStrategyByName(t *testing.T) {
+	strategy, err := business.SwitchStrategyByName("type-advantage")
+	if err != nil || strategy == nil {
+		t.Fatalf("expected type-advantage strategy, got %v, %v", strategy, err)
+	}
+
+	strategy, err = business.SwitchStrategyByName("")
+	if err != nil || strategy != nil {
+		t.Fatalf("expected no strategy, got %v, %v", strategy, err)
+	}
+
+	_, err = business.SwitchStrategyByName("unknown")
+	if !errors.Is(err, business.ErrUnknownSwitchStrategy) {
+		t.Fatalf("expected ErrUnknownSwitchStrategy, got %v", err)
+	}
+}
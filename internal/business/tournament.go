@@ -0,0 +1,476 @@
+package business
+
+import (
+	"errors"
+	"math/bits"
+	"sort"
+
+	"pokemon-battle/internal/models"
+)
+
+var (
+	// ErrTournamentFinished se devuelve al intentar jugar una ronda de un torneo terminado.
+	ErrTournamentFinished = errors.New("tournament is already finished")
+
+	// ErrInvalidTournamentRounds se devuelve cuando el número de rondas de un torneo no es válido.
+	ErrInvalidTournamentRounds = errors.New("invalid number of tournament rounds")
+
+	// ErrNoBracket se devuelve al pedir el cuadro de un torneo que no es de eliminación directa.
+	ErrNoBracket = errors.New("only single-elimination tournaments have a bracket")
+)
+
+const (
+	// maxTiebreakBattles es el número máximo de batallas para deshacer un empate en
+	// un torneo de eliminación directa. Si todas terminan en empate, pasa de ronda
+	// el mejor cabeza de serie.
+	maxTiebreakBattles = 10
+
+	// tiebreakSeedStride separa las semillas de las batallas de desempate de las
+	// semillas del resto de batallas del torneo.
+	tiebreakSeedStride = 1 << 20
+
+	// maxPairingBacktracks es el número máximo de vueltas atrás al buscar un
+	// emparejamiento suizo sin repetir enfrentamientos. Al agotarlas, la ronda
+	// se empareja de forma voraz y puede repetir alguno.
+	maxPairingBacktracks = 1000
+
+	// Puntos de cada resultado en la clasificación de un torneo.
+	winPoints  = 3
+	drawPoints = 1
+)
+
+// BattleRecorder guarda una batalla de un torneo y le asigna su ID.
+type BattleRecorder func(battle *models.Battle) error
+
+// NewTournament crea un torneo entre los Pokémon, ordenados por cabeza de serie,
+// y empareja su primera ronda. Solo los torneos suizos admiten un número de
+// rondas, que por defecto es el logaritmo en base 2 del número de Pokémon,
+// redondeado hacia arriba, y nunca puede ser mayor que el número de rivales.
+func NewTournament(name string, format string, pokemonIDs []int, rounds int) (models.Tournament, error) {
+	t := models.Tournament{
+		Name:         name,
+		Format:       format,
+		PokemonIDs:   pokemonIDs,
+		CurrentRound: 1,
+		Status:       models.TournamentInProgress,
+	}
+	if err := t.Validate(); err != nil {
+		return models.Tournament{}, err
+	}
+
+	n := len(pokemonIDs)
+	switch format {
+	case models.TournamentSwiss:
+		if rounds < 0 || rounds > n-1 {
+			return models.Tournament{}, ErrInvalidTournamentRounds
+		}
+		if rounds == 0 {
+			rounds = min(log2Ceil(n), n-1)
+		}
+		t.Rounds = rounds
+	case models.TournamentSingleElimination:
+		if rounds != 0 {
+			return models.Tournament{}, ErrInvalidTournamentRounds
+		}
+		t.Rounds = log2Ceil(n)
+	case models.TournamentRoundRobin:
+		if rounds != 0 {
+			return models.Tournament{}, ErrInvalidTournamentRounds
+		}
+		// with an odd number of pokemon, every pokemon rests one round
+		t.Rounds = n - 1 + n%2
+	}
+
+	pairRound(&t)
+	return t, nil
+}
+
+// PlayRound libra los enfrentamientos pendientes de la ronda actual del torneo,
+// guarda cada batalla con record y empareja la siguiente ronda, o termina el
+// torneo si era la última. Si la semilla de las opciones está fijada, el
+// enfrentamiento i del torneo usa la semilla más i.
+//
+// Si record falla, los enfrentamientos ya guardados quedan resueltos y la ronda
+// puede volver a jugarse para librar el resto.
+func PlayRound(t *models.Tournament, pokemons map[int]models.Pokemon, record BattleRecorder, diceSides int, opts ...Option) error {
+	if t.Status == models.TournamentFinished {
+		return ErrTournamentFinished
+	}
+
+	seed := newFightConfig(opts...).seed
+
+	for i := range t.Matches {
+		match := &t.Matches[i]
+		if match.Round != t.CurrentRound || match.Played {
+			continue
+		}
+
+		if match.IsBye() {
+			match.WinnerID = match.Pokemon1ID
+			match.Played = true
+			continue
+		}
+
+		var battle models.Battle
+		for attempt := 0; attempt < maxTiebreakBattles; attempt++ {
+			matchSeed := seed + int64(i) + int64(attempt)*tiebreakSeedStride
+			battle = Fight(diceSides, pokemons[match.Pokemon1ID], pokemons[match.Pokemon2ID], append(opts[:len(opts):len(opts)], WithSeed(matchSeed))...)
+
+			// only the single elimination tournaments need a winner
+			if !battle.IsDraw() || t.Format != models.TournamentSingleElimination {
+				break
+			}
+		}
+
+		battle.TournamentID = t.ID
+		battle.Round = t.CurrentRound
+		if err := record(&battle); err != nil {
+			return err
+		}
+
+		match.BattleID = battle.ID
+		match.WinnerID = battle.WinnerID
+		if battle.IsDraw() && t.Format == models.TournamentSingleElimination {
+			match.WinnerID = match.Pokemon1ID
+		}
+		match.Played = true
+	}
+
+	if t.CurrentRound == t.Rounds {
+		t.Status = models.TournamentFinished
+		t.WinnerID = tournamentWinner(*t)
+		return nil
+	}
+
+	t.CurrentRound++
+	pairRound(t)
+	return nil
+}
+
+// tournamentWinner devuelve el ganador de un torneo terminado: el de la final en
+// la eliminación directa y el primero de la clasificación en el resto.
+func tournamentWinner(t models.Tournament) int {
+	if t.Format == models.TournamentSingleElimination {
+		final := roundMatches(t, t.Rounds)
+		return final[0].WinnerID
+	}
+	return Standings(t)[0].PokemonID
+}
+
+// pairRound empareja la ronda actual del torneo.
+func pairRound(t *models.Tournament) {
+	var pairs [][2]int
+	switch t.Format {
+	case models.TournamentSingleElimination:
+		pairs = pairSingleElimination(*t)
+	case models.TournamentRoundRobin:
+		pairs = pairRoundRobin(t.PokemonIDs, t.CurrentRound)
+	case models.TournamentSwiss:
+		pairs = pairSwiss(*t)
+	}
+
+	for position, pair := range pairs {
+		// the pokemon that rests is always the first one
+		if pair[0] == 0 {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		t.Matches = append(t.Matches, models.TournamentMatch{
+			Round:      t.CurrentRound,
+			Position:   position,
+			Pokemon1ID: pair[0],
+			Pokemon2ID: pair[1],
+		})
+	}
+}
+
+// pairSingleElimination empareja la primera ronda según el orden del cuadro, con
+// los descansos para los mejores cabezas de serie, y cada ronda siguiente con
+// los ganadores de cada dos enfrentamientos consecutivos de la anterior.
+func pairSingleElimination(t models.Tournament) [][2]int {
+	if t.CurrentRound == 1 {
+		order := bracketOrder(1 << log2Ceil(len(t.PokemonIDs)))
+
+		pairs := make([][2]int, 0, len(order)/2)
+		for i := 0; i < len(order); i += 2 {
+			pairs = append(pairs, [2]int{seedID(t.PokemonIDs, order[i]), seedID(t.PokemonIDs, order[i+1])})
+		}
+		return pairs
+	}
+
+	previous := roundMatches(t, t.CurrentRound-1)
+	pairs := make([][2]int, 0, len(previous)/2)
+	for i := 0; i+1 < len(previous); i += 2 {
+		pairs = append(pairs, [2]int{previous[i].WinnerID, previous[i+1].WinnerID})
+	}
+	return pairs
+}
+
+// bracketOrder devuelve los cabezas de serie, empezando por 1, en el orden del
+// cuadro de size Pokémon, de manera que los mejores solo se enfrentan al final.
+func bracketOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, 2*len(order))
+		for _, seed := range order {
+			next = append(next, seed, 2*len(order)+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// seedID devuelve el ID del cabeza de serie, o 0 si no hay tantos Pokémon.
+func seedID(pokemonIDs []int, seed int) int {
+	if seed > len(pokemonIDs) {
+		return 0
+	}
+	return pokemonIDs[seed-1]
+}
+
+// pairRoundRobin empareja una ronda de la liguilla con el método del círculo:
+// el primer Pokémon queda fijo y el resto rota una posición en cada ronda.
+func pairRoundRobin(pokemonIDs []int, round int) [][2]int {
+	players := append([]int(nil), pokemonIDs...)
+	if len(players)%2 == 1 {
+		// the pokemon paired with 0 rests this round
+		players = append(players, 0)
+	}
+
+	n := len(players)
+	rotated := make([]int, n)
+	rotated[0] = players[0]
+	for i := 1; i < n; i++ {
+		rotated[i] = players[1+(i-1+round-1)%(n-1)]
+	}
+
+	pairs := make([][2]int, 0, n/2)
+	for i := 0; i < n/2; i++ {
+		pairs = append(pairs, [2]int{rotated[i], rotated[n-1-i]})
+	}
+	return pairs
+}
+
+// pairSwiss empareja una ronda del sistema suizo. En la primera ronda, la mitad
+// superior de los cabezas de serie se enfrenta a la inferior. En el resto, se
+// enfrentan los Pokémon con puntuaciones parecidas que no se han enfrentado
+// todavía. Con un número impar de Pokémon, descansa el peor clasificado que no
+// haya descansado aún.
+func pairSwiss(t models.Tournament) [][2]int {
+	var players []int
+	if t.CurrentRound == 1 {
+		players = append(players, t.PokemonIDs...)
+	} else {
+		for _, standing := range Standings(t) {
+			players = append(players, standing.PokemonID)
+		}
+	}
+
+	played := make(map[[2]int]bool)
+	rested := make(map[int]bool)
+	for _, match := range t.Matches {
+		if match.IsBye() {
+			rested[match.Pokemon1ID] = true
+			continue
+		}
+		played[[2]int{match.Pokemon1ID, match.Pokemon2ID}] = true
+		played[[2]int{match.Pokemon2ID, match.Pokemon1ID}] = true
+	}
+
+	var bye *[2]int
+	if len(players)%2 == 1 {
+		resting := len(players) - 1
+		for i := len(players) - 1; i >= 0; i-- {
+			if !rested[players[i]] {
+				resting = i
+				break
+			}
+		}
+		bye = &[2]int{players[resting], 0}
+		players = append(players[:resting:resting], players[resting+1:]...)
+	}
+
+	var pairs [][2]int
+	if t.CurrentRound == 1 {
+		half := len(players) / 2
+		for i := 0; i < half; i++ {
+			pairs = append(pairs, [2]int{players[i], players[half+i]})
+		}
+	} else {
+		backtracks := maxPairingBacktracks
+		if pairs = pairWithoutRematches(players, played, &backtracks); pairs == nil {
+			// no pairing without rematches was found in time, so some match is repeated
+			pairs = pairGreedy(players, played)
+		}
+	}
+
+	if bye != nil {
+		pairs = append(pairs, *bye)
+	}
+	return pairs
+}
+
+// pairWithoutRematches empareja cada Pokémon con el siguiente mejor clasificado
+// contra el que no haya combatido, volviendo atrás cuando no es posible. Devuelve
+// nil si no existe ningún emparejamiento sin repetir enfrentamientos o si agota
+// las vueltas atrás de backtracks.
+func pairWithoutRematches(players []int, played map[[2]int]bool, backtracks *int) [][2]int {
+	if len(players) == 0 {
+		return [][2]int{}
+	}
+
+	first := players[0]
+	for i := 1; i < len(players); i++ {
+		if played[[2]int{first, players[i]}] {
+			continue
+		}
+
+		rest := make([]int, 0, len(players)-2)
+		rest = append(rest, players[1:i]...)
+		rest = append(rest, players[i+1:]...)
+
+		if pairs := pairWithoutRematches(rest, played, backtracks); pairs != nil {
+			return append([][2]int{{first, players[i]}}, pairs...)
+		}
+		if *backtracks--; *backtracks <= 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// pairGreedy empareja cada Pokémon con el siguiente mejor clasificado contra el
+// que no haya combatido, sin volver atrás. Si ya ha combatido contra todos los
+// que quedan, repite el enfrentamiento con el siguiente.
+func pairGreedy(players []int, played map[[2]int]bool) [][2]int {
+	rest := append([]int(nil), players...)
+
+	pairs := make([][2]int, 0, len(players)/2)
+	for len(rest) > 1 {
+		first, opponent := rest[0], 1
+		for i := 1; i < len(rest); i++ {
+			if !played[[2]int{first, rest[i]}] {
+				opponent = i
+				break
+			}
+		}
+		pairs = append(pairs, [2]int{first, rest[opponent]})
+		rest = append(rest[1:opponent], rest[opponent+1:]...)
+	}
+	return pairs
+}
+
+// Standings devuelve la clasificación del torneo con los enfrentamientos resueltos.
+// Los Pokémon se ordenan por puntos, después por la suma de los puntos de sus
+// rivales (Buchholz), después por victorias y por último por cabeza de serie.
+func Standings(t models.Tournament) []models.TournamentStanding {
+	standings := make(map[int]*models.TournamentStanding, len(t.PokemonIDs))
+	seeds := make(map[int]int, len(t.PokemonIDs))
+	for seed, id := range t.PokemonIDs {
+		standings[id] = &models.TournamentStanding{PokemonID: id}
+		seeds[id] = seed
+	}
+
+	opponents := make(map[int][]int)
+	for _, match := range t.Matches {
+		if !match.Played {
+			continue
+		}
+
+		for _, id := range []int{match.Pokemon1ID, match.Pokemon2ID} {
+			standing, ok := standings[id]
+			if !ok {
+				continue
+			}
+
+			standing.Played++
+			switch match.WinnerID {
+			case id:
+				standing.Wins++
+				standing.Points += winPoints
+			case 0:
+				standing.Draws++
+				standing.Points += drawPoints
+			default:
+				standing.Losses++
+			}
+		}
+
+		if !match.IsBye() {
+			opponents[match.Pokemon1ID] = append(opponents[match.Pokemon1ID], match.Pokemon2ID)
+			opponents[match.Pokemon2ID] = append(opponents[match.Pokemon2ID], match.Pokemon1ID)
+		}
+	}
+
+	result := make([]models.TournamentStanding, 0, len(standings))
+	for _, id := range t.PokemonIDs {
+		standing := standings[id]
+		for _, opponent := range opponents[id] {
+			standing.Buchholz += standings[opponent].Points
+		}
+		result = append(result, *standing)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.Buchholz != b.Buchholz {
+			return a.Buchholz > b.Buchholz
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return seeds[a.PokemonID] < seeds[b.PokemonID]
+	})
+
+	for i := range result {
+		result[i].Rank = i + 1
+	}
+	return result
+}
+
+// Bracket devuelve el cuadro de un torneo de eliminación directa, con la final
+// como raíz. Los enfrentamientos de las rondas sin emparejar quedan vacíos.
+func Bracket(t models.Tournament) (*models.BracketNode, error) {
+	if t.Format != models.TournamentSingleElimination {
+		return nil, ErrNoBracket
+	}
+
+	matches := make(map[[2]int]*models.TournamentMatch, len(t.Matches))
+	for i := range t.Matches {
+		match := &t.Matches[i]
+		matches[[2]int{match.Round, match.Position}] = match
+	}
+
+	var node func(round, position int) *models.BracketNode
+	node = func(round, position int) *models.BracketNode {
+		n := &models.BracketNode{Match: matches[[2]int{round, position}], Round: round}
+		if round > 1 {
+			n.Children = []*models.BracketNode{node(round-1, 2*position), node(round-1, 2*position+1)}
+		}
+		return n
+	}
+
+	return node(t.Rounds, 0), nil
+}
+
+// roundMatches devuelve los enfrentamientos de una ronda, ordenados por posición.
+func roundMatches(t models.Tournament, round int) []models.TournamentMatch {
+	var matches []models.TournamentMatch
+	for _, match := range t.Matches {
+		if match.Round == round {
+			matches = append(matches, match)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Position < matches[j].Position })
+	return matches
+}
+
+// log2Ceil devuelve el logaritmo en base 2 de n redondeado hacia arriba.
+func log2Ceil(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	return bits.Len(uint(n - 1))
+}
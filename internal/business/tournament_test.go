@@ -0,0 +1,232 @@
+package business_test
+
+import (
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+// tournamentPokemons devuelve n Pokémon con IDs de 1 a n, en el que el primero
+// es mucho más fuerte que el resto.
+func tournamentPokemons(n int) ([]int, map[int]models.Pokemon) {
+	ids := make([]int, n)
+	pokemons := make(map[int]models.Pokemon, n)
+	for i := range ids {
+		pokemon := weakPokemon
+		pokemon.ID = i + 1
+		pokemon.HP = 20
+		if i == 0 {
+			pokemon = strongPokemon
+			pokemon.ID = 1
+		}
+		ids[i] = pokemon.ID
+		pokemons[pokemon.ID] = pokemon
+	}
+	return ids, pokemons
+}
+
+// playTournament juega todas las rondas del torneo, guardando las batallas en battles.
+func playTournament(t *testing.T, tournament *models.Tournament, pokemons map[int]models.Pokemon) []models.Battle {
+	t.Helper()
+
+	var battles []models.Battle
+	record := func(battle *models.Battle) error {
+		battle.ID = len(battles) + 1
+		battles = append(battles, *battle)
+		return nil
+	}
+
+	for tournament.Status != models.TournamentFinished {
+		if err := business.PlayRound(tournament, pokemons, record, 6, business.WithSeed(1)); err != nil {
+			t.Fatalf("expected PlayRound() to return nil, got %v", err)
+		}
+	}
+	return battles
+}
+
+func TestSingleEliminationTournament(t *testing.T) {
+	ids, pokemons := tournamentPokemons(5)
+
+	tournament, err := business.NewTournament("Copa Kanto", models.TournamentSingleElimination, ids, 0)
+	if err != nil {
+		t.Fatalf("expected NewTournament() to return nil, got %v", err)
+	}
+	if tournament.Rounds != 3 {
+		t.Fatalf("expected 3 rounds, got %d", tournament.Rounds)
+	}
+
+	// 8 plazas para 5 Pokémon: los tres mejores cabezas de serie descansan
+	byes := 0
+	for _, match := range tournament.Matches {
+		if match.IsBye() {
+			byes++
+			if match.Pokemon1ID > 3 {
+				t.Fatalf("expected only the top 3 seeds to rest, got %+v", match)
+			}
+		}
+	}
+	if len(tournament.Matches) != 4 || byes != 3 {
+		t.Fatalf("expected 4 matches with 3 byes, got %+v", tournament.Matches)
+	}
+
+	battles := playTournament(t, &tournament, pokemons)
+
+	if tournament.WinnerID != 1 {
+		t.Fatalf("expected the strongest pokemon to win, got %d", tournament.WinnerID)
+	}
+	// 4 Pokémon eliminados en 4 batallas
+	if len(battles) != 4 {
+		t.Fatalf("expected 4 battles, got %d", len(battles))
+	}
+	for _, battle := range battles {
+		if battle.Round == 0 || battle.IsDraw() {
+			t.Fatalf("expected battles with a round and a winner, got %+v", battle)
+		}
+	}
+
+	bracket, err := business.Bracket(tournament)
+	if err != nil {
+		t.Fatalf("expected Bracket() to return nil, got %v", err)
+	}
+	if bracket.Round != 3 || bracket.Match.WinnerID != 1 || len(bracket.Children) != 2 || len(bracket.Children[0].Children) != 2 {
+		t.Fatalf("expected the final as the root of the bracket, got %+v", bracket)
+	}
+
+	if err := business.PlayRound(&tournament, pokemons, nil, 6); !errors.Is(err, business.ErrTournamentFinished) {
+		t.Fatalf("expected ErrTournamentFinished, got %v", err)
+	}
+}
+
+func TestRoundRobinTournament(t *testing.T) {
+	ids, pokemons := tournamentPokemons(5)
+
+	tournament, err := business.NewTournament("Liga Johto", models.TournamentRoundRobin, ids, 0)
+	if err != nil {
+		t.Fatalf("expected NewTournament() to return nil, got %v", err)
+	}
+	if tournament.Rounds != 5 {
+		t.Fatalf("expected 5 rounds, got %d", tournament.Rounds)
+	}
+
+	battles := playTournament(t, &tournament, pokemons)
+
+	// todos contra todos exactamente una vez, y cada uno descansa una ronda
+	if len(battles) != 10 {
+		t.Fatalf("expected 10 battles, got %d", len(battles))
+	}
+	pairs := map[[2]int]bool{}
+	rests := map[int]int{}
+	for _, match := range tournament.Matches {
+		if match.IsBye() {
+			rests[match.Pokemon1ID]++
+			continue
+		}
+		pair := [2]int{min(match.Pokemon1ID, match.Pokemon2ID), max(match.Pokemon1ID, match.Pokemon2ID)}
+		if pairs[pair] {
+			t.Fatalf("expected every pair to battle once, got %v twice", pair)
+		}
+		pairs[pair] = true
+	}
+	for _, id := range ids {
+		if rests[id] != 1 {
+			t.Fatalf("expected pokemon %d to rest once, got %d", id, rests[id])
+		}
+	}
+
+	standings := business.Standings(tournament)
+	if standings[0].PokemonID != 1 || standings[0].Points != 15 || standings[0].Rank != 1 {
+		t.Fatalf("expected the strongest pokemon to win all its matches, got %+v", standings[0])
+	}
+	if tournament.WinnerID != 1 {
+		t.Fatalf("expected the leader of the standings to win, got %d", tournament.WinnerID)
+	}
+
+	if _, err := business.Bracket(tournament); !errors.Is(err, business.ErrNoBracket) {
+		t.Fatalf("expected ErrNoBracket, got %v", err)
+	}
+}
+
+func TestSwissTournament(t *testing.T) {
+	ids, pokemons := tournamentPokemons(8)
+
+	tournament, err := business.NewTournament("Open Hoenn", models.TournamentSwiss, ids, 0)
+	if err != nil {
+		t.Fatalf("expected NewTournament() to return nil, got %v", err)
+	}
+	if tournament.Rounds != 3 {
+		t.Fatalf("expected 3 rounds, got %d", tournament.Rounds)
+	}
+
+	// la mitad superior contra la inferior
+	first := tournament.Matches[0]
+	if first.Pokemon1ID != 1 || first.Pokemon2ID != 5 {
+		t.Fatalf("expected the first seed against the fifth, got %+v", first)
+	}
+
+	playTournament(t, &tournament, pokemons)
+
+	pairs := map[[2]int]bool{}
+	for _, match := range tournament.Matches {
+		pair := [2]int{min(match.Pokemon1ID, match.Pokemon2ID), max(match.Pokemon1ID, match.Pokemon2ID)}
+		if pairs[pair] {
+			t.Fatalf("expected no rematches, got %v twice", pair)
+		}
+		pairs[pair] = true
+	}
+	if len(tournament.Matches) != 12 {
+		t.Fatalf("expected 12 matches, got %d", len(tournament.Matches))
+	}
+	if tournament.WinnerID != 1 {
+		t.Fatalf("expected the strongest pokemon to win, got %d", tournament.WinnerID)
+	}
+}
+
+func TestSwissTournament_maxRounds(t *testing.T) {
+	// con tantas rondas como rivales, las últimas pueden no tener ningún
+	// emparejamiento sin repetir enfrentamientos, y el torneo debe terminar igualmente
+	ids, pokemons := tournamentPokemons(64)
+
+	tournament, err := business.NewTournament("Open Hoenn", models.TournamentSwiss, ids, len(ids)-1)
+	if err != nil {
+		t.Fatalf("expected NewTournament() to return nil, got %v", err)
+	}
+
+	playTournament(t, &tournament, pokemons)
+
+	if len(tournament.Matches) != 63*32 {
+		t.Fatalf("expected %d matches, got %d", 63*32, len(tournament.Matches))
+	}
+	for _, match := range tournament.Matches {
+		if match.Pokemon1ID == match.Pokemon2ID {
+			t.Fatalf("expected every pokemon to battle another one, got %+v", match)
+		}
+	}
+}
+
+func TestNewTournament(t *testing.T) {
+	ids, _ := tournamentPokemons(4)
+
+	testCases := []struct {
+		name   string
+		format string
+		ids    []int
+		rounds int
+	}{
+		{name: "invalid-format", format: "ladder", ids: ids},
+		{name: "too-few-pokemon", format: models.TournamentSwiss, ids: ids[:1]},
+		{name: "repeated-pokemon", format: models.TournamentSwiss, ids: []int{1, 2, 2}},
+		{name: "too-many-rounds", format: models.TournamentSwiss, ids: ids, rounds: 4},
+		{name: "rounds-not-swiss", format: models.TournamentRoundRobin, ids: ids, rounds: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := business.NewTournament("Copa", testCase.format, testCase.ids, testCase.rounds)
+			if err == nil {
+				t.Fatal("expected NewTournament() to return an error")
+			}
+		})
+	}
+}
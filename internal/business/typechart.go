@@ -0,0 +1,130 @@
+package business
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// typeSeparator es el separador usado para los Pokémon de doble tipo,
+// por ejemplo "Fire/Flying".
+const typeSeparator = "/"
+
+//go:embed typechart.json
+var defaultTypeChartJSON []byte
+
+// TypeChart es la tabla de efectividad de tipos. Para cada tipo atacante
+// guarda el multiplicador que se aplica contra cada tipo defensor.
+// Las combinaciones que no aparecen en la tabla tienen un multiplicador de 1.
+type TypeChart map[string]map[string]float64
+
+// DefaultTypeChart devuelve la tabla de tipos incluida en el binario.
+// La tabla se carga una única vez y se comparte, por lo que no debe modificarse.
+var DefaultTypeChart = sync.OnceValue(func() TypeChart {
+	chart, err := LoadTypeChart(bytes.NewReader(defaultTypeChartJSON))
+	if err != nil {
+		// la tabla embebida forma parte del código, por lo que un error
+		// aquí es un error de programación.
+		panic(err)
+	}
+	return chart
+})
+
+// LoadTypeChart lee una tabla de tipos en formato JSON, con la forma
+// {"Fire": {"Grass": 2, "Water": 0.5}, ...}.
+func LoadTypeChart(r io.Reader) (TypeChart, error) {
+	var raw map[string]map[string]float64
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid type chart: %w", err)
+	}
+
+	chart := make(TypeChart, len(raw))
+	for attackType, row := range raw {
+		normalized := make(map[string]float64, len(row))
+		for defenseType, multiplier := range row {
+			if multiplier < 0 {
+				return nil, fmt.Errorf("invalid type chart: negative multiplier for %s against %s", attackType, defenseType)
+			}
+			normalized[normalizeType(defenseType)] = multiplier
+		}
+		chart[normalizeType(attackType)] = normalized
+	}
+
+	return chart, nil
+}
+
+// LoadTypeChartFile lee una tabla de tipos desde un fichero JSON.
+func LoadTypeChartFile(path string) (TypeChart, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadTypeChart(f)
+}
+
+// Effectiveness devuelve el multiplicador de daño de un ataque del tipo
+// indicado contra un defensor que puede tener uno o dos tipos.
+// Para los defensores de doble tipo se multiplican ambos valores,
+// de manera que un ataque puede ser x4, x2, x1, x0.5, x0.25 o x0.
+func (c TypeChart) Effectiveness(attackType string, defenderTypes []string) float64 {
+	row := c[normalizeType(attackType)]
+
+	multiplier := 1.0
+	for _, defenseType := range defenderTypes {
+		if m, ok := row[normalizeType(defenseType)]; ok {
+			multiplier *= m
+		}
+	}
+
+	return multiplier
+}
+
+// ParseTypes separa el tipo de un Pokémon en sus tipos individuales,
+// de manera que "Water/Ground" devuelve ["Water", "Ground"].
+func ParseTypes(pokemonType string) []string {
+	var types []string
+	for _, t := range strings.Split(pokemonType, typeSeparator) {
+		t = strings.TrimSpace(t)
+		if t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// BestEffectiveness devuelve el mejor multiplicador que un atacante puede
+// conseguir contra un defensor, eligiendo el más efectivo de sus tipos.
+// Un atacante sin tipos conocidos ataca con un multiplicador de 1.
+func (c TypeChart) BestEffectiveness(attackerType string, defenderType string) float64 {
+	return c.Effectiveness(c.BestType(attackerType, defenderType), ParseTypes(defenderType))
+}
+
+// BestType devuelve el más efectivo de los tipos de un atacante contra un
+// defensor, o el primero si hay varios igual de efectivos. Un atacante sin
+// tipos conocidos devuelve un tipo vacío.
+func (c TypeChart) BestType(attackerType string, defenderType string) string {
+	attackTypes := ParseTypes(attackerType)
+	if len(attackTypes) == 0 {
+		return ""
+	}
+
+	defenderTypes := ParseTypes(defenderType)
+	best, bestEffectiveness := attackTypes[0], c.Effectiveness(attackTypes[0], defenderTypes)
+	for _, t := range attackTypes[1:] {
+		if e := c.Effectiveness(t, defenderTypes); e > bestEffectiveness {
+			best, bestEffectiveness = t, e
+		}
+	}
+	return best
+}
+
+func normalizeType(t string) string {
+	return strings.ToLower(strings.TrimSpace(t))
+}
@@ -0,0 +1,160 @@
+package business_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+func TestParseTypes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "single", input: "Fire", expected: []string{"Fire"}},
+		{name: "dual", input: "Water/Ground", expected: []string{"Water", "Ground"}},
+		{name: "spaces", input: " Fire / Flying ", expected: []string{"Fire", "Flying"}},
+		{name: "empty", input: "", expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			types := business.ParseTypes(testCase.input)
+			if strings.Join(types, ",") != strings.Join(testCase.expected, ",") {
+				t.Fatalf("expected types to be %v, got %v", testCase.expected, types)
+			}
+		})
+	}
+}
+
+func TestTypeChart(t *testing.T) {
+	chart := business.DefaultTypeChart()
+
+	t.Run("effectiveness", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			attackType string
+			defender   string
+			expected   float64
+		}{
+			{name: "super-effective", attackType: "Water", defender: "Fire", expected: 2},
+			{name: "not-very-effective", attackType: "Fire", defender: "Water", expected: 0.5},
+			{name: "neutral", attackType: "Normal", defender: "Normal", expected: 1},
+			{name: "immune", attackType: "Electric", defender: "Ground", expected: 0},
+			{name: "dual/x4", attackType: "Water", defender: "Rock/Ground", expected: 4},
+			{name: "dual/x0.25", attackType: "Fire", defender: "Water/Dragon", expected: 0.25},
+			{name: "dual/immune", attackType: "Electric", defender: "Water/Ground", expected: 0},
+			{name: "case-insensitive", attackType: "water", defender: "FIRE", expected: 2},
+			{name: "unknown", attackType: "Sound", defender: "Fire", expected: 1},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				effectiveness := chart.Effectiveness(testCase.attackType, business.ParseTypes(testCase.defender))
+				if effectiveness != testCase.expected {
+					t.Fatalf("expected effectiveness to be %v, got %v", testCase.expected, effectiveness)
+				}
+			})
+		}
+	})
+
+	t.Run("best-effectiveness", func(t *testing.T) {
+		// Zapdos ataca con su tipo Electric contra Squirtle, y con Flying contra Bulbasaur
+		if e := chart.BestEffectiveness("Electric/Flying", "Water"); e != 2 {
+			t.Fatalf("expected effectiveness to be 2, got %v", e)
+		}
+		if e := chart.BestEffectiveness("Electric/Flying", "Grass"); e != 2 {
+			t.Fatalf("expected effectiveness to be 2, got %v", e)
+		}
+		if e := chart.BestEffectiveness("", "Grass"); e != 1 {
+			t.Fatalf("expected effectiveness to be 1, got %v", e)
+		}
+	})
+}
+
+func TestLoadTypeChart(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		chart, err := business.LoadTypeChart(strings.NewReader(`{"Water": {"Fire": 3}}`))
+		if err != nil {
+			t.Fatalf("expected LoadTypeChart() to return nil, got %v", err)
+		}
+
+		if e := chart.Effectiveness("Water", []string{"Fire"}); e != 3 {
+			t.Fatalf("expected effectiveness to be 3, got %v", e)
+		}
+	})
+
+	t.Run("error/invalid-json", func(t *testing.T) {
+		_, err := business.LoadTypeChart(strings.NewReader(`{"Water": `))
+		if err == nil {
+			t.Fatal("expected LoadTypeChart() to return an error")
+		}
+	})
+
+	t.Run("error/negative", func(t *testing.T) {
+		_, err := business.LoadTypeChart(strings.NewReader(`{"Water": {"Fire": -1}}`))
+		if err == nil {
+			t.Fatal("expected LoadTypeChart() to return an error")
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "chart.json")
+		err := os.WriteFile(path, []byte(`{"Fire": {"Grass": 2}}`), 0o600)
+		if err != nil {
+			t.Fatalf("error writing chart file. Err: %v", err)
+		}
+
+		chart, err := business.LoadTypeChartFile(path)
+		if err != nil {
+			t.Fatalf("expected LoadTypeChartFile() to return nil, got %v", err)
+		}
+
+		if e := chart.Effectiveness("Fire", []string{"Grass"}); e != 2 {
+			t.Fatalf("expected effectiveness to be 2, got %v", e)
+		}
+	})
+
+	t.Run("file/not-found", func(t *testing.T) {
+		_, err := business.LoadTypeChartFile(filepath.Join(t.TempDir(), "missing.json"))
+		if err == nil {
+			t.Fatal("expected LoadTypeChartFile() to return an error")
+		}
+	})
+}
+
+func TestFight_typeChart(t *testing.T) {
+	// groundPokemon no tiene defensa, pero es inmune a los ataques eléctricos,
+	// por lo que siempre debe ganar a electricPokemon aunque este empiece atacando.
+	electricPokemon := models.Pokemon{ID: 1, Name: "Pikachu", Type: "Electric", HP: 100, Attack: 55, Defense: 40}
+	groundPokemon := models.Pokemon{ID: 2, Name: "Dugtrio", Type: "Ground", HP: 1, Attack: 55, Defense: 0}
+
+	for i := 0; i < 10; i++ {
+		battle := business.Fight(10, electricPokemon, groundPokemon)
+		if battle.WinnerID != groundPokemon.ID {
+			t.Fatalf("expected winner ID to be %d, got %d", groundPokemon.ID, battle.WinnerID)
+		}
+	}
+
+	t.Run("custom-chart", func(t *testing.T) {
+		// con una tabla sin inmunidades, el pokemon de tierra no sobrevive
+		// a un ataque, por lo que el pokemon eléctrico debe ganar alguna vez.
+		chart, err := business.LoadTypeChart(strings.NewReader(`{}`))
+		if err != nil {
+			t.Fatalf("expected LoadTypeChart() to return nil, got %v", err)
+		}
+
+		for i := 0; i < 100; i++ {
+			battle := business.Fight(10, electricPokemon, groundPokemon, business.WithTypeChart(chart))
+			if battle.WinnerID == electricPokemon.ID {
+				return
+			}
+		}
+		t.Fatal("expected the electric pokemon to win at least once")
+	})
+}
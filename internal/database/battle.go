@@ -2,6 +2,10 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
 
 	"pokemon-battle/internal/models"
 )
@@ -12,6 +16,14 @@ type battleService struct {
 
 	// srv is the service with the actual database connection
 	srv Service
+
+	// rate computes the new ratings of the pokemon of each created battle,
+	// the ratings are not updated if it is nil
+	rate RateFunc
+
+	// grant computes the experience and the level-ups of the winners of each
+	// created battle, no experience is granted if it is nil
+	grant ExperienceFunc
 }
 
 func NewBattleService(srv Service) *battleService {
@@ -20,7 +32,114 @@ func NewBattleService(srv Service) *battleService {
 	}
 }
 
-// Create inserts a new battle into the database
+// WithRatings makes the service update the ratings of the pokemon of each
+// created battle with the given function, in the transaction of the insert
+func (s *battleService) WithRatings(rate RateFunc) *battleService {
+	s.rate = rate
+	return s
+}
+
+// WithExperience makes the service grant experience to the winners of each
+// created battle with the given function, in the transaction of the insert
+func (s *battleService) WithExperience(grant ExperienceFunc) *battleService {
+	s.grant = grant
+	return s
+}
+
+// battleColumns are the columns of the battles table, in the order used by scanBattle
+const battleColumns = "id, pokemon1_id, pokemon2_id, winner_id, turns, pokemon1_criticals, pokemon2_criticals, seed, format, tournament_id, round, settings, participants, item_uses, conditions"
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanBattle reads a battle from a row with the battleColumns
+func scanBattle(row rowScanner) (models.Battle, error) {
+	var battle models.Battle
+	var winnerID, tournamentID sql.NullInt64
+	var settings, participants, itemUses, conditions []byte
+	if err := row.Scan(&battle.ID, &battle.Pokemon1ID, &battle.Pokemon2ID, &winnerID, &battle.Turns, &battle.Pokemon1Criticals, &battle.Pokemon2Criticals, &battle.Seed, &battle.Format, &tournamentID, &battle.Round, &settings, &participants, &itemUses, &conditions); err != nil {
+		return models.Battle{}, err
+	}
+	// a draw has no winner, and a battle outside a tournament has no tournament
+	battle.WinnerID = int(winnerID.Int64)
+	battle.TournamentID = int(tournamentID.Int64)
+
+	if err := json.Unmarshal(settings, &battle.Settings); err != nil {
+		return models.Battle{}, err
+	}
+	if err := json.Unmarshal(participants, &battle.Participants); err != nil {
+		return models.Battle{}, err
+	}
+	if err := json.Unmarshal(itemUses, &battle.ItemUses); err != nil {
+		return models.Battle{}, err
+	}
+	if err := json.Unmarshal(conditions, &battle.Conditions); err != nil {
+		return models.Battle{}, err
+	}
+
+	return battle, nil
+}
+
+// winnerValue returns the value stored in the winner_id column,
+// which is NULL when the battle ended in a draw
+func winnerValue(battle models.Battle) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(battle.WinnerID), Valid: !battle.IsDraw()}
+}
+
+// tournamentValue returns the value stored in the tournament_id column,
+// which is NULL when the battle is not part of a tournament
+func tournamentValue(battle models.Battle) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(battle.TournamentID), Valid: battle.TournamentID != 0}
+}
+
+// formatValue returns the value stored in the format column,
+// battles without a format are stored as singles
+func formatValue(battle models.Battle) string {
+	if battle.Format == "" {
+		return models.FormatSingles
+	}
+	return battle.Format
+}
+
+// marshalBattleData serializes the JSON columns of a battle
+func marshalBattleData(battle models.Battle) (settings []byte, participants []byte, itemUses []byte, conditions []byte, err error) {
+	settings, err = json.Marshal(battle.Settings)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if battle.Participants == nil {
+		battle.Participants = []models.Pokemon{}
+	}
+	participants, err = json.Marshal(battle.Participants)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if battle.ItemUses == nil {
+		battle.ItemUses = []models.ItemUse{}
+	}
+	itemUses, err = json.Marshal(battle.ItemUses)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if battle.Conditions == nil {
+		battle.Conditions = []models.FieldCondition{}
+	}
+	conditions, err = json.Marshal(battle.Conditions)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return settings, participants, itemUses, conditions, nil
+}
+
+// Create inserts a new battle into the database, together with its log,
+// the rosters of a team battle, the new ratings of its pokemon and the
+// experience of its winners. All are inserted in the same transaction.
 func (s *battleService) Create(ctx context.Context, battle *models.Battle) error {
 	db := s.srv.MustDB()
 
@@ -28,9 +147,114 @@ func (s *battleService) Create(ctx context.Context, battle *models.Battle) error
 		return err
 	}
 
-	query := "INSERT INTO battles (pokemon1_id, pokemon2_id, winner_id, turns) VALUES ($1, $2, $3, $4) RETURNING id"
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	return db.QueryRowContext(ctx, query, battle.Pokemon1ID, battle.Pokemon2ID, battle.WinnerID, battle.Turns).Scan(&battle.ID)
+	if err := s.insert(ctx, tx, battle); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insert inserts a validated battle with its log, rosters, ratings and experience
+// in the given transaction, so other writes can be committed together with it
+func (s *battleService) insert(ctx context.Context, tx *sql.Tx, battle *models.Battle) error {
+	settings, participants, itemUses, conditions, err := marshalBattleData(*battle)
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO battles (pokemon1_id, pokemon2_id, winner_id, turns, pokemon1_criticals, pokemon2_criticals, seed, format, tournament_id, round, settings, participants, item_uses, conditions) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id"
+
+	err = tx.QueryRowContext(ctx, query, battle.Pokemon1ID, battle.Pokemon2ID, winnerValue(*battle), battle.Turns, battle.Pokemon1Criticals, battle.Pokemon2Criticals, battle.Seed, formatValue(*battle), tournamentValue(*battle), battle.Round, settings, participants, itemUses, conditions).Scan(&battle.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := insertTeams(ctx, tx, *battle); err != nil {
+		return err
+	}
+
+	eventQuery := "INSERT INTO battle_events (battle_id, seq, turn, type, pokemon_id, target_id, data) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	for i, event := range battle.Log {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, eventQuery, battle.ID, i, event.Turn, event.Type, event.PokemonID, event.TargetID, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.rate != nil {
+		if err := rateBattle(ctx, tx, *battle, s.rate); err != nil {
+			return err
+		}
+	}
+
+	if s.grant != nil {
+		if err := grantExperience(ctx, tx, *battle, s.grant); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertTeams inserts the rosters of a team battle, in the order the pokemon entered the battle
+func insertTeams(ctx context.Context, tx *sql.Tx, battle models.Battle) error {
+	query := "INSERT INTO battle_teams (battle_id, side, position, pokemon_id) VALUES ($1, $2, $3, $4)"
+	for side, team := range [][]int{battle.Team1, battle.Team2} {
+		for position, pokemonID := range team {
+			if _, err := tx.ExecContext(ctx, query, battle.ID, side+1, position, pokemonID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadTeams reads the rosters of the given battles, leaving them empty for the
+// battles between two single pokemon
+func loadTeams(ctx context.Context, db *sql.DB, battles []models.Battle) error {
+	if len(battles) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(battles))
+	index := make(map[int]int, len(battles))
+	for i, battle := range battles {
+		ids[i] = battle.ID
+		index[battle.ID] = i
+	}
+
+	query := "SELECT battle_id, side, pokemon_id FROM battle_teams WHERE battle_id = ANY($1) ORDER BY battle_id, side, position"
+	rows, err := db.QueryContext(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var battleID, side, pokemonID int
+		if err := rows.Scan(&battleID, &side, &pokemonID); err != nil {
+			return err
+		}
+
+		battle := &battles[index[battleID]]
+		if side == 1 {
+			battle.Team1 = append(battle.Team1, pokemonID)
+		} else {
+			battle.Team2 = append(battle.Team2, pokemonID)
+		}
+	}
+
+	return rows.Err()
 }
 
 // DeleteBattle deletes a battle from the database
@@ -46,7 +270,7 @@ func (s *battleService) Delete(ctx context.Context, id int) error {
 func (s *battleService) GetAll(ctx context.Context) ([]models.Battle, error) {
 	db := s.srv.MustDB()
 
-	query := "SELECT id, pokemon1_id, pokemon2_id, winner_id, turns FROM battles"
+	query := "SELECT " + battleColumns + " FROM battles"
 	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -55,13 +279,81 @@ func (s *battleService) GetAll(ctx context.Context) ([]models.Battle, error) {
 
 	var battles []models.Battle
 	for rows.Next() {
-		var battle models.Battle
-		if err := rows.Scan(&battle.ID, &battle.Pokemon1ID, &battle.Pokemon2ID, &battle.WinnerID, &battle.Turns); err != nil {
+		battle, err := scanBattle(rows)
+		if err != nil {
 			return nil, err
 		}
 		battles = append(battles, battle)
 	}
 
+	if err := loadTeams(ctx, db, battles); err != nil {
+		return nil, err
+	}
+
+	return battles, nil
+}
+
+// Find retrieves the battles matching the filter from the database
+func (s *battleService) Find(ctx context.Context, filter BattleFilter) ([]models.Battle, error) {
+	db := s.srv.MustDB()
+
+	var conditions []string
+	if filter.Draw != nil {
+		if *filter.Draw {
+			conditions = append(conditions, "winner_id IS NULL")
+		} else {
+			conditions = append(conditions, "winner_id IS NOT NULL")
+		}
+	}
+
+	var args []any
+	if filter.Format != "" {
+		args = append(args, filter.Format)
+		conditions = append(conditions, "format = $"+strconv.Itoa(len(args)))
+	}
+	if filter.WinnerID != 0 {
+		args = append(args, filter.WinnerID)
+		conditions = append(conditions, "winner_id = $"+strconv.Itoa(len(args)))
+	}
+	if filter.Condition != "" {
+		// the battles store the history of their field conditions as a JSON array
+		history, err := json.Marshal([]map[string]string{{"condition": filter.Condition}})
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, string(history))
+		conditions = append(conditions, "conditions @> $"+strconv.Itoa(len(args))+"::jsonb")
+	}
+
+	query := "SELECT " + battleColumns + " FROM battles"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id"
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	battles := []models.Battle{}
+	for rows.Next() {
+		battle, err := scanBattle(rows)
+		if err != nil {
+			return nil, err
+		}
+		battles = append(battles, battle)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadTeams(ctx, db, battles); err != nil {
+		return nil, err
+	}
+
 	return battles, nil
 }
 
@@ -69,14 +361,20 @@ func (s *battleService) GetAll(ctx context.Context) ([]models.Battle, error) {
 func (s *battleService) GetByID(ctx context.Context, id int) (models.Battle, error) {
 	db := s.srv.MustDB()
 
-	query := "SELECT id, pokemon1_id, pokemon2_id, winner_id, turns FROM battles WHERE id=$1"
+	query := "SELECT " + battleColumns + " FROM battles WHERE id=$1"
 	row := db.QueryRowContext(ctx, query, id)
 
-	var battle models.Battle
-	if err := row.Scan(&battle.ID, &battle.Pokemon1ID, &battle.Pokemon2ID, &battle.WinnerID, &battle.Turns); err != nil {
+	battle, err := scanBattle(row)
+	if err != nil {
 		return models.Battle{}, err
 	}
-	return battle, nil
+
+	battles := []models.Battle{battle}
+	if err := loadTeams(ctx, db, battles); err != nil {
+		return models.Battle{}, err
+	}
+
+	return battles[0], nil
 }
 
 // Update updates an existing battle in the database
@@ -87,7 +385,62 @@ func (s *battleService) Update(ctx context.Context, battle models.Battle) error
 		return err
 	}
 
-	query := "UPDATE battles SET pokemon1_id=$1, pokemon2_id=$2, winner_id=$3, turns=$4 WHERE id=$5"
-	_, err := db.ExecContext(ctx, query, battle.Pokemon1ID, battle.Pokemon2ID, battle.WinnerID, battle.Turns, battle.ID)
-	return err
+	settings, participants, itemUses, conditions, err := marshalBattleData(battle)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE battles SET pokemon1_id=$1, pokemon2_id=$2, winner_id=$3, turns=$4, pokemon1_criticals=$5, pokemon2_criticals=$6, seed=$7, format=$8, settings=$9, participants=$10, item_uses=$11, conditions=$12 WHERE id=$13"
+	_, err = tx.ExecContext(ctx, query, battle.Pokemon1ID, battle.Pokemon2ID, winnerValue(battle), battle.Turns, battle.Pokemon1Criticals, battle.Pokemon2Criticals, battle.Seed, formatValue(battle), settings, participants, itemUses, conditions, battle.ID)
+	if err != nil {
+		return err
+	}
+
+	// the rosters are replaced as a whole
+	if _, err := tx.ExecContext(ctx, "DELETE FROM battle_teams WHERE battle_id=$1", battle.ID); err != nil {
+		return err
+	}
+	if err := insertTeams(ctx, tx, battle); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetLog retrieves the log of a battle from the database, in the order the events happened
+func (s *battleService) GetLog(ctx context.Context, id int) ([]models.BattleEvent, error) {
+	db := s.srv.MustDB()
+
+	query := "SELECT data FROM battle_events WHERE battle_id=$1 ORDER BY seq"
+	rows, err := db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.BattleEvent{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var event models.BattleEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
 }
@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"database/sql"
+	"slices"
 	"testing"
 
 	"pokemon-battle/internal/database"
@@ -138,6 +139,68 @@ func TestNewBattleService(t *testing.T) {
 		if battle.Turns != b.Turns {
 			t.Fatalf("expected Turns to be %d, got %d", b.Turns, battle.Turns)
 		}
+
+		if battle.Seed != b.Seed {
+			t.Fatalf("expected Seed to be %d, got %d", b.Seed, battle.Seed)
+		}
+
+		if battle.Pokemon1Criticals != b.Pokemon1Criticals || battle.Pokemon2Criticals != b.Pokemon2Criticals {
+			t.Fatalf("expected criticals to be %d and %d, got %d and %d", b.Pokemon1Criticals, b.Pokemon2Criticals, battle.Pokemon1Criticals, battle.Pokemon2Criticals)
+		}
+
+		if battle.Settings != b.Settings {
+			t.Fatalf("expected Settings to be %+v, got %+v", b.Settings, battle.Settings)
+		}
+
+		if len(battle.Participants) != len(b.Participants) {
+			t.Fatalf("expected %d participants, got %d", len(b.Participants), len(battle.Participants))
+		}
+	})
+
+	t.Run("GetLog", func(t *testing.T) {
+		battle := models.Battle{
+			Pokemon1ID: 1,
+			Pokemon2ID: 2,
+			WinnerID:   1,
+			Turns:      1,
+			Log: []models.BattleEvent{
+				{Turn: 1, Type: models.EventInitiative, PokemonID: 1, TargetID: 2, Roll: 5, TargetRoll: 2, HP: 100, TargetHP: 90},
+				{Turn: 1, Type: models.EventAttack, PokemonID: 1, TargetID: 2, Roll: 6, TargetRoll: 1, Effectiveness: 1, Damage: 90, HP: 100, TargetHP: 0},
+				{Turn: 1, Type: models.EventFaint, PokemonID: 2},
+			},
+		}
+
+		err := srv.Create(context.Background(), &battle)
+		if err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer cleanupBattle(t, srv, battle.ID)
+
+		events, err := srv.GetLog(context.Background(), battle.ID)
+		if err != nil {
+			t.Fatalf("expected GetLog() to return nil, got %v", err)
+		}
+
+		if len(events) != len(battle.Log) {
+			t.Fatalf("expected GetLog() to return %d events, got %d", len(battle.Log), len(events))
+		}
+
+		for i, event := range events {
+			if event != battle.Log[i] {
+				t.Fatalf("expected event %d to be %+v, got %+v", i, battle.Log[i], event)
+			}
+		}
+	})
+
+	t.Run("GetLog/zero", func(t *testing.T) {
+		events, err := srv.GetLog(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("expected GetLog() to return nil, got %v", err)
+		}
+
+		if len(events) != 0 {
+			t.Fatalf("expected GetLog() to return 0 events, got %d", len(events))
+		}
 	})
 
 	t.Run("Update", func(t *testing.T) {
@@ -164,6 +227,164 @@ func TestNewBattleService(t *testing.T) {
 			t.Fatalf("expected Turns to be 5, got %d", battle.Turns)
 		}
 	})
+
+	t.Run("Draw", func(t *testing.T) {
+		battle := createTestBattle(t, srv)
+		defer cleanupBattle(t, srv, battle.ID)
+
+		// a draw has no winner
+		battle.WinnerID = 0
+
+		err := srv.Update(context.Background(), battle)
+		if err != nil {
+			t.Fatalf("expected Update() to return nil, got %v", err)
+		}
+
+		battle, err = srv.GetByID(context.Background(), battle.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		if !battle.IsDraw() {
+			t.Fatalf("expected battle to be a draw, got winner ID %d", battle.WinnerID)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		won := createTestBattle(t, srv)
+		defer cleanupBattle(t, srv, won.ID)
+
+		draw := createTestBattle(t, srv)
+		defer cleanupBattle(t, srv, draw.ID)
+		draw.WinnerID = 0
+		draw.Conditions = []models.FieldCondition{{Condition: models.ConditionRain, StartTurn: 1, Turns: 5}}
+		if err := srv.Update(context.Background(), draw); err != nil {
+			t.Fatalf("expected Update() to return nil, got %v", err)
+		}
+
+		isDraw := true
+		battles, err := srv.Find(context.Background(), database.BattleFilter{Draw: &isDraw})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 1 || battles[0].ID != draw.ID {
+			t.Fatalf("expected Find() to return the draw %d, got %+v", draw.ID, battles)
+		}
+
+		isDraw = false
+		battles, err = srv.Find(context.Background(), database.BattleFilter{Draw: &isDraw})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 1 || battles[0].ID != won.ID {
+			t.Fatalf("expected Find() to return the battle %d, got %+v", won.ID, battles)
+		}
+
+		battles, err = srv.Find(context.Background(), database.BattleFilter{})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 2 {
+			t.Fatalf("expected Find() to return 2 battles, got %d", len(battles))
+		}
+
+		battles, err = srv.Find(context.Background(), database.BattleFilter{WinnerID: won.WinnerID})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 1 || battles[0].ID != won.ID {
+			t.Fatalf("expected Find() to return the battle %d, got %+v", won.ID, battles)
+		}
+
+		battles, err = srv.Find(context.Background(), database.BattleFilter{Format: models.FormatDoubles})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 0 {
+			t.Fatalf("expected Find() to return no double battles, got %d", len(battles))
+		}
+
+		battles, err = srv.Find(context.Background(), database.BattleFilter{Draw: &isDraw, Format: models.FormatSingles})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 1 || battles[0].Format != models.FormatSingles {
+			t.Fatalf("expected Find() to return the singles battle %d, got %+v", won.ID, battles)
+		}
+
+		battles, err = srv.Find(context.Background(), database.BattleFilter{Condition: models.ConditionRain})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 1 || battles[0].ID != draw.ID || !slices.Equal(battles[0].Conditions, draw.Conditions) {
+			t.Fatalf("expected Find() to return the rainy battle %d, got %+v", draw.ID, battles)
+		}
+
+		battles, err = srv.Find(context.Background(), database.BattleFilter{Condition: models.ConditionSun})
+		if err != nil {
+			t.Fatalf("expected Find() to return nil, got %v", err)
+		}
+		if len(battles) != 0 {
+			t.Fatalf("expected Find() to return no sunny battles, got %d", len(battles))
+		}
+	})
+
+	t.Run("Teams", func(t *testing.T) {
+		battle := models.Battle{
+			Pokemon1ID: 1,
+			Pokemon2ID: 4,
+			WinnerID:   5,
+			Turns:      12,
+			Format:     models.FormatDoubles,
+			Settings:   models.BattleSettings{DiceSides: 6, MaxExplosions: 3},
+			Team1:      []int{1, 2, 3},
+			Team2:      []int{4, 5, 6},
+			Log: []models.BattleEvent{
+				{Turn: 1, Type: models.EventAttack, PokemonID: 1, TargetID: 4, Damage: 10, HP: 100, TargetHP: 0},
+				{Turn: 1, Type: models.EventFaint, PokemonID: 4},
+				{Turn: 1, Type: models.EventSwitch, PokemonID: 5, TargetID: 1, ReplacedID: 4, Forced: true},
+			},
+		}
+
+		err := srv.Create(context.Background(), &battle)
+		if err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer cleanupBattle(t, srv, battle.ID)
+
+		got, err := srv.GetByID(context.Background(), battle.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if !slices.Equal(got.Team1, battle.Team1) || !slices.Equal(got.Team2, battle.Team2) {
+			t.Fatalf("expected teams %v and %v, got %v and %v", battle.Team1, battle.Team2, got.Team1, got.Team2)
+		}
+		if got.Format != models.FormatDoubles {
+			t.Fatalf("expected format to be doubles, got %s", got.Format)
+		}
+
+		// the rosters are replaced on update
+		battle.Team2 = []int{4, 5}
+		if err := srv.Update(context.Background(), battle); err != nil {
+			t.Fatalf("expected Update() to return nil, got %v", err)
+		}
+
+		battles, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(battles) != 1 || !slices.Equal(battles[0].Team2, []int{4, 5}) {
+			t.Fatalf("expected GetAll() to return the updated teams, got %+v", battles)
+		}
+
+		log, err := srv.GetLog(context.Background(), battle.ID)
+		if err != nil {
+			t.Fatalf("expected GetLog() to return nil, got %v", err)
+		}
+		if len(log) != 3 || log[2].ReplacedID != 4 || !log[2].Forced {
+			t.Fatalf("expected the switch to be stored in the log, got %+v", log)
+		}
+	})
 }
 
 // createTestBattle is a helper function to create a battle for testing
@@ -171,10 +392,17 @@ func createTestBattle(t *testing.T, srv database.BattleCRUDService) models.Battl
 	t.Helper()
 
 	battle := models.Battle{
-		Pokemon1ID: 1,
-		Pokemon2ID: 2,
-		WinnerID:   1,
-		Turns:      10,
+		Pokemon1ID:        1,
+		Pokemon2ID:        2,
+		WinnerID:          1,
+		Turns:             10,
+		Pokemon1Criticals: 2,
+		Seed:              42,
+		Settings:          models.BattleSettings{DiceSides: 6, MaxExplosions: 3},
+		Participants: []models.Pokemon{
+			{ID: 1, Name: "Pikachu", Type: "Electric", HP: 100, Attack: 55, Defense: 40},
+			{ID: 2, Name: "Charmander", Type: "Fire", HP: 90, Attack: 62, Defense: 58},
+		},
 	}
 
 	err := srv.Create(context.Background(), &battle)
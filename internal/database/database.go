@@ -170,4 +170,116 @@ type BattleCRUDService interface {
 	GetAll(ctx context.Context) ([]models.Battle, error)
 	GetByID(ctx context.Context, id int) (models.Battle, error)
 	Update(ctx context.Context, obj models.Battle) error
+
+	// GetLog retrieves the turn-by-turn log of a battle
+	GetLog(ctx context.Context, id int) ([]models.BattleEvent, error)
+
+	// Find retrieves the battles matching the filter
+	Find(ctx context.Context, filter BattleFilter) ([]models.Battle, error)
+}
+
+// BattleFilter defines the conditions used to find battles.
+// Nil and empty fields don't filter the battles.
+type BattleFilter struct {
+	// Draw selects the battles that ended in a draw, if true,
+	// or the battles with a winner, if false
+	Draw *bool
+
+	// Format selects the battles of a format, e.g. "singles" or "doubles"
+	Format string
+
+	// WinnerID selects the battles won by a pokemon
+	WinnerID int
+
+	// Condition selects the battles in which a field condition was active,
+	// e.g. "rain" or "sandstorm"
+	Condition string
+}
+
+// TournamentCRUDService stores the tournaments with their pokemon and matches.
+// The battles of the matches are stored by the battle service.
+type TournamentCRUDService interface {
+	Create(ctx context.Context, obj *models.Tournament) error
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]models.Tournament, error)
+	GetByID(ctx context.Context, id int) (models.Tournament, error)
+	Update(ctx context.Context, obj models.Tournament) error
+}
+
+// BattleSessionCRUDService stores the interactive battle sessions with the actions
+// of their resolved turns. The battle of a finished session is stored with Finish,
+// in the same transaction as the session.
+type BattleSessionCRUDService interface {
+	Create(ctx context.Context, obj *models.BattleSession) error
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]models.BattleSession, error)
+	GetByID(ctx context.Context, id int) (models.BattleSession, error)
+	Update(ctx context.Context, obj models.BattleSession) error
+
+	// Finish stores the battle of a finished session together with the session,
+	// setting the ID of the battle in both
+	Finish(ctx context.Context, obj *models.BattleSession, battle *models.Battle) error
+}
+
+type MoveCRUDService interface {
+	Create(ctx context.Context, obj *models.Move) error
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]models.Move, error)
+	GetByID(ctx context.Context, id int) (models.Move, error)
+	Update(ctx context.Context, obj models.Move) error
+}
+
+type ItemCRUDService interface {
+	Create(ctx context.Context, obj *models.Item) error
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]models.Item, error)
+	GetByID(ctx context.Context, id int) (models.Item, error)
+	Update(ctx context.Context, obj models.Item) error
+}
+
+// HeldItemService manages the item held by each pokemon, which it takes into its battles
+type HeldItemService interface {
+	GetItem(ctx context.Context, pokemonID int) (models.Item, error)
+	Give(ctx context.Context, pokemonID int, itemID int) error
+	Take(ctx context.Context, pokemonID int) error
+}
+
+// PokemonMoveService manages the moveset of each pokemon
+type PokemonMoveService interface {
+	GetMoves(ctx context.Context, pokemonID int) ([]models.Move, error)
+	Learn(ctx context.Context, pokemonID int, moveID int) error
+	Forget(ctx context.Context, pokemonID int, moveID int) error
+}
+
+// MatchupService stores the matchup matrix computed from simulated battles
+type MatchupService interface {
+	// ReplaceAll replaces all the stored matchups with the given ones
+	ReplaceAll(ctx context.Context, matchups []models.Matchup) error
+	GetAll(ctx context.Context) ([]models.Matchup, error)
+}
+
+// RatingService reads the ratings of the pokemon, which are updated
+// by the battle service every time a battle is stored
+type RatingService interface {
+	GetAll(ctx context.Context) ([]models.Rating, error)
+	GetByPokemonID(ctx context.Context, pokemonID int) (models.Rating, error)
+
+	// GetHistory retrieves the ratings of a pokemon after each of its battles
+	GetHistory(ctx context.Context, pokemonID int) ([]models.RatingChange, error)
+}
+
+// LevelUpService reads the level-ups of the pokemon, which are inserted
+// by the battle service when a battle grants experience
+type LevelUpService interface {
+	GetByPokemonID(ctx context.Context, pokemonID int) ([]models.LevelUp, error)
+}
+
+// EvolutionService manages the evolutions between pokemon entries
+type EvolutionService interface {
+	Create(ctx context.Context, obj *models.Evolution) error
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]models.Evolution, error)
+
+	// GetBySpecies retrieves the evolutions from the entries with the name of a species
+	GetBySpecies(ctx context.Context, name string) ([]models.Evolution, error)
 }
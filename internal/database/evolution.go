@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+
+	"pokemon-battle/internal/models"
+)
+
+type evolutionService struct {
+	// EvolutionService is the service to manage the evolutions between pokemon
+	EvolutionService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewEvolutionService(srv Service) *evolutionService {
+	return &evolutionService{
+		srv: srv,
+	}
+}
+
+// evolutionColumns are the columns of the evolutions table, in the order used by scanEvolution
+const evolutionColumns = "id, from_pokemon_id, to_pokemon_id, min_level, min_wins"
+
+// scanEvolution reads an evolution from a row with the evolutionColumns
+func scanEvolution(row rowScanner) (models.Evolution, error) {
+	var evolution models.Evolution
+	err := row.Scan(&evolution.ID, &evolution.FromPokemonID, &evolution.ToPokemonID, &evolution.MinLevel, &evolution.MinWins)
+	return evolution, err
+}
+
+// Create inserts a new evolution into the database
+func (s *evolutionService) Create(ctx context.Context, evolution *models.Evolution) error {
+	db := s.srv.MustDB()
+
+	if err := evolution.Validate(); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO evolutions (from_pokemon_id, to_pokemon_id, min_level, min_wins) VALUES ($1, $2, $3, $4) RETURNING id"
+	return db.QueryRowContext(ctx, query, evolution.FromPokemonID, evolution.ToPokemonID, evolution.MinLevel, evolution.MinWins).Scan(&evolution.ID)
+}
+
+// Delete deletes an evolution from the database
+func (s *evolutionService) Delete(ctx context.Context, id int) error {
+	db := s.srv.MustDB()
+
+	query := "DELETE FROM evolutions WHERE id=$1"
+	_, err := db.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetAll retrieves all the evolutions from the database
+func (s *evolutionService) GetAll(ctx context.Context) ([]models.Evolution, error) {
+	return s.find(ctx, "SELECT "+evolutionColumns+" FROM evolutions ORDER BY id")
+}
+
+// GetBySpecies retrieves the evolutions of a species, that is, the evolutions
+// from any pokemon entry with its name. This way, a pokemon that already
+// evolved into another species follows the evolutions of that species.
+func (s *evolutionService) GetBySpecies(ctx context.Context, name string) ([]models.Evolution, error) {
+	query := `SELECT e.id, e.from_pokemon_id, e.to_pokemon_id, e.min_level, e.min_wins
+		FROM evolutions e JOIN pokemons p ON p.id = e.from_pokemon_id
+		WHERE p.name=$1 ORDER BY e.id`
+	return s.find(ctx, query, name)
+}
+
+// find retrieves the evolutions selected by a query with the evolutionColumns
+func (s *evolutionService) find(ctx context.Context, query string, args ...any) ([]models.Evolution, error) {
+	db := s.srv.MustDB()
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	evolutions := []models.Evolution{}
+	for rows.Next() {
+		evolution, err := scanEvolution(rows)
+		if err != nil {
+			return nil, err
+		}
+		evolutions = append(evolutions, evolution)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return evolutions, nil
+}
@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestNewEvolutionService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewEvolutionService(dbService)
+	if srv == nil {
+		t.Fatal("NewEvolutionService() returned nil")
+	}
+
+	pokemonSrv := database.NewPokemonService(dbService)
+
+	t.Run("Create", func(t *testing.T) {
+		from := createTestPokemon(t, pokemonSrv)
+		defer cleanupPokemon(t, pokemonSrv, from.ID)
+
+		evolution := models.Evolution{FromPokemonID: from.ID, ToPokemonID: 14, MinLevel: 20}
+		if err := srv.Create(context.Background(), &evolution); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer srv.Delete(context.Background(), evolution.ID)
+
+		if evolution.ID == 0 {
+			t.Fatal("expected Create() to set the ID of the evolution")
+		}
+	})
+
+	t.Run("Create invalid", func(t *testing.T) {
+		evolution := models.Evolution{FromPokemonID: 1, ToPokemonID: 14}
+		if err := srv.Create(context.Background(), &evolution); err == nil {
+			t.Fatal("expected Create() to fail without a condition")
+		}
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		evolutions, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(evolutions) == 0 {
+			t.Fatal("expected GetAll() to return the evolutions of the test data")
+		}
+	})
+
+	t.Run("GetBySpecies", func(t *testing.T) {
+		// a pokemon named like an entry follows the evolutions of the entry
+		pokemon := models.Pokemon{Name: "Pikachu", Type: "Electric", HP: 35, Attack: 55, Defense: 40}
+		if err := pokemonSrv.Create(context.Background(), &pokemon); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer cleanupPokemon(t, pokemonSrv, pokemon.ID)
+
+		evolutions, err := srv.GetBySpecies(context.Background(), pokemon.Name)
+		if err != nil {
+			t.Fatalf("expected GetBySpecies() to return nil, got %v", err)
+		}
+		if len(evolutions) != 1 || evolutions[0].FromPokemonID != 1 || evolutions[0].ToPokemonID != 14 {
+			t.Fatalf("expected the evolution of Pikachu into Raichu, got %+v", evolutions)
+		}
+
+		evolutions, err = srv.GetBySpecies(context.Background(), "Missingno")
+		if err != nil {
+			t.Fatalf("expected GetBySpecies() to return nil, got %v", err)
+		}
+		if len(evolutions) != 0 {
+			t.Fatalf("expected no evolutions, got %+v", evolutions)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pokemon-battle/internal/models"
+)
+
+// ExperienceFunc computes the winners of a battle after granting them the
+// experience of the battle, and their level-ups, from the current state of
+// the pokemon of the battle
+type ExperienceFunc func(battle models.Battle, pokemons map[int]models.Pokemon) ([]models.Pokemon, []models.LevelUp)
+
+type levelUpService struct {
+	// LevelUpService is the service to read the level-ups of the pokemon
+	LevelUpService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewLevelUpService(srv Service) *levelUpService {
+	return &levelUpService{
+		srv: srv,
+	}
+}
+
+// GetByPokemonID retrieves the level-ups of a pokemon, from the oldest to the newest
+func (s *levelUpService) GetByPokemonID(ctx context.Context, pokemonID int) ([]models.LevelUp, error) {
+	db := s.srv.MustDB()
+
+	query := "SELECT pokemon_id, battle_id, level, hp, attack, defense, created_at FROM level_ups WHERE pokemon_id=$1 ORDER BY id"
+	rows, err := db.QueryContext(ctx, query, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levelUps := []models.LevelUp{}
+	for rows.Next() {
+		var levelUp models.LevelUp
+		var battleID sql.NullInt64
+		if err := rows.Scan(&levelUp.PokemonID, &battleID, &levelUp.Level, &levelUp.HP, &levelUp.Attack, &levelUp.Defense, &levelUp.CreatedAt); err != nil {
+			return nil, err
+		}
+		// the battle may have been deleted
+		levelUp.BattleID = int(battleID.Int64)
+		levelUps = append(levelUps, levelUp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return levelUps, nil
+}
+
+// grantExperience updates the level, the experience and the stats of the winners
+// of a stored battle and inserts their level-ups, in the transaction of the battle
+// insert. The pokemon are locked so concurrent battles grant experience in order.
+func grantExperience(ctx context.Context, tx *sql.Tx, battle models.Battle, grant ExperienceFunc) error {
+	ids := []int{battle.Pokemon1ID, battle.Pokemon2ID}
+	if battle.IsTeamBattle() {
+		ids = append(append([]int{}, battle.Team1...), battle.Team2...)
+	}
+
+	query := "SELECT id, name, type, hp, attack, defense, speed, level, experience FROM pokemons WHERE id = ANY($1) FOR UPDATE"
+	rows, err := tx.QueryContext(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	pokemons := make(map[int]models.Pokemon, len(ids))
+	for rows.Next() {
+		var pokemon models.Pokemon
+		if err := rows.Scan(&pokemon.ID, &pokemon.Name, &pokemon.Type, &pokemon.HP, &pokemon.Attack, &pokemon.Defense, &pokemon.Speed, &pokemon.Level, &pokemon.Experience); err != nil {
+			return err
+		}
+		pokemons[pokemon.ID] = pokemon
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	updated, levelUps := grant(battle, pokemons)
+
+	updateQuery := "UPDATE pokemons SET hp=$1, attack=$2, defense=$3, level=$4, experience=$5 WHERE id=$6"
+	for _, pokemon := range updated {
+		_, err := tx.ExecContext(ctx, updateQuery, pokemon.HP, pokemon.Attack, pokemon.Defense, levelValue(pokemon), pokemon.Experience, pokemon.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	levelUpQuery := "INSERT INTO level_ups (pokemon_id, battle_id, level, hp, attack, defense, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	for _, levelUp := range levelUps {
+		_, err := tx.ExecContext(ctx, levelUpQuery, levelUp.PokemonID, battle.ID, levelUp.Level, levelUp.HP, levelUp.Attack, levelUp.Defense, levelUp.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
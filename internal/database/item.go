@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pokemon-battle/internal/models"
+)
+
+type itemService struct {
+	// ItemCRUDService is a generic CRUD service implemented by the service
+	ItemCRUDService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewItemService(srv Service) *itemService {
+	return &itemService{
+		srv: srv,
+	}
+}
+
+// itemColumns are the columns of the items table, in the order used by scanItem
+const itemColumns = "id, name, effect, value, threshold, consumable"
+
+// scanItem reads an item from a row with the itemColumns
+func scanItem(row rowScanner) (models.Item, error) {
+	var item models.Item
+	err := row.Scan(&item.ID, &item.Name, &item.Effect, &item.Value, &item.Threshold, &item.Consumable)
+	return item, err
+}
+
+// Create inserts a new item into the catalog
+func (s *itemService) Create(ctx context.Context, item *models.Item) error {
+	db := s.srv.MustDB()
+
+	if err := item.Validate(); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO items (name, effect, value, threshold, consumable) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	return db.QueryRowContext(ctx, query, item.Name, item.Effect, item.Value, item.Threshold, item.Consumable).Scan(&item.ID)
+}
+
+// Delete deletes an item from the catalog, taking it from the pokemon that hold it
+func (s *itemService) Delete(ctx context.Context, id int) error {
+	db := s.srv.MustDB()
+
+	query := "DELETE FROM items WHERE id=$1"
+	_, err := db.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetAll retrieves all the items of the catalog
+func (s *itemService) GetAll(ctx context.Context) ([]models.Item, error) {
+	db := s.srv.MustDB()
+
+	rows, err := db.QueryContext(ctx, "SELECT "+itemColumns+" FROM items ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetByID retrieves an item of the catalog by its ID
+func (s *itemService) GetByID(ctx context.Context, id int) (models.Item, error) {
+	db := s.srv.MustDB()
+
+	row := db.QueryRowContext(ctx, "SELECT "+itemColumns+" FROM items WHERE id=$1", id)
+	return scanItem(row)
+}
+
+// Update updates an existing item of the catalog
+func (s *itemService) Update(ctx context.Context, item models.Item) error {
+	db := s.srv.MustDB()
+
+	if err := item.Validate(); err != nil {
+		return err
+	}
+
+	query := "UPDATE items SET name=$1, effect=$2, value=$3, threshold=$4, consumable=$5 WHERE id=$6"
+	_, err := db.ExecContext(ctx, query, item.Name, item.Effect, item.Value, item.Threshold, item.Consumable, item.ID)
+	return err
+}
+
+type heldItemService struct {
+	// HeldItemService is the service to manage the items held by the pokemons
+	HeldItemService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewHeldItemService(srv Service) *heldItemService {
+	return &heldItemService{
+		srv: srv,
+	}
+}
+
+// getHeldItem retrieves the item held by a pokemon, nil if it holds none
+func getHeldItem(ctx context.Context, db queryer, pokemonID int) (*models.Item, error) {
+	query := `SELECT i.id, i.name, i.effect, i.value, i.threshold, i.consumable
+		FROM items i JOIN held_items h ON h.item_id = i.id
+		WHERE h.pokemon_id=$1`
+	rows, err := db.QueryContext(ctx, query, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	item, err := scanItem(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &item, rows.Err()
+}
+
+// GetItem retrieves the item held by a pokemon.
+// It returns sql.ErrNoRows if the pokemon holds no item.
+func (s *heldItemService) GetItem(ctx context.Context, pokemonID int) (models.Item, error) {
+	item, err := getHeldItem(ctx, s.srv.MustDB(), pokemonID)
+	if err != nil {
+		return models.Item{}, err
+	}
+	if item == nil {
+		return models.Item{}, sql.ErrNoRows
+	}
+	return *item, nil
+}
+
+// Give makes a pokemon hold an item, replacing the item it held before
+func (s *heldItemService) Give(ctx context.Context, pokemonID int, itemID int) error {
+	db := s.srv.MustDB()
+
+	query := `INSERT INTO held_items (pokemon_id, item_id) VALUES ($1, $2)
+		ON CONFLICT (pokemon_id) DO UPDATE SET item_id = EXCLUDED.item_id`
+	_, err := db.ExecContext(ctx, query, pokemonID, itemID)
+	return err
+}
+
+// Take takes the item held by a pokemon, if it holds one
+func (s *heldItemService) Take(ctx context.Context, pokemonID int) error {
+	db := s.srv.MustDB()
+
+	query := "DELETE FROM held_items WHERE pokemon_id=$1"
+	_, err := db.ExecContext(ctx, query, pokemonID)
+	return err
+}
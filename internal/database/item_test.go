@@ -0,0 +1,166 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestNewItemService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewItemService(dbService)
+	if srv == nil {
+		t.Fatal("NewItemService() returned nil")
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		item := createTestItem(t, srv)
+		defer cleanupItem(t, srv, item.ID)
+
+		if item.ID == 0 {
+			t.Fatal("expected Create() to set the ID of the item")
+		}
+	})
+
+	t.Run("Create invalid", func(t *testing.T) {
+		item := models.Item{Name: "Mystery Box", Effect: "teleport"}
+		if err := srv.Create(context.Background(), &item); err == nil {
+			t.Fatal("expected Create() to fail with an unknown effect")
+		}
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		items, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(items) == 0 {
+			t.Fatal("expected GetAll() to return the items of the test data")
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		item := createTestItem(t, srv)
+		defer cleanupItem(t, srv, item.ID)
+
+		item.Value = 40
+		if err := srv.Update(context.Background(), item); err != nil {
+			t.Fatalf("expected Update() to return nil, got %v", err)
+		}
+
+		item, err := srv.GetByID(context.Background(), item.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if item.Value != 40 || item.Threshold != 50 || !item.Consumable {
+			t.Fatalf("expected the item to be updated, got %+v", item)
+		}
+	})
+}
+
+func TestNewHeldItemService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewHeldItemService(dbService)
+	if srv == nil {
+		t.Fatal("NewHeldItemService() returned nil")
+	}
+
+	itemSrv := database.NewItemService(dbService)
+	pokemonSrv := database.NewPokemonService(dbService)
+
+	t.Run("Give", func(t *testing.T) {
+		pokemon := createTestPokemon(t, pokemonSrv)
+		defer cleanupPokemon(t, pokemonSrv, pokemon.ID)
+		item := createTestItem(t, itemSrv)
+		defer cleanupItem(t, itemSrv, item.ID)
+
+		if _, err := srv.GetItem(context.Background(), pokemon.ID); !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("expected GetItem() to return sql.ErrNoRows, got %v", err)
+		}
+
+		if err := srv.Give(context.Background(), pokemon.ID, item.ID); err != nil {
+			t.Fatalf("expected Give() to return nil, got %v", err)
+		}
+
+		held, err := srv.GetItem(context.Background(), pokemon.ID)
+		if err != nil {
+			t.Fatalf("expected GetItem() to return nil, got %v", err)
+		}
+		if held.ID != item.ID {
+			t.Fatalf("expected the pokemon to hold the item %d, got %+v", item.ID, held)
+		}
+
+		// the pokemon takes its item into its battles
+		pokemon, err = pokemonSrv.GetByID(context.Background(), pokemon.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if pokemon.Item == nil || pokemon.Item.ID != item.ID {
+			t.Fatalf("expected GetByID() to return the held item, got %+v", pokemon.Item)
+		}
+
+		pokemons, err := pokemonSrv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		for _, p := range pokemons {
+			if p.ID == pokemon.ID && (p.Item == nil || p.Item.ID != item.ID) {
+				t.Fatalf("expected GetAll() to return the held item, got %+v", p.Item)
+			}
+		}
+
+		// a pokemon holds a single item
+		other := createTestItem(t, itemSrv)
+		defer cleanupItem(t, itemSrv, other.ID)
+		if err := srv.Give(context.Background(), pokemon.ID, other.ID); err != nil {
+			t.Fatalf("expected Give() to return nil, got %v", err)
+		}
+		held, err = srv.GetItem(context.Background(), pokemon.ID)
+		if err != nil || held.ID != other.ID {
+			t.Fatalf("expected the new item to replace the old one, got %+v, %v", held, err)
+		}
+	})
+
+	t.Run("Take", func(t *testing.T) {
+		pokemon := createTestPokemon(t, pokemonSrv)
+		defer cleanupPokemon(t, pokemonSrv, pokemon.ID)
+		item := createTestItem(t, itemSrv)
+		defer cleanupItem(t, itemSrv, item.ID)
+
+		if err := srv.Give(context.Background(), pokemon.ID, item.ID); err != nil {
+			t.Fatalf("expected Give() to return nil, got %v", err)
+		}
+		if err := srv.Take(context.Background(), pokemon.ID); err != nil {
+			t.Fatalf("expected Take() to return nil, got %v", err)
+		}
+		if _, err := srv.GetItem(context.Background(), pokemon.ID); !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("expected GetItem() to return sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
+// createTestItem is a helper function to create an item for testing
+func createTestItem(t *testing.T, srv database.ItemCRUDService) models.Item {
+	t.Helper()
+
+	item := models.Item{Name: "Test Potion", Effect: models.ItemEffectHeal, Value: 20, Threshold: 50, Consumable: true}
+	if err := srv.Create(context.Background(), &item); err != nil {
+		t.Fatalf("expected Create() to return nil, got %v", err)
+	}
+	return item
+}
+
+// cleanupItem is a helper function to delete an item from the database
+func cleanupItem(t *testing.T, srv database.ItemCRUDService, id int) {
+	t.Helper()
+
+	if err := srv.Delete(context.Background(), id); err != nil {
+		t.Fatalf("expected Delete() to return nil, got %v", err)
+	}
+}
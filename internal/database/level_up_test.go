@@ -0,0 +1,68 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestNewLevelUpService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewLevelUpService(dbService)
+	if srv == nil {
+		t.Fatal("NewLevelUpService() returned nil")
+	}
+
+	pokemonSrv := database.NewPokemonService(dbService)
+	battleSrv := database.NewBattleService(dbService).WithExperience(business.GrantExperience)
+
+	t.Run("Create", func(t *testing.T) {
+		// a new pokemon starts at level 1
+		winner := createTestPokemon(t, pokemonSrv)
+		defer cleanupPokemon(t, pokemonSrv, winner.ID)
+		if winner.Level != 1 {
+			t.Fatalf("expected a new pokemon to be at level 1, got %d", winner.Level)
+		}
+
+		loser := models.Pokemon{Name: "Mewtwo", Type: "Psychic", HP: 106, Attack: 110, Defense: 90, Speed: 130, Level: 50}
+		if err := pokemonSrv.Create(context.Background(), &loser); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer cleanupPokemon(t, pokemonSrv, loser.ID)
+
+		battle := models.Battle{Pokemon1ID: winner.ID, Pokemon2ID: loser.ID, WinnerID: winner.ID, Turns: 3}
+		if err := battleSrv.Create(context.Background(), &battle); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer cleanupBattle(t, battleSrv, battle.ID)
+
+		updated, err := pokemonSrv.GetByID(context.Background(), winner.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if updated.Level <= 1 || updated.Experience == 0 || updated.HP <= winner.HP {
+			t.Fatalf("expected the winner to level up, got %+v", updated)
+		}
+
+		levelUps, err := srv.GetByPokemonID(context.Background(), winner.ID)
+		if err != nil {
+			t.Fatalf("expected GetByPokemonID() to return nil, got %v", err)
+		}
+		if len(levelUps) != updated.Level-1 || levelUps[len(levelUps)-1].BattleID != battle.ID {
+			t.Fatalf("expected a level-up per new level from the battle, got %+v", levelUps)
+		}
+
+		// the loser doesn't gain experience
+		unchanged, err := pokemonSrv.GetByID(context.Background(), loser.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if unchanged.Level != 50 || unchanged.Experience != 0 {
+			t.Fatalf("expected the loser to stay the same, got %+v", unchanged)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+
+	"pokemon-battle/internal/models"
+)
+
+type matchupService struct {
+	// MatchupService is the service to store the matchup matrix
+	MatchupService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewMatchupService(srv Service) *matchupService {
+	return &matchupService{
+		srv: srv,
+	}
+}
+
+// ReplaceAll replaces the stored matchups with the given ones, in a single
+// transaction, so the matrix is never read half computed
+func (s *matchupService) ReplaceAll(ctx context.Context, matchups []models.Matchup) error {
+	db := s.srv.MustDB()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM matchups"); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO matchups (pokemon1_id, pokemon2_id, runs, pokemon1_wins, pokemon2_wins, draws, computed_at) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	for _, m := range matchups {
+		_, err := tx.ExecContext(ctx, query, m.Pokemon1ID, m.Pokemon2ID, m.Runs, m.Pokemon1Wins, m.Pokemon2Wins, m.Draws, m.ComputedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetAll retrieves all the stored matchups
+func (s *matchupService) GetAll(ctx context.Context) ([]models.Matchup, error) {
+	db := s.srv.MustDB()
+
+	query := "SELECT pokemon1_id, pokemon2_id, runs, pokemon1_wins, pokemon2_wins, draws, computed_at FROM matchups ORDER BY pokemon1_id, pokemon2_id"
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matchups := []models.Matchup{}
+	for rows.Next() {
+		var m models.Matchup
+		if err := rows.Scan(&m.Pokemon1ID, &m.Pokemon2ID, &m.Runs, &m.Pokemon1Wins, &m.Pokemon2Wins, &m.Draws, &m.ComputedAt); err != nil {
+			return nil, err
+		}
+		matchups = append(matchups, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return matchups, nil
+}
@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestNewMatchupService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewMatchupService(dbService)
+	if srv == nil {
+		t.Fatal("NewMatchupService() returned nil")
+	}
+
+	t.Run("ReplaceAll", func(t *testing.T) {
+		computedAt := time.Now().UTC().Truncate(time.Second)
+		matchups := []models.Matchup{
+			{Pokemon1ID: 1, Pokemon2ID: 2, Runs: 10, Pokemon1Wins: 6, Pokemon2Wins: 3, Draws: 1, ComputedAt: computedAt},
+			{Pokemon1ID: 1, Pokemon2ID: 3, Runs: 10, Pokemon1Wins: 2, Pokemon2Wins: 8, ComputedAt: computedAt},
+		}
+
+		if err := srv.ReplaceAll(context.Background(), matchups); err != nil {
+			t.Fatalf("expected ReplaceAll() to return nil, got %v", err)
+		}
+
+		stored, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(stored) != 2 {
+			t.Fatalf("expected GetAll() to return 2 matchups, got %d", len(stored))
+		}
+		if stored[0].Pokemon2ID != 2 || stored[0].Pokemon1Wins != 6 || stored[0].Draws != 1 {
+			t.Fatalf("expected the first matchup to be %+v, got %+v", matchups[0], stored[0])
+		}
+		if !stored[0].ComputedAt.Equal(computedAt) {
+			t.Fatalf("expected computed at %v, got %v", computedAt, stored[0].ComputedAt)
+		}
+
+		// a new matrix replaces the previous one
+		if err := srv.ReplaceAll(context.Background(), matchups[1:]); err != nil {
+			t.Fatalf("expected ReplaceAll() to return nil, got %v", err)
+		}
+
+		stored, err = srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(stored) != 1 || stored[0].Pokemon2ID != 3 {
+			t.Fatalf("expected GetAll() to return only the last matchup, got %+v", stored)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+
+	"pokemon-battle/internal/models"
+)
+
+type moveService struct {
+	// MoveCRUDService is a generic CRUD service implemented by the service
+	MoveCRUDService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewMoveService(srv Service) *moveService {
+	return &moveService{
+		srv: srv,
+	}
+}
+
+// Create inserts a new move into the database
+func (s *moveService) Create(ctx context.Context, move *models.Move) error {
+	db := s.srv.MustDB()
+
+	if err := move.Validate(); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance, target, condition) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id"
+
+	return db.QueryRowContext(ctx, query, move.Name, move.Type, move.Power, move.Accuracy, move.Category, move.Effect, move.EffectChance, move.Target, move.Condition).Scan(&move.ID)
+}
+
+// Delete deletes a move from the database, making the pokemons forget it
+func (s *moveService) Delete(ctx context.Context, id int) error {
+	db := s.srv.MustDB()
+
+	query := "DELETE FROM moves WHERE id=$1"
+	_, err := db.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetAll retrieves all moves from the database
+func (s *moveService) GetAll(ctx context.Context) ([]models.Move, error) {
+	db := s.srv.MustDB()
+
+	query := "SELECT id, name, type, power, accuracy, category, effect, effect_chance, target, condition FROM moves ORDER BY id"
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []models.Move
+	for rows.Next() {
+		var move models.Move
+		if err := rows.Scan(&move.ID, &move.Name, &move.Type, &move.Power, &move.Accuracy, &move.Category, &move.Effect, &move.EffectChance, &move.Target, &move.Condition); err != nil {
+			return nil, err
+		}
+		moves = append(moves, move)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return moves, nil
+}
+
+// GetByID retrieves a move from the database by its ID
+func (s *moveService) GetByID(ctx context.Context, id int) (models.Move, error) {
+	db := s.srv.MustDB()
+
+	query := "SELECT id, name, type, power, accuracy, category, effect, effect_chance, target, condition FROM moves WHERE id=$1"
+	row := db.QueryRowContext(ctx, query, id)
+
+	var move models.Move
+	if err := row.Scan(&move.ID, &move.Name, &move.Type, &move.Power, &move.Accuracy, &move.Category, &move.Effect, &move.EffectChance, &move.Target, &move.Condition); err != nil {
+		return models.Move{}, err
+	}
+	return move, nil
+}
+
+// Update updates an existing move in the database
+func (s *moveService) Update(ctx context.Context, move models.Move) error {
+	db := s.srv.MustDB()
+
+	if err := move.Validate(); err != nil {
+		return err
+	}
+
+	query := "UPDATE moves SET name=$1, type=$2, power=$3, accuracy=$4, category=$5, effect=$6, effect_chance=$7, target=$8, condition=$9 WHERE id=$10"
+	_, err := db.ExecContext(ctx, query, move.Name, move.Type, move.Power, move.Accuracy, move.Category, move.Effect, move.EffectChance, move.Target, move.Condition, move.ID)
+	return err
+}
@@ -0,0 +1,159 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestNewMoveService(t *testing.T) {
+	srv := database.NewMoveService(database.MustNewWithDatabase(t))
+
+	if srv == nil {
+		t.Fatal("NewMoveService() returned nil")
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		move := createTestMove(t, srv)
+		defer cleanupMove(t, srv, move.ID)
+
+		if move.ID == 0 {
+			t.Fatal("expected ID to be greater than 0")
+		}
+	})
+
+	t.Run("Create/invalid", func(t *testing.T) {
+		move := models.Move{Name: "Splash", Type: "Normal", Power: 10, Accuracy: 100, Category: models.MoveCategoryStatus}
+
+		err := srv.Create(context.Background(), &move)
+		if err == nil {
+			t.Fatal("expected Create() to return an error")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		move := createTestMove(t, srv)
+
+		err := srv.Delete(context.Background(), move.ID)
+		if err != nil {
+			t.Fatalf("expected Delete() to return nil, got %v", err)
+		}
+
+		_, err = srv.GetByID(context.Background(), move.ID)
+		if err != sql.ErrNoRows {
+			t.Fatalf("expected GetByID() to return sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		moves, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+
+		// There are 35 moves in the testdata/01-inserts.sql file
+		if len(moves) != 35 {
+			t.Fatalf("expected GetAll() to return 35 moves, got %d", len(moves))
+		}
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		move, err := srv.GetByID(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		if move.Name != "Tackle" {
+			t.Fatalf("expected name to be 'Tackle', got %s", move.Name)
+		}
+	})
+
+	t.Run("GetByID/effect", func(t *testing.T) {
+		// Thunder Wave is the move with ID 27 in the testdata/01-inserts.sql file
+		move, err := srv.GetByID(context.Background(), 27)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		if move.Effect != models.StatusParalysis || move.EffectChance != 100 {
+			t.Fatalf("expected effect to be paralysis (100), got %s (%d)", move.Effect, move.EffectChance)
+		}
+	})
+
+	t.Run("GetByID/target", func(t *testing.T) {
+		// Earthquake is the move with ID 21 in the testdata/01-inserts.sql file
+		move, err := srv.GetByID(context.Background(), 21)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		if move.Target != models.MoveTargetAllFoes {
+			t.Fatalf("expected target to be all-foes, got %s", move.Target)
+		}
+	})
+
+	t.Run("GetByID/condition", func(t *testing.T) {
+		// Rain Dance is the move with ID 32 in the testdata/01-inserts.sql file
+		move, err := srv.GetByID(context.Background(), 32)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		if move.Condition != models.ConditionRain {
+			t.Fatalf("expected condition to be rain, got %s", move.Condition)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		move := createTestMove(t, srv)
+		defer cleanupMove(t, srv, move.ID)
+
+		move.Power = 120
+
+		err := srv.Update(context.Background(), move)
+		if err != nil {
+			t.Fatalf("expected Update() to return nil, got %v", err)
+		}
+
+		move, err = srv.GetByID(context.Background(), move.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		if move.Power != 120 {
+			t.Fatalf("expected power to be 120, got %d", move.Power)
+		}
+	})
+}
+
+// createTestMove is a helper function to create a move for testing
+func createTestMove(t *testing.T, srv database.MoveCRUDService) models.Move {
+	t.Helper()
+
+	move := models.Move{
+		Name:     "Volt Tackle",
+		Type:     "Electric",
+		Power:    120,
+		Accuracy: 100,
+		Category: models.MoveCategoryPhysical,
+	}
+
+	err := srv.Create(context.Background(), &move)
+	if err != nil {
+		t.Fatalf("expected Create() to return nil, got %v", err)
+	}
+	return move
+}
+
+// cleanupMove is a helper function to delete a move from the database
+func cleanupMove(t *testing.T, srv database.MoveCRUDService, id int) {
+	t.Helper()
+
+	err := srv.Delete(context.Background(), id)
+	if err != nil {
+		t.Fatalf("expected Delete() to return nil, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 
 	"pokemon-battle/internal/models"
 )
@@ -28,9 +29,15 @@ func (s *pokemonService) Create(ctx context.Context, pokemon *models.Pokemon) er
 		return err
 	}
 
-	query := "INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	query := "INSERT INTO pokemons (name, type, hp, attack, defense, speed, level, experience, ability) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, level"
 
-	return db.QueryRowContext(ctx, query, pokemon.Name, pokemon.Type, pokemon.HP, pokemon.Attack, pokemon.Defense).Scan(&pokemon.ID)
+	return db.QueryRowContext(ctx, query, pokemon.Name, pokemon.Type, pokemon.HP, pokemon.Attack, pokemon.Defense, pokemon.Speed, levelValue(*pokemon), pokemon.Experience, pokemon.Ability).Scan(&pokemon.ID, &pokemon.Level)
+}
+
+// levelValue returns the value stored in the level column,
+// pokemon without a level are stored at level 1
+func levelValue(pokemon models.Pokemon) int {
+	return max(pokemon.Level, 1)
 }
 
 // Delete deletes a pokemon from the database
@@ -42,11 +49,14 @@ func (s *pokemonService) Delete(ctx context.Context, id int) error {
 	return err
 }
 
-// GetAll retrieves all pokemons from the database
+// GetAll retrieves all pokemons from the database, including their held items
 func (s *pokemonService) GetAll(ctx context.Context) ([]models.Pokemon, error) {
 	db := s.srv.MustDB()
 
-	query := "SELECT id, name, type, hp, attack, defense FROM pokemons ORDER BY id"
+	query := `SELECT p.id, p.name, p.type, p.hp, p.attack, p.defense, p.speed, p.level, p.experience, p.ability,
+		i.id, i.name, i.effect, i.value, i.threshold, i.consumable
+		FROM pokemons p LEFT JOIN held_items h ON h.pokemon_id = p.id LEFT JOIN items i ON i.id = h.item_id
+		ORDER BY p.id`
 	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -56,9 +66,24 @@ func (s *pokemonService) GetAll(ctx context.Context) ([]models.Pokemon, error) {
 	var pokemons []models.Pokemon
 	for rows.Next() {
 		var pokemon models.Pokemon
-		if err := rows.Scan(&pokemon.ID, &pokemon.Name, &pokemon.Type, &pokemon.HP, &pokemon.Attack, &pokemon.Defense); err != nil {
+		// the item columns are NULL for the pokemon without an item
+		var itemID, itemValue, itemThreshold sql.NullInt64
+		var itemName, itemEffect sql.NullString
+		var itemConsumable sql.NullBool
+		if err := rows.Scan(&pokemon.ID, &pokemon.Name, &pokemon.Type, &pokemon.HP, &pokemon.Attack, &pokemon.Defense, &pokemon.Speed, &pokemon.Level, &pokemon.Experience, &pokemon.Ability,
+			&itemID, &itemName, &itemEffect, &itemValue, &itemThreshold, &itemConsumable); err != nil {
 			return nil, err
 		}
+		if itemID.Valid {
+			pokemon.Item = &models.Item{
+				ID:         int(itemID.Int64),
+				Name:       itemName.String,
+				Effect:     itemEffect.String,
+				Value:      int(itemValue.Int64),
+				Threshold:  int(itemThreshold.Int64),
+				Consumable: itemConsumable.Bool,
+			}
+		}
 		pokemons = append(pokemons, pokemon)
 	}
 
@@ -69,17 +94,29 @@ func (s *pokemonService) GetAll(ctx context.Context) ([]models.Pokemon, error) {
 	return pokemons, nil
 }
 
-// GetByID retrieves a pokemon from the database by its ID
+// GetByID retrieves a pokemon from the database by its ID, including its moves and its held item
 func (s *pokemonService) GetByID(ctx context.Context, id int) (models.Pokemon, error) {
 	db := s.srv.MustDB()
 
-	query := "SELECT id, name, type, hp, attack, defense FROM pokemons WHERE id=$1"
+	query := "SELECT id, name, type, hp, attack, defense, speed, level, experience, ability FROM pokemons WHERE id=$1"
 	row := db.QueryRowContext(ctx, query, id)
 
 	var pokemon models.Pokemon
-	if err := row.Scan(&pokemon.ID, &pokemon.Name, &pokemon.Type, &pokemon.HP, &pokemon.Attack, &pokemon.Defense); err != nil {
+	if err := row.Scan(&pokemon.ID, &pokemon.Name, &pokemon.Type, &pokemon.HP, &pokemon.Attack, &pokemon.Defense, &pokemon.Speed, &pokemon.Level, &pokemon.Experience, &pokemon.Ability); err != nil {
+		return models.Pokemon{}, err
+	}
+
+	moves, err := getPokemonMoves(ctx, db, pokemon.ID)
+	if err != nil {
+		return models.Pokemon{}, err
+	}
+	pokemon.Moves = moves
+
+	pokemon.Item, err = getHeldItem(ctx, db, pokemon.ID)
+	if err != nil {
 		return models.Pokemon{}, err
 	}
+
 	return pokemon, nil
 }
 
@@ -91,7 +128,8 @@ func (s *pokemonService) Update(ctx context.Context, pokemon models.Pokemon) err
 		return err
 	}
 
-	query := "UPDATE pokemons SET name=$1, type=$2, hp=$3, attack=$4, defense=$5 WHERE id=$6"
-	_, err := db.ExecContext(ctx, query, pokemon.Name, pokemon.Type, pokemon.HP, pokemon.Attack, pokemon.Defense, pokemon.ID)
+	// the level and the experience are only earned in battles
+	query := "UPDATE pokemons SET name=$1, type=$2, hp=$3, attack=$4, defense=$5, speed=$6, ability=$7 WHERE id=$8"
+	_, err := db.ExecContext(ctx, query, pokemon.Name, pokemon.Type, pokemon.HP, pokemon.Attack, pokemon.Defense, pokemon.Speed, pokemon.Ability, pokemon.ID)
 	return err
 }
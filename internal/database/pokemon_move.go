@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"pokemon-battle/internal/models"
+)
+
+// ErrMovesetFull is returned when a pokemon tries to learn a move
+// but it already knows models.MaxMoves moves
+var ErrMovesetFull = errors.New("pokemon already knows the maximum number of moves")
+
+type pokemonMoveService struct {
+	// PokemonMoveService is the service to manage the moves of the pokemons
+	PokemonMoveService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewPokemonMoveService(srv Service) *pokemonMoveService {
+	return &pokemonMoveService{
+		srv: srv,
+	}
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// getPokemonMoves retrieves the moves known by a pokemon
+func getPokemonMoves(ctx context.Context, db queryer, pokemonID int) ([]models.Move, error) {
+	query := `SELECT m.id, m.name, m.type, m.power, m.accuracy, m.category, m.effect, m.effect_chance, m.target, m.condition
+		FROM moves m JOIN pokemon_moves pm ON pm.move_id = m.id
+		WHERE pm.pokemon_id=$1 ORDER BY m.id`
+	rows, err := db.QueryContext(ctx, query, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	moves := []models.Move{}
+	for rows.Next() {
+		var move models.Move
+		if err := rows.Scan(&move.ID, &move.Name, &move.Type, &move.Power, &move.Accuracy, &move.Category, &move.Effect, &move.EffectChance, &move.Target, &move.Condition); err != nil {
+			return nil, err
+		}
+		moves = append(moves, move)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return moves, nil
+}
+
+// GetMoves retrieves the moves known by a pokemon
+func (s *pokemonMoveService) GetMoves(ctx context.Context, pokemonID int) ([]models.Move, error) {
+	return getPokemonMoves(ctx, s.srv.MustDB(), pokemonID)
+}
+
+// Learn teaches a move to a pokemon. Learning an already known move does nothing.
+// It returns ErrMovesetFull if the pokemon already knows models.MaxMoves moves.
+func (s *pokemonMoveService) Learn(ctx context.Context, pokemonID int, moveID int) error {
+	db := s.srv.MustDB()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// lock the pokemon so concurrent requests cannot exceed the maximum number of moves
+	var id int
+	err = tx.QueryRowContext(ctx, "SELECT id FROM pokemons WHERE id=$1 FOR UPDATE", pokemonID).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	moves, err := getPokemonMoves(ctx, tx, pokemonID)
+	if err != nil {
+		return err
+	}
+
+	for _, move := range moves {
+		if move.ID == moveID {
+			return nil
+		}
+	}
+
+	if len(moves) >= models.MaxMoves {
+		return ErrMovesetFull
+	}
+
+	query := "INSERT INTO pokemon_moves (pokemon_id, move_id) VALUES ($1, $2)"
+	if _, err := tx.ExecContext(ctx, query, pokemonID, moveID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Forget makes a pokemon forget a move
+func (s *pokemonMoveService) Forget(ctx context.Context, pokemonID int, moveID int) error {
+	db := s.srv.MustDB()
+
+	query := "DELETE FROM pokemon_moves WHERE pokemon_id=$1 AND move_id=$2"
+	_, err := db.ExecContext(ctx, query, pokemonID, moveID)
+	return err
+}
@@ -0,0 +1,93 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pokemon-battle/internal/database"
+)
+
+func TestNewPokemonMoveService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewPokemonMoveService(dbService)
+	if srv == nil {
+		t.Fatal("NewPokemonMoveService() returned nil")
+	}
+
+	pokemonSrv := database.NewPokemonService(dbService)
+	moveSrv := database.NewMoveService(dbService)
+
+	t.Run("GetMoves", func(t *testing.T) {
+		// Pikachu knows 4 moves in the testdata/01-inserts.sql file
+		moves, err := srv.GetMoves(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("expected GetMoves() to return nil, got %v", err)
+		}
+
+		if len(moves) != 4 {
+			t.Fatalf("expected GetMoves() to return 4 moves, got %d", len(moves))
+		}
+	})
+
+	t.Run("Learn", func(t *testing.T) {
+		pokemon := createTestPokemon(t, pokemonSrv)
+		defer cleanupPokemon(t, pokemonSrv, pokemon.ID)
+
+		move := createTestMove(t, moveSrv)
+		defer cleanupMove(t, moveSrv, move.ID)
+
+		err := srv.Learn(context.Background(), pokemon.ID, move.ID)
+		if err != nil {
+			t.Fatalf("expected Learn() to return nil, got %v", err)
+		}
+
+		// learning the same move twice does nothing
+		err = srv.Learn(context.Background(), pokemon.ID, move.ID)
+		if err != nil {
+			t.Fatalf("expected Learn() to return nil, got %v", err)
+		}
+
+		p, err := pokemonSrv.GetByID(context.Background(), pokemon.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		if len(p.Moves) != 1 || p.Moves[0].ID != move.ID {
+			t.Fatalf("expected pokemon to know move %d, got %+v", move.ID, p.Moves)
+		}
+	})
+
+	t.Run("Learn/full", func(t *testing.T) {
+		// Pikachu already knows 4 moves, so it cannot learn another one
+		err := srv.Learn(context.Background(), 1, 26)
+		if !errors.Is(err, database.ErrMovesetFull) {
+			t.Fatalf("expected Learn() to return ErrMovesetFull, got %v", err)
+		}
+	})
+
+	t.Run("Forget", func(t *testing.T) {
+		pokemon := createTestPokemon(t, pokemonSrv)
+		defer cleanupPokemon(t, pokemonSrv, pokemon.ID)
+
+		err := srv.Learn(context.Background(), pokemon.ID, 1)
+		if err != nil {
+			t.Fatalf("expected Learn() to return nil, got %v", err)
+		}
+
+		err = srv.Forget(context.Background(), pokemon.ID, 1)
+		if err != nil {
+			t.Fatalf("expected Forget() to return nil, got %v", err)
+		}
+
+		moves, err := srv.GetMoves(context.Background(), pokemon.ID)
+		if err != nil {
+			t.Fatalf("expected GetMoves() to return nil, got %v", err)
+		}
+
+		if len(moves) != 0 {
+			t.Fatalf("expected GetMoves() to return 0 moves, got %d", len(moves))
+		}
+	})
+}
@@ -64,6 +64,10 @@ func TestNewPokemonService(t *testing.T) {
 		if pokemon.Name != "Pikachu" {
 			t.Fatalf("expected name to be 'Pikachu', got %s", pokemon.Name)
 		}
+
+		if pokemon.Speed != 90 {
+			t.Fatalf("expected speed to be 90, got %d", pokemon.Speed)
+		}
 	})
 
 	t.Run("Update", func(t *testing.T) {
@@ -71,6 +75,10 @@ func TestNewPokemonService(t *testing.T) {
 		defer cleanupPokemon(t, srv, pokemon.ID)
 
 		pokemon.Name = "Test Pikachu"
+		pokemon.Speed = 110
+		pokemon.Ability = "levitate"
+		pokemon.Level = 50
+		pokemon.Experience = 9000
 
 		err := srv.Update(context.Background(), pokemon)
 		if err != nil {
@@ -85,6 +93,19 @@ func TestNewPokemonService(t *testing.T) {
 		if pokemon.Name != "Test Pikachu" {
 			t.Fatalf("expected name to be 'Test Pikachu', got %s", pokemon.Name)
 		}
+
+		if pokemon.Speed != 110 {
+			t.Fatalf("expected speed to be 110, got %d", pokemon.Speed)
+		}
+
+		if pokemon.Ability != "levitate" {
+			t.Fatalf("expected ability to be 'levitate', got %s", pokemon.Ability)
+		}
+
+		// the level and the experience are only earned in battles
+		if pokemon.Level != 1 || pokemon.Experience != 0 {
+			t.Fatalf("expected level 1 and no experience, got level %d and %d experience", pokemon.Level, pokemon.Experience)
+		}
 	})
 }
 
@@ -98,6 +119,7 @@ func createTestPokemon(t *testing.T, srv database.PokemonCRUDService) models.Pok
 		HP:      100,
 		Attack:  55,
 		Defense: 40,
+		Speed:   90,
 	}
 
 	err := srv.Create(context.Background(), &pokemon)
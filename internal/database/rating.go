@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pokemon-battle/internal/models"
+)
+
+// RateFunc computes the new ratings of the pokemon of a battle from their current
+// ratings. The current ratings only contain the pokemon that were already rated.
+type RateFunc func(battle models.Battle, ratings map[int]models.Rating) []models.Rating
+
+type ratingService struct {
+	// RatingService is the service to read the ratings of the pokemon
+	RatingService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewRatingService(srv Service) *ratingService {
+	return &ratingService{
+		srv: srv,
+	}
+}
+
+// ratingColumns are the columns of the ratings table, in the order used by scanRating
+const ratingColumns = "pokemon_id, rating, deviation, volatility, battles, updated_at"
+
+// scanRating reads a rating from a row with the ratingColumns
+func scanRating(row rowScanner) (models.Rating, error) {
+	var rating models.Rating
+	err := row.Scan(&rating.PokemonID, &rating.Rating, &rating.Deviation, &rating.Volatility, &rating.Battles, &rating.UpdatedAt)
+	return rating, err
+}
+
+// GetAll retrieves the ratings of all the rated pokemon, from the highest to the lowest
+func (s *ratingService) GetAll(ctx context.Context) ([]models.Rating, error) {
+	db := s.srv.MustDB()
+
+	rows, err := db.QueryContext(ctx, "SELECT "+ratingColumns+" FROM ratings ORDER BY rating DESC, pokemon_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := []models.Rating{}
+	for rows.Next() {
+		rating, err := scanRating(rows)
+		if err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, rating)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ratings, nil
+}
+
+// GetByPokemonID retrieves the current rating of a pokemon.
+// It returns sql.ErrNoRows if the pokemon was never rated.
+func (s *ratingService) GetByPokemonID(ctx context.Context, pokemonID int) (models.Rating, error) {
+	db := s.srv.MustDB()
+
+	row := db.QueryRowContext(ctx, "SELECT "+ratingColumns+" FROM ratings WHERE pokemon_id=$1", pokemonID)
+	return scanRating(row)
+}
+
+// GetHistory retrieves the ratings of a pokemon after each of its rated battles,
+// from the oldest to the newest
+func (s *ratingService) GetHistory(ctx context.Context, pokemonID int) ([]models.RatingChange, error) {
+	db := s.srv.MustDB()
+
+	query := "SELECT battle_id, pokemon_id, rating, deviation, volatility, created_at FROM rating_history WHERE pokemon_id=$1 ORDER BY created_at, battle_id"
+	rows, err := db.QueryContext(ctx, query, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.RatingChange{}
+	for rows.Next() {
+		var change models.RatingChange
+		if err := rows.Scan(&change.BattleID, &change.PokemonID, &change.Rating, &change.Deviation, &change.Volatility, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// rateBattle updates the ratings of the pokemon of a stored battle and appends
+// them to their history, in the transaction of the battle insert. The current
+// ratings are locked so concurrent battles of the same pokemon are rated in order.
+func rateBattle(ctx context.Context, tx *sql.Tx, battle models.Battle, rate RateFunc) error {
+	ids := []int{battle.Pokemon1ID, battle.Pokemon2ID}
+	if battle.IsTeamBattle() {
+		ids = append(append([]int{}, battle.Team1...), battle.Team2...)
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT "+ratingColumns+" FROM ratings WHERE pokemon_id = ANY($1) FOR UPDATE", ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	current := make(map[int]models.Rating, len(ids))
+	for rows.Next() {
+		rating, err := scanRating(rows)
+		if err != nil {
+			return err
+		}
+		current[rating.PokemonID] = rating
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	upsert := `INSERT INTO ratings (pokemon_id, rating, deviation, volatility, battles, updated_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (pokemon_id) DO UPDATE SET rating = EXCLUDED.rating, deviation = EXCLUDED.deviation, volatility = EXCLUDED.volatility, battles = EXCLUDED.battles, updated_at = EXCLUDED.updated_at`
+	historyQuery := "INSERT INTO rating_history (battle_id, pokemon_id, rating, deviation, volatility, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+
+	for _, rating := range rate(battle, current) {
+		_, err := tx.ExecContext(ctx, upsert, rating.PokemonID, rating.Rating, rating.Deviation, rating.Volatility, rating.Battles, rating.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, historyQuery, battle.ID, rating.PokemonID, rating.Rating, rating.Deviation, rating.Volatility, rating.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+)
+
+func TestNewRatingService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewRatingService(dbService)
+	if srv == nil {
+		t.Fatal("NewRatingService() returned nil")
+	}
+
+	battleSrv := database.NewBattleService(dbService).WithRatings(business.RateBattle)
+
+	t.Run("GetByPokemonID/not-rated", func(t *testing.T) {
+		_, err := srv.GetByPokemonID(context.Background(), 3)
+		if err != sql.ErrNoRows {
+			t.Fatalf("expected GetByPokemonID() to return sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		// Pikachu wins the test battle against Charmander
+		battle := createTestBattle(t, battleSrv)
+		defer cleanupBattle(t, battleSrv, battle.ID)
+
+		ratings, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(ratings) != 2 {
+			t.Fatalf("expected GetAll() to return 2 ratings, got %d", len(ratings))
+		}
+		if ratings[0].PokemonID != 1 || ratings[0].Rating <= business.DefaultRating || ratings[1].Rating >= business.DefaultRating {
+			t.Fatalf("expected the winner to be ranked first, got %+v", ratings)
+		}
+
+		rating, err := srv.GetByPokemonID(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("expected GetByPokemonID() to return nil, got %v", err)
+		}
+		if rating.Battles != 1 {
+			t.Fatalf("expected 1 rated battle, got %d", rating.Battles)
+		}
+
+		history, err := srv.GetHistory(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("expected GetHistory() to return nil, got %v", err)
+		}
+		if len(history) != 1 || history[0].BattleID != battle.ID || history[0].Rating != rating.Rating {
+			t.Fatalf("expected the history to contain the battle, got %+v", history)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"pokemon-battle/internal/models"
+)
+
+type battleSessionService struct {
+	// BattleSessionCRUDService is a generic CRUD service implemented by the service
+	BattleSessionCRUDService
+
+	// srv is the service with the actual database connection
+	srv Service
+
+	// battles stores the battle of a finished session
+	battles *battleService
+}
+
+func NewBattleSessionService(srv Service) *battleSessionService {
+	return &battleSessionService{
+		srv: srv,
+	}
+}
+
+// WithBattles makes the service store the battles of the finished sessions with
+// the given battle service, in the same transaction as the session update
+func (s *battleSessionService) WithBattles(battles *battleService) *battleSessionService {
+	s.battles = battles
+	return s
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// sessionColumns are the columns of the battle_sessions table, in the order used by scanSession
+const sessionColumns = "id, team1, team2, seed, settings, participants, turns, pending1, pending2, status, battle_id, created_at"
+
+// scanSession reads a battle session from a row with the sessionColumns
+func scanSession(row rowScanner) (models.BattleSession, error) {
+	var session models.BattleSession
+	var team1, team2, settings, participants, turns, pending1, pending2 []byte
+	var battleID sql.NullInt64
+	if err := row.Scan(&session.ID, &team1, &team2, &session.Seed, &settings, &participants, &turns, &pending1, &pending2, &session.Status, &battleID, &session.CreatedAt); err != nil {
+		return models.BattleSession{}, err
+	}
+	// a session in progress has no battle
+	session.BattleID = int(battleID.Int64)
+
+	if err := json.Unmarshal(team1, &session.Team1); err != nil {
+		return models.BattleSession{}, err
+	}
+	if err := json.Unmarshal(team2, &session.Team2); err != nil {
+		return models.BattleSession{}, err
+	}
+	if err := json.Unmarshal(settings, &session.Settings); err != nil {
+		return models.BattleSession{}, err
+	}
+	if err := json.Unmarshal(participants, &session.Participants); err != nil {
+		return models.BattleSession{}, err
+	}
+	if err := json.Unmarshal(turns, &session.Turns); err != nil {
+		return models.BattleSession{}, err
+	}
+
+	// the pending actions are NULL until each side sends its action
+	if pending1 != nil {
+		if err := json.Unmarshal(pending1, &session.Pending1); err != nil {
+			return models.BattleSession{}, err
+		}
+	}
+	if pending2 != nil {
+		if err := json.Unmarshal(pending2, &session.Pending2); err != nil {
+			return models.BattleSession{}, err
+		}
+	}
+
+	return session, nil
+}
+
+// sessionData are the JSON columns of a battle session
+type sessionData struct {
+	team1, team2, settings, participants, turns []byte
+
+	// pending1 and pending2 are nil when the side has not sent its action
+	pending1, pending2 []byte
+}
+
+// marshalSessionData serializes the JSON columns of a battle session
+func marshalSessionData(session models.BattleSession) (sessionData, error) {
+	var data sessionData
+	var err error
+
+	if data.team1, err = json.Marshal(session.Team1); err != nil {
+		return sessionData{}, err
+	}
+	if data.team2, err = json.Marshal(session.Team2); err != nil {
+		return sessionData{}, err
+	}
+	if data.settings, err = json.Marshal(session.Settings); err != nil {
+		return sessionData{}, err
+	}
+	if data.participants, err = json.Marshal(session.Participants); err != nil {
+		return sessionData{}, err
+	}
+
+	if session.Turns == nil {
+		session.Turns = []models.SessionTurn{}
+	}
+	if data.turns, err = json.Marshal(session.Turns); err != nil {
+		return sessionData{}, err
+	}
+
+	if session.Pending1 != nil {
+		if data.pending1, err = json.Marshal(session.Pending1); err != nil {
+			return sessionData{}, err
+		}
+	}
+	if session.Pending2 != nil {
+		if data.pending2, err = json.Marshal(session.Pending2); err != nil {
+			return sessionData{}, err
+		}
+	}
+
+	return data, nil
+}
+
+// sessionBattleValue returns the value stored in the battle_id column,
+// which is NULL until the session is finished
+func sessionBattleValue(session models.BattleSession) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(session.BattleID), Valid: session.BattleID != 0}
+}
+
+// Create inserts a new battle session into the database
+func (s *battleSessionService) Create(ctx context.Context, session *models.BattleSession) error {
+	db := s.srv.MustDB()
+
+	if err := session.Validate(); err != nil {
+		return err
+	}
+
+	data, err := marshalSessionData(*session)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO battle_sessions (team1, team2, seed, settings, participants, turns, pending1, pending2, status, battle_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at`
+	return db.QueryRowContext(ctx, query, data.team1, data.team2, session.Seed, data.settings, data.participants, data.turns,
+		data.pending1, data.pending2, session.Status, sessionBattleValue(*session)).Scan(&session.ID, &session.CreatedAt)
+}
+
+// Delete deletes a battle session from the database. The battle of a finished session is kept.
+func (s *battleSessionService) Delete(ctx context.Context, id int) error {
+	db := s.srv.MustDB()
+
+	query := "DELETE FROM battle_sessions WHERE id=$1"
+	_, err := db.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetAll retrieves all the battle sessions from the database
+func (s *battleSessionService) GetAll(ctx context.Context) ([]models.BattleSession, error) {
+	db := s.srv.MustDB()
+
+	rows, err := db.QueryContext(ctx, "SELECT "+sessionColumns+" FROM battle_sessions ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.BattleSession{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetByID retrieves a battle session from the database
+func (s *battleSessionService) GetByID(ctx context.Context, id int) (models.BattleSession, error) {
+	db := s.srv.MustDB()
+
+	row := db.QueryRowContext(ctx, "SELECT "+sessionColumns+" FROM battle_sessions WHERE id=$1", id)
+	return scanSession(row)
+}
+
+// Update stores the turns, the pending actions, the status and the battle of a session
+func (s *battleSessionService) Update(ctx context.Context, session models.BattleSession) error {
+	if err := session.Validate(); err != nil {
+		return err
+	}
+	return updateSession(ctx, s.srv.MustDB(), session)
+}
+
+// Finish stores the battle of a finished session and the session with the ID of
+// its battle. Both are written in the same transaction, so a failed update
+// never leaves the battle stored while the session is still in progress.
+func (s *battleSessionService) Finish(ctx context.Context, session *models.BattleSession, battle *models.Battle) error {
+	db := s.srv.MustDB()
+
+	if s.battles == nil {
+		return errors.New("the battle session service cannot store battles")
+	}
+	if err := battle.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.battles.insert(ctx, tx, battle); err != nil {
+		return err
+	}
+
+	finished := *session
+	finished.BattleID = battle.ID
+	if err := finished.Validate(); err != nil {
+		return err
+	}
+	if err := updateSession(ctx, tx, finished); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	session.BattleID = battle.ID
+	return nil
+}
+
+// updateSession writes the columns of a validated session
+func updateSession(ctx context.Context, db execer, session models.BattleSession) error {
+	data, err := marshalSessionData(session)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE battle_sessions SET team1=$1, team2=$2, seed=$3, settings=$4, participants=$5, turns=$6,
+		pending1=$7, pending2=$8, status=$9, battle_id=$10 WHERE id=$11`
+	_, err = db.ExecContext(ctx, query, data.team1, data.team2, session.Seed, data.settings, data.participants, data.turns,
+		data.pending1, data.pending2, session.Status, sessionBattleValue(session), session.ID)
+	return err
+}
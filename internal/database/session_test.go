@@ -0,0 +1,140 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestNewBattleSessionService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewBattleSessionService(dbService)
+	if srv == nil {
+		t.Fatal("NewBattleSessionService() returned nil")
+	}
+
+	pokemonSrv := database.NewPokemonService(dbService)
+
+	// newSession prepares a session between the pokemon 1 and 2 of the test data
+	newSession := func(t *testing.T) models.BattleSession {
+		t.Helper()
+
+		pokemon1, err := pokemonSrv.GetByID(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		pokemon2, err := pokemonSrv.GetByID(context.Background(), 2)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+
+		battle := business.NewSession(6, []models.Pokemon{pokemon1}, []models.Pokemon{pokemon2}, business.WithSeed(42)).Battle()
+		return models.BattleSession{
+			Team1:        []int{1},
+			Team2:        []int{2},
+			Seed:         battle.Seed,
+			Settings:     battle.Settings,
+			Participants: battle.Participants,
+			Status:       models.SessionInProgress,
+		}
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		session := newSession(t)
+		if err := srv.Create(context.Background(), &session); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer srv.Delete(context.Background(), session.ID)
+
+		stored, err := srv.GetByID(context.Background(), session.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if stored.Seed != 42 || len(stored.Participants) != 2 || stored.Settings.Strategy1 != business.TrainerStrategy {
+			t.Fatalf("expected the stored session to be %+v, got %+v", session, stored)
+		}
+		if len(stored.Turns) != 0 || stored.Pending1 != nil || stored.Pending2 != nil || stored.BattleID != 0 {
+			t.Fatalf("expected a session without turns, got %+v", stored)
+		}
+
+		sessions, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(sessions) != 1 {
+			t.Fatalf("expected GetAll() to return 1 session, got %d", len(sessions))
+		}
+	})
+
+	t.Run("Create invalid", func(t *testing.T) {
+		session := newSession(t)
+		session.Team2 = []int{1}
+		if err := srv.Create(context.Background(), &session); err == nil {
+			t.Fatal("expected Create() to fail with a pokemon in both teams")
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		session := newSession(t)
+		if err := srv.Create(context.Background(), &session); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer srv.Delete(context.Background(), session.ID)
+
+		attack := models.BattleAction{Type: models.ActionAttack}
+		session.Turns = []models.SessionTurn{{Action1: attack, Action2: attack}}
+		session.Pending2 = &models.BattleAction{Type: models.ActionAttack, Move: 1}
+		if err := srv.Update(context.Background(), session); err != nil {
+			t.Fatalf("expected Update() to return nil, got %v", err)
+		}
+
+		stored, err := srv.GetByID(context.Background(), session.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if len(stored.Turns) != 1 || stored.Pending1 != nil || stored.Pending2 == nil || stored.Pending2.Move != 1 {
+			t.Fatalf("expected the turns and the pending actions to be stored, got %+v", stored)
+		}
+	})
+
+	t.Run("Finish", func(t *testing.T) {
+		battleSrv := database.NewBattleService(dbService)
+		srv := database.NewBattleSessionService(dbService).WithBattles(battleSrv)
+
+		session := newSession(t)
+		if err := srv.Create(context.Background(), &session); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer srv.Delete(context.Background(), session.ID)
+
+		pokemon1, _ := pokemonSrv.GetByID(context.Background(), 1)
+		pokemon2, _ := pokemonSrv.GetByID(context.Background(), 2)
+		battle := business.Fight(6, pokemon1, pokemon2, business.WithSeed(42))
+
+		session.Status = models.SessionFinished
+		if err := srv.Finish(context.Background(), &session, &battle); err != nil {
+			t.Fatalf("expected Finish() to return nil, got %v", err)
+		}
+		defer battleSrv.Delete(context.Background(), battle.ID)
+
+		stored, err := srv.GetByID(context.Background(), session.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if battle.ID == 0 || stored.BattleID != battle.ID || stored.Status != models.SessionFinished {
+			t.Fatalf("expected the session to be finished with the battle %d, got %+v", battle.ID, stored)
+		}
+	})
+
+	t.Run("Finish without battles", func(t *testing.T) {
+		session := newSession(t)
+		battle := models.Battle{}
+		if err := srv.Finish(context.Background(), &session, &battle); err == nil {
+			t.Fatal("expected Finish() to fail without a battle service")
+		}
+	})
+}
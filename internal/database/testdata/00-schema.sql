@@ -4,16 +4,200 @@ CREATE TABLE pokemons (
     type VARCHAR(50) NOT NULL,
     hp INT NOT NULL,
     attack INT NOT NULL,
-    defense INT NOT NULL
+    defense INT NOT NULL,
+    speed INT NOT NULL DEFAULT 0,
+    level INT NOT NULL DEFAULT 1,
+    experience INT NOT NULL DEFAULT 0,
+    ability VARCHAR(50) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE tournaments (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    format VARCHAR(30) NOT NULL,
+    rounds INT NOT NULL,
+    current_round INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    winner_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (winner_id) REFERENCES pokemons (id)
+);
+
+CREATE TABLE tournament_pokemons (
+    tournament_id INT NOT NULL,
+    seed INT NOT NULL,
+    pokemon_id INT NOT NULL,
+    PRIMARY KEY (tournament_id, seed),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id) ON DELETE CASCADE,
+    FOREIGN KEY (pokemon_id) REFERENCES pokemons (id)
 );
 
 CREATE TABLE battles (
     id SERIAL PRIMARY KEY,
     pokemon1_id INT NOT NULL,
     pokemon2_id INT NOT NULL,
-    winner_id INT NOT NULL,
+    winner_id INT,
     turns INT NOT NULL,
+    pokemon1_criticals INT NOT NULL DEFAULT 0,
+    pokemon2_criticals INT NOT NULL DEFAULT 0,
+    seed BIGINT NOT NULL DEFAULT 0,
+    format VARCHAR(20) NOT NULL DEFAULT 'singles',
+    tournament_id INT,
+    round INT NOT NULL DEFAULT 0,
+    settings JSONB NOT NULL DEFAULT '{}',
+    participants JSONB NOT NULL DEFAULT '[]',
+    item_uses JSONB NOT NULL DEFAULT '[]',
+    conditions JSONB NOT NULL DEFAULT '[]',
     FOREIGN KEY (pokemon1_id) REFERENCES pokemons (id),
     FOREIGN KEY (pokemon2_id) REFERENCES pokemons (id),
-    FOREIGN KEY (winner_id) REFERENCES pokemons (id)
+    FOREIGN KEY (winner_id) REFERENCES pokemons (id),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id) ON DELETE SET NULL
+);
+
+CREATE TABLE battle_events (
+    id SERIAL PRIMARY KEY,
+    battle_id INT NOT NULL,
+    seq INT NOT NULL,
+    turn INT NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    pokemon_id INT NOT NULL DEFAULT 0,
+    target_id INT NOT NULL DEFAULT 0,
+    data JSONB NOT NULL,
+    FOREIGN KEY (battle_id) REFERENCES battles (id) ON DELETE CASCADE
+);
+
+CREATE TABLE battle_teams (
+    battle_id INT NOT NULL,
+    side INT NOT NULL,
+    position INT NOT NULL,
+    pokemon_id INT NOT NULL,
+    PRIMARY KEY (battle_id, side, position),
+    FOREIGN KEY (battle_id) REFERENCES battles (id) ON DELETE CASCADE,
+    FOREIGN KEY (pokemon_id) REFERENCES pokemons (id)
+);
+
+CREATE TABLE moves (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    power INT NOT NULL,
+    accuracy INT NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    effect VARCHAR(20) NOT NULL DEFAULT '',
+    effect_chance INT NOT NULL DEFAULT 0,
+    target VARCHAR(20) NOT NULL DEFAULT '',
+    condition VARCHAR(20) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE pokemon_moves (
+    pokemon_id INT NOT NULL,
+    move_id INT NOT NULL,
+    PRIMARY KEY (pokemon_id, move_id),
+    FOREIGN KEY (pokemon_id) REFERENCES pokemons (id) ON DELETE CASCADE,
+    FOREIGN KEY (move_id) REFERENCES moves (id) ON DELETE CASCADE
+);
+
+CREATE TABLE matchups (
+    pokemon1_id INT NOT NULL,
+    pokemon2_id INT NOT NULL,
+    runs INT NOT NULL,
+    pokemon1_wins INT NOT NULL,
+    pokemon2_wins INT NOT NULL,
+    draws INT NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pokemon1_id, pokemon2_id),
+    FOREIGN KEY (pokemon1_id) REFERENCES pokemons (id) ON DELETE CASCADE,
+    FOREIGN KEY (pokemon2_id) REFERENCES pokemons (id) ON DELETE CASCADE
+);
+
+CREATE TABLE ratings (
+    pokemon_id INT PRIMARY KEY,
+    rating DOUBLE PRECISION NOT NULL,
+    deviation DOUBLE PRECISION NOT NULL,
+    volatility DOUBLE PRECISION NOT NULL,
+    battles INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pokemon_id) REFERENCES pokemons (id) ON DELETE CASCADE
+);
+
+CREATE TABLE rating_history (
+    battle_id INT NOT NULL,
+    pokemon_id INT NOT NULL,
+    rating DOUBLE PRECISION NOT NULL,
+    deviation DOUBLE PRECISION NOT NULL,
+    volatility DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (battle_id, pokemon_id),
+    FOREIGN KEY (battle_id) REFERENCES battles (id) ON DELETE CASCADE,
+    FOREIGN KEY (pokemon_id) REFERENCES pokemons (id) ON DELETE CASCADE
+);
+
+CREATE TABLE tournament_matches (
+    tournament_id INT NOT NULL,
+    round INT NOT NULL,
+    position INT NOT NULL,
+    pokemon1_id INT NOT NULL,
+    pokemon2_id INT NOT NULL DEFAULT 0,
+    battle_id INT,
+    winner_id INT NOT NULL DEFAULT 0,
+    played BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (tournament_id, round, position),
+    FOREIGN KEY (tournament_id) REFERENCES tournaments (id) ON DELETE CASCADE,
+    FOREIGN KEY (battle_id) REFERENCES battles (id) ON DELETE SET NULL
+);
+
+CREATE TABLE level_ups (
+    id SERIAL PRIMARY KEY,
+    pokemon_id INT NOT NULL,
+    battle_id INT,
+    level INT NOT NULL,
+    hp INT NOT NULL,
+    attack INT NOT NULL,
+    defense INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pokemon_id) REFERENCES pokemons (id) ON DELETE CASCADE,
+    FOREIGN KEY (battle_id) REFERENCES battles (id) ON DELETE SET NULL
+);
+
+CREATE TABLE evolutions (
+    id SERIAL PRIMARY KEY,
+    from_pokemon_id INT NOT NULL,
+    to_pokemon_id INT NOT NULL,
+    min_level INT NOT NULL DEFAULT 0,
+    min_wins INT NOT NULL DEFAULT 0,
+    UNIQUE (from_pokemon_id, to_pokemon_id),
+    FOREIGN KEY (from_pokemon_id) REFERENCES pokemons (id) ON DELETE CASCADE,
+    FOREIGN KEY (to_pokemon_id) REFERENCES pokemons (id) ON DELETE CASCADE
+);
+
+CREATE TABLE items (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    effect VARCHAR(30) NOT NULL,
+    value INT NOT NULL DEFAULT 0,
+    threshold INT NOT NULL DEFAULT 0,
+    consumable BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE held_items (
+    pokemon_id INT PRIMARY KEY,
+    item_id INT NOT NULL,
+    FOREIGN KEY (pokemon_id) REFERENCES pokemons (id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+);
+
+CREATE TABLE battle_sessions (
+    id SERIAL PRIMARY KEY,
+    team1 JSONB NOT NULL,
+    team2 JSONB NOT NULL,
+    seed BIGINT NOT NULL,
+    settings JSONB NOT NULL DEFAULT '{}',
+    participants JSONB NOT NULL DEFAULT '[]',
+    turns JSONB NOT NULL DEFAULT '[]',
+    pending1 JSONB,
+    pending2 JSONB,
+    status VARCHAR(20) NOT NULL DEFAULT 'in-progress',
+    battle_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (battle_id) REFERENCES battles (id) ON DELETE SET NULL
 );
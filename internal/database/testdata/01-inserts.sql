@@ -1,100 +1,145 @@
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Pikachu', 'Electric', 100, 55, 40);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Charmander', 'Fire', 90, 62, 58);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Bulbasaur', 'Grass/Poison', 100, 49, 49);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Squirtle', 'Water', 90, 48, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Jigglypuff', 'Normal/Fairy', 115, 45, 20);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Zapdos', 'Electric/Flying', 100, 80, 70);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Mewtwo', 'Psychic', 100, 110, 90);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Mew', 'Psychic', 100, 100, 100);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Venusaur', 'Grass/Poison', 110, 82, 83);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Charizard', 'Fire/Flying', 105, 84, 78);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Blastoise', 'Water', 105, 83, 100);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Butterfree', 'Bug/Flying', 85, 45, 50);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Pidgeot', 'Normal/Flying', 95, 80, 75);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Raichu', 'Electric', 90, 90, 55);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Sandslash', 'Ground', 95, 100, 110);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Nidoking', 'Poison/Ground', 105, 102, 77);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Clefable', 'Fairy', 105, 70, 73);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Ninetales', 'Fire', 95, 76, 75);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Wigglytuff', 'Normal/Fairy', 120, 70, 45);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Vileplume', 'Grass/Poison', 95, 80, 85);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Parasect', 'Bug/Grass', 85, 95, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Dugtrio', 'Ground', 75, 100, 50);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Persian', 'Normal', 85, 70, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Golduck', 'Water', 90, 82, 78);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Primeape', 'Fighting', 85, 105, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Arcanine', 'Fire', 110, 110, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Poliwrath', 'Water/Fighting', 100, 85, 95);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Alakazam', 'Psychic', 85, 135, 45);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Machamp', 'Fighting', 105, 130, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Victreebel', 'Grass/Poison', 90, 105, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Tentacruel', 'Water/Poison', 95, 70, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Golem', 'Rock/Ground', 95, 120, 130);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Rapidash', 'Fire', 85, 100, 70);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Slowbro', 'Water/Psychic', 105, 75, 110);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Magneton', 'Electric/Steel', 80, 60, 95);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Farfetchd', 'Normal/Flying', 75, 90, 55);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Dodrio', 'Normal/Flying', 85, 110, 70);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Dewgong', 'Water/Ice', 95, 70, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Muk', 'Poison', 105, 105, 75);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Cloyster', 'Water/Ice', 85, 95, 180);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Gengar', 'Ghost/Poison', 85, 110, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Onix', 'Rock/Ground', 75, 45, 160);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Hypno', 'Psychic', 95, 73, 70);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Kingler', 'Water', 85, 130, 115);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Electrode', 'Electric', 80, 50, 70);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Exeggutor', 'Grass/Psychic', 105, 95, 85);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Marowak', 'Ground', 85, 80, 110);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Hitmonlee', 'Fighting', 85, 120, 53);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Hitmonchan', 'Fighting', 85, 105, 79);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Lickitung', 'Normal', 110, 55, 75);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Weezing', 'Poison', 85, 90, 120);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Rhydon', 'Ground/Rock', 105, 130, 120);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Chansey', 'Normal', 250, 5, 5);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Tangela', 'Grass', 85, 55, 115);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Kangaskhan', 'Normal', 105, 95, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Seadra', 'Water', 85, 95, 95);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Seaking', 'Water', 90, 92, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Starmie', 'Water/Psychic', 85, 100, 85);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Mr. Mime', 'Psychic/Fairy', 75, 45, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Scyther', 'Bug/Flying', 90, 110, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Jynx', 'Ice/Psychic', 85, 50, 35);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Electabuzz', 'Electric', 85, 83, 57);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Magmar', 'Fire', 85, 95, 57);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Pinsir', 'Bug', 85, 125, 100);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Tauros', 'Normal', 95, 100, 95);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Gyarados', 'Water/Flying', 105, 125, 79);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Lapras', 'Water/Ice', 130, 85, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Ditto', 'Normal', 75, 48, 48);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Vaporeon', 'Water', 130, 65, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Jolteon', 'Electric', 85, 65, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Flareon', 'Fire', 85, 130, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Porygon', 'Normal', 85, 60, 70);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Omastar', 'Rock/Water', 90, 60, 125);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Kabutops', 'Rock/Water', 85, 115, 105);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Aerodactyl', 'Rock/Flying', 95, 105, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Snorlax', 'Normal', 160, 110, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Articuno', 'Ice/Flying', 100, 85, 100);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Moltres', 'Fire/Flying', 100, 100, 90);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Dragonite', 'Dragon/Flying', 110, 134, 95);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Typhlosion', 'Fire', 95, 109, 85);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Feraligatr', 'Water', 105, 105, 100);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Noctowl', 'Normal/Flying', 100, 50, 50);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Ampharos', 'Electric', 90, 75, 85);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Bellossom', 'Grass', 95, 80, 95);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Azumarill', 'Water/Fairy', 100, 50, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Sudowoodo', 'Rock', 90, 100, 115);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Politoed', 'Water', 90, 75, 75);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Espeon', 'Psychic', 85, 65, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Umbreon', 'Dark', 95, 65, 110);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Slowking', 'Water/Psychic', 95, 75, 80);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Wooper', 'Water/Ground', 85, 45, 45);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Quagsire', 'Water/Ground', 95, 85, 85);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Murkrow', 'Dark/Flying', 85, 85, 42);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Misdreavus', 'Ghost', 85, 60, 60);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Wobbuffet', 'Psychic', 190, 33, 58);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Girafarig', 'Normal/Psychic', 90, 80, 65);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Forretress', 'Bug/Steel', 95, 90, 140);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Dunsparce', 'Normal', 100, 70, 70);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Steelix', 'Steel/Ground', 95, 85, 200);
-INSERT INTO pokemons (name, type, hp, attack, defense) VALUES ('Granbull', 'Fairy', 90, 120, 75);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Pikachu', 'Electric', 100, 55, 40, 90);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Charmander', 'Fire', 90, 62, 58, 65);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Bulbasaur', 'Grass/Poison', 100, 49, 49, 45);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Squirtle', 'Water', 90, 48, 65, 43);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Jigglypuff', 'Normal/Fairy', 115, 45, 20, 20);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Zapdos', 'Electric/Flying', 100, 80, 70, 100);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Mewtwo', 'Psychic', 100, 110, 90, 130);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Mew', 'Psychic', 100, 100, 100, 100);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Venusaur', 'Grass/Poison', 110, 82, 83, 80);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Charizard', 'Fire/Flying', 105, 84, 78, 100);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Blastoise', 'Water', 105, 83, 100, 78);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Butterfree', 'Bug/Flying', 85, 45, 50, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Pidgeot', 'Normal/Flying', 95, 80, 75, 101);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Raichu', 'Electric', 90, 90, 55, 110);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Sandslash', 'Ground', 95, 100, 110, 65);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Nidoking', 'Poison/Ground', 105, 102, 77, 85);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Clefable', 'Fairy', 105, 70, 73, 60);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Ninetales', 'Fire', 95, 76, 75, 100);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Wigglytuff', 'Normal/Fairy', 120, 70, 45, 45);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Vileplume', 'Grass/Poison', 95, 80, 85, 50);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Parasect', 'Bug/Grass', 85, 95, 80, 30);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Dugtrio', 'Ground', 75, 100, 50, 120);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Persian', 'Normal', 85, 70, 60, 115);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Golduck', 'Water', 90, 82, 78, 85);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Primeape', 'Fighting', 85, 105, 60, 95);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Arcanine', 'Fire', 110, 110, 80, 95);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Poliwrath', 'Water/Fighting', 100, 85, 95, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Alakazam', 'Psychic', 85, 135, 45, 120);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Machamp', 'Fighting', 105, 130, 80, 55);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Victreebel', 'Grass/Poison', 90, 105, 65, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Tentacruel', 'Water/Poison', 95, 70, 65, 100);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Golem', 'Rock/Ground', 95, 120, 130, 45);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Rapidash', 'Fire', 85, 100, 70, 105);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Slowbro', 'Water/Psychic', 105, 75, 110, 30);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Magneton', 'Electric/Steel', 80, 60, 95, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Farfetchd', 'Normal/Flying', 75, 90, 55, 60);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Dodrio', 'Normal/Flying', 85, 110, 70, 110);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Dewgong', 'Water/Ice', 95, 70, 80, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Muk', 'Poison', 105, 105, 75, 50);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Cloyster', 'Water/Ice', 85, 95, 180, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Gengar', 'Ghost/Poison', 85, 110, 60, 110);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Onix', 'Rock/Ground', 75, 45, 160, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Hypno', 'Psychic', 95, 73, 70, 67);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Kingler', 'Water', 85, 130, 115, 75);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Electrode', 'Electric', 80, 50, 70, 150);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Exeggutor', 'Grass/Psychic', 105, 95, 85, 55);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Marowak', 'Ground', 85, 80, 110, 45);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Hitmonlee', 'Fighting', 85, 120, 53, 87);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Hitmonchan', 'Fighting', 85, 105, 79, 76);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Lickitung', 'Normal', 110, 55, 75, 30);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Weezing', 'Poison', 85, 90, 120, 60);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Rhydon', 'Ground/Rock', 105, 130, 120, 40);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Chansey', 'Normal', 250, 5, 5, 50);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Tangela', 'Grass', 85, 55, 115, 60);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Kangaskhan', 'Normal', 105, 95, 80, 90);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Seadra', 'Water', 85, 95, 95, 85);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Seaking', 'Water', 90, 92, 65, 68);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Starmie', 'Water/Psychic', 85, 100, 85, 115);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Mr. Mime', 'Psychic/Fairy', 75, 45, 65, 90);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Scyther', 'Bug/Flying', 90, 110, 80, 105);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Jynx', 'Ice/Psychic', 85, 50, 35, 95);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Electabuzz', 'Electric', 85, 83, 57, 105);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Magmar', 'Fire', 85, 95, 57, 93);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Pinsir', 'Bug', 85, 125, 100, 85);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Tauros', 'Normal', 95, 100, 95, 110);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Gyarados', 'Water/Flying', 105, 125, 79, 81);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Lapras', 'Water/Ice', 130, 85, 80, 60);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Ditto', 'Normal', 75, 48, 48, 48);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Vaporeon', 'Water', 130, 65, 60, 65);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Jolteon', 'Electric', 85, 65, 60, 130);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Flareon', 'Fire', 85, 130, 60, 65);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Porygon', 'Normal', 85, 60, 70, 40);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Omastar', 'Rock/Water', 90, 60, 125, 55);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Kabutops', 'Rock/Water', 85, 115, 105, 80);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Aerodactyl', 'Rock/Flying', 95, 105, 65, 130);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Snorlax', 'Normal', 160, 110, 65, 30);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Articuno', 'Ice/Flying', 100, 85, 100, 85);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Moltres', 'Fire/Flying', 100, 100, 90, 90);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Dragonite', 'Dragon/Flying', 110, 134, 95, 80);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Typhlosion', 'Fire', 95, 109, 85, 100);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Feraligatr', 'Water', 105, 105, 100, 78);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Noctowl', 'Normal/Flying', 100, 50, 50, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Ampharos', 'Electric', 90, 75, 85, 55);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Bellossom', 'Grass', 95, 80, 95, 50);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Azumarill', 'Water/Fairy', 100, 50, 80, 50);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Sudowoodo', 'Rock', 90, 100, 115, 30);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Politoed', 'Water', 90, 75, 75, 70);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Espeon', 'Psychic', 85, 65, 60, 110);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Umbreon', 'Dark', 95, 65, 110, 65);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Slowking', 'Water/Psychic', 95, 75, 80, 30);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Wooper', 'Water/Ground', 85, 45, 45, 15);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Quagsire', 'Water/Ground', 95, 85, 85, 35);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Murkrow', 'Dark/Flying', 85, 85, 42, 91);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Misdreavus', 'Ghost', 85, 60, 60, 85);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Wobbuffet', 'Psychic', 190, 33, 58, 33);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Girafarig', 'Normal/Psychic', 90, 80, 65, 85);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Forretress', 'Bug/Steel', 95, 90, 140, 40);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Dunsparce', 'Normal', 100, 70, 70, 45);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Steelix', 'Steel/Ground', 95, 85, 200, 30);
+INSERT INTO pokemons (name, type, hp, attack, defense, speed) VALUES ('Granbull', 'Fairy', 90, 120, 75, 45);
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Tackle', 'Normal', 40, 100, 'physical');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Quick Attack', 'Normal', 40, 100, 'physical');
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Body Slam', 'Normal', 85, 100, 'physical', 'paralysis', 30);
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Hyper Beam', 'Normal', 150, 90, 'special');
+INSERT INTO moves (name, type, power, accuracy, category, target) VALUES ('Growl', 'Normal', 0, 100, 'status', 'all-foes');
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Ember', 'Fire', 40, 100, 'special', 'burn', 10);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Flamethrower', 'Fire', 90, 100, 'special', 'burn', 10);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Fire Blast', 'Fire', 110, 85, 'special', 'burn', 10);
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Water Gun', 'Water', 40, 100, 'special');
+INSERT INTO moves (name, type, power, accuracy, category, target) VALUES ('Surf', 'Water', 90, 100, 'special', 'all-foes');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Hydro Pump', 'Water', 110, 80, 'special');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Vine Whip', 'Grass', 45, 100, 'physical');
+INSERT INTO moves (name, type, power, accuracy, category, target) VALUES ('Razor Leaf', 'Grass', 55, 95, 'physical', 'all-foes');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Solar Beam', 'Grass', 120, 100, 'special');
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Thunder Shock', 'Electric', 40, 100, 'special', 'paralysis', 10);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Thunderbolt', 'Electric', 90, 100, 'special', 'paralysis', 10);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Thunder', 'Electric', 110, 70, 'special', 'paralysis', 30);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Ice Beam', 'Ice', 90, 100, 'special', 'freeze', 10);
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Karate Chop', 'Fighting', 50, 100, 'physical');
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Sludge Bomb', 'Poison', 90, 100, 'special', 'poison', 30);
+INSERT INTO moves (name, type, power, accuracy, category, target) VALUES ('Earthquake', 'Ground', 100, 100, 'physical', 'all-foes');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Wing Attack', 'Flying', 60, 100, 'physical');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Psychic', 'Psychic', 90, 100, 'special');
+INSERT INTO moves (name, type, power, accuracy, category, target) VALUES ('Rock Slide', 'Rock', 75, 90, 'physical', 'all-foes');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Shadow Ball', 'Ghost', 80, 100, 'special');
+INSERT INTO moves (name, type, power, accuracy, category) VALUES ('Dragon Claw', 'Dragon', 80, 100, 'physical');
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Thunder Wave', 'Electric', 0, 90, 'status', 'paralysis', 100);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Hypnosis', 'Psychic', 0, 60, 'status', 'sleep', 100);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Toxic', 'Poison', 0, 90, 'status', 'poison', 100);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Will-O-Wisp', 'Fire', 0, 85, 'status', 'burn', 100);
+INSERT INTO moves (name, type, power, accuracy, category, effect, effect_chance) VALUES ('Sleep Powder', 'Grass', 0, 75, 'status', 'sleep', 100);
+INSERT INTO moves (name, type, power, accuracy, category, condition) VALUES ('Rain Dance', 'Water', 0, 100, 'status', 'rain');
+INSERT INTO moves (name, type, power, accuracy, category, condition) VALUES ('Sunny Day', 'Fire', 0, 100, 'status', 'sun');
+INSERT INTO moves (name, type, power, accuracy, category, condition) VALUES ('Sandstorm', 'Rock', 0, 100, 'status', 'sandstorm');
+INSERT INTO moves (name, type, power, accuracy, category, condition) VALUES ('Hail', 'Ice', 0, 100, 'status', 'hail');
+INSERT INTO pokemon_moves (pokemon_id, move_id) VALUES (1, 2), (1, 15), (1, 16), (1, 5);
+INSERT INTO pokemon_moves (pokemon_id, move_id) VALUES (2, 1), (2, 6), (2, 7), (2, 5);
+INSERT INTO pokemon_moves (pokemon_id, move_id) VALUES (3, 1), (3, 12), (3, 13), (3, 31);
+INSERT INTO pokemon_moves (pokemon_id, move_id) VALUES (4, 1), (4, 9), (4, 10), (4, 5);
+INSERT INTO evolutions (from_pokemon_id, to_pokemon_id, min_level, min_wins) VALUES (1, 14, 0, 5), (2, 10, 36, 0), (3, 9, 32, 0), (4, 11, 36, 0), (5, 19, 0, 3);
+INSERT INTO items (name, effect, value, threshold, consumable) VALUES ('Potion', 'heal', 20, 50, TRUE);
+INSERT INTO items (name, effect, value, threshold, consumable) VALUES ('Super Potion', 'heal', 50, 50, TRUE);
+INSERT INTO items (name, effect, value, threshold, consumable) VALUES ('Leftovers', 'regen', 6, 0, FALSE);
+INSERT INTO items (name, effect, value, threshold, consumable) VALUES ('Choice Band', 'attack-boost', 50, 0, FALSE);
+INSERT INTO items (name, effect, value, threshold, consumable) VALUES ('Lum Berry', 'cure', 0, 0, TRUE);
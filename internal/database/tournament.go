@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pokemon-battle/internal/models"
+)
+
+type tournamentService struct {
+	// TournamentCRUDService is a generic CRUD service implemented by the service
+	TournamentCRUDService
+
+	// srv is the service with the actual database connection
+	srv Service
+}
+
+func NewTournamentService(srv Service) *tournamentService {
+	return &tournamentService{
+		srv: srv,
+	}
+}
+
+// tournamentColumns are the columns of the tournaments table, in the order used by scanTournament
+const tournamentColumns = "id, name, format, rounds, current_round, status, winner_id, created_at"
+
+// scanTournament reads a tournament from a row with the tournamentColumns
+func scanTournament(row rowScanner) (models.Tournament, error) {
+	var tournament models.Tournament
+	var winnerID sql.NullInt64
+	if err := row.Scan(&tournament.ID, &tournament.Name, &tournament.Format, &tournament.Rounds, &tournament.CurrentRound, &tournament.Status, &winnerID, &tournament.CreatedAt); err != nil {
+		return models.Tournament{}, err
+	}
+	// a tournament in progress has no winner
+	tournament.WinnerID = int(winnerID.Int64)
+	return tournament, nil
+}
+
+// tournamentWinnerValue returns the value stored in the winner_id column,
+// which is NULL until the tournament is finished
+func tournamentWinnerValue(tournament models.Tournament) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(tournament.WinnerID), Valid: tournament.WinnerID != 0}
+}
+
+// Create inserts a new tournament into the database, together with its pokemon
+// and its paired matches. All are inserted in the same transaction.
+func (s *tournamentService) Create(ctx context.Context, tournament *models.Tournament) error {
+	db := s.srv.MustDB()
+
+	if err := tournament.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO tournaments (name, format, rounds, current_round, status, winner_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at"
+	err = tx.QueryRowContext(ctx, query, tournament.Name, tournament.Format, tournament.Rounds, tournament.CurrentRound, tournament.Status, tournamentWinnerValue(*tournament)).Scan(&tournament.ID, &tournament.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	pokemonQuery := "INSERT INTO tournament_pokemons (tournament_id, seed, pokemon_id) VALUES ($1, $2, $3)"
+	for seed, pokemonID := range tournament.PokemonIDs {
+		if _, err := tx.ExecContext(ctx, pokemonQuery, tournament.ID, seed, pokemonID); err != nil {
+			return err
+		}
+	}
+
+	if err := insertMatches(ctx, tx, *tournament); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertMatches inserts the matches of a tournament
+func insertMatches(ctx context.Context, tx *sql.Tx, tournament models.Tournament) error {
+	query := "INSERT INTO tournament_matches (tournament_id, round, position, pokemon1_id, pokemon2_id, battle_id, winner_id, played) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	for _, match := range tournament.Matches {
+		// a match that was not fought has no battle
+		battleID := sql.NullInt64{Int64: int64(match.BattleID), Valid: match.BattleID != 0}
+
+		_, err := tx.ExecContext(ctx, query, tournament.ID, match.Round, match.Position, match.Pokemon1ID, match.Pokemon2ID, battleID, match.WinnerID, match.Played)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete deletes a tournament from the database. Its battles are kept, without the tournament.
+func (s *tournamentService) Delete(ctx context.Context, id int) error {
+	db := s.srv.MustDB()
+
+	query := "DELETE FROM tournaments WHERE id=$1"
+	_, err := db.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetAll retrieves all the tournaments from the database, without their matches
+func (s *tournamentService) GetAll(ctx context.Context) ([]models.Tournament, error) {
+	db := s.srv.MustDB()
+
+	rows, err := db.QueryContext(ctx, "SELECT "+tournamentColumns+" FROM tournaments ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournaments := []models.Tournament{}
+	for rows.Next() {
+		tournament, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range tournaments {
+		tournaments[i].PokemonIDs, err = getTournamentPokemons(ctx, db, tournaments[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tournaments, nil
+}
+
+// GetByID retrieves a tournament from the database, with its pokemon and its matches
+func (s *tournamentService) GetByID(ctx context.Context, id int) (models.Tournament, error) {
+	db := s.srv.MustDB()
+
+	row := db.QueryRowContext(ctx, "SELECT "+tournamentColumns+" FROM tournaments WHERE id=$1", id)
+	tournament, err := scanTournament(row)
+	if err != nil {
+		return models.Tournament{}, err
+	}
+
+	tournament.PokemonIDs, err = getTournamentPokemons(ctx, db, id)
+	if err != nil {
+		return models.Tournament{}, err
+	}
+
+	tournament.Matches, err = getTournamentMatches(ctx, db, id)
+	if err != nil {
+		return models.Tournament{}, err
+	}
+
+	return tournament, nil
+}
+
+// getTournamentPokemons reads the pokemon of a tournament, ordered by seed
+func getTournamentPokemons(ctx context.Context, db queryer, tournamentID int) ([]int, error) {
+	query := "SELECT pokemon_id FROM tournament_pokemons WHERE tournament_id=$1 ORDER BY seed"
+	rows, err := db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// getTournamentMatches reads the matches of a tournament, round by round
+func getTournamentMatches(ctx context.Context, db queryer, tournamentID int) ([]models.TournamentMatch, error) {
+	query := "SELECT round, position, pokemon1_id, pokemon2_id, battle_id, winner_id, played FROM tournament_matches WHERE tournament_id=$1 ORDER BY round, position"
+	rows, err := db.QueryContext(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := []models.TournamentMatch{}
+	for rows.Next() {
+		var match models.TournamentMatch
+		var battleID sql.NullInt64
+		if err := rows.Scan(&match.Round, &match.Position, &match.Pokemon1ID, &match.Pokemon2ID, &battleID, &match.WinnerID, &match.Played); err != nil {
+			return nil, err
+		}
+		match.BattleID = int(battleID.Int64)
+		matches = append(matches, match)
+	}
+
+	return matches, rows.Err()
+}
+
+// Update updates the state of a tournament and replaces its matches,
+// in the same transaction. The name, format and pokemon cannot change.
+func (s *tournamentService) Update(ctx context.Context, tournament models.Tournament) error {
+	db := s.srv.MustDB()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE tournaments SET rounds=$1, current_round=$2, status=$3, winner_id=$4 WHERE id=$5"
+	_, err = tx.ExecContext(ctx, query, tournament.Rounds, tournament.CurrentRound, tournament.Status, tournamentWinnerValue(tournament), tournament.ID)
+	if err != nil {
+		return err
+	}
+
+	// the matches are replaced as a whole
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tournament_matches WHERE tournament_id=$1", tournament.ID); err != nil {
+		return err
+	}
+	if err := insertMatches(ctx, tx, tournament); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,122 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestNewTournamentService(t *testing.T) {
+	dbService := database.MustNewWithDatabase(t)
+
+	srv := database.NewTournamentService(dbService)
+	if srv == nil {
+		t.Fatal("NewTournamentService() returned nil")
+	}
+
+	battleSrv := database.NewBattleService(dbService)
+	pokemonSrv := database.NewPokemonService(dbService)
+
+	t.Run("Create", func(t *testing.T) {
+		tournament, err := business.NewTournament("Copa Kanto", models.TournamentSingleElimination, []int{1, 2, 3, 4}, 0)
+		if err != nil {
+			t.Fatalf("expected NewTournament() to return nil, got %v", err)
+		}
+
+		if err := srv.Create(context.Background(), &tournament); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+		defer srv.Delete(context.Background(), tournament.ID)
+
+		stored, err := srv.GetByID(context.Background(), tournament.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if stored.Name != "Copa Kanto" || len(stored.PokemonIDs) != 4 || stored.PokemonIDs[0] != 1 {
+			t.Fatalf("expected the stored tournament to be %+v, got %+v", tournament, stored)
+		}
+		if len(stored.Matches) != 2 || stored.Matches[0].Pokemon1ID != 1 || stored.Matches[0].Pokemon2ID != 4 {
+			t.Fatalf("expected the first seed against the fourth, got %+v", stored.Matches)
+		}
+
+		tournaments, err := srv.GetAll(context.Background())
+		if err != nil {
+			t.Fatalf("expected GetAll() to return nil, got %v", err)
+		}
+		if len(tournaments) != 1 {
+			t.Fatalf("expected GetAll() to return 1 tournament, got %d", len(tournaments))
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		tournament, err := business.NewTournament("Liga Johto", models.TournamentRoundRobin, []int{1, 2, 3}, 0)
+		if err != nil {
+			t.Fatalf("expected NewTournament() to return nil, got %v", err)
+		}
+		if err := srv.Create(context.Background(), &tournament); err != nil {
+			t.Fatalf("expected Create() to return nil, got %v", err)
+		}
+
+		pokemons := map[int]models.Pokemon{}
+		for _, id := range tournament.PokemonIDs {
+			pokemon, err := pokemonSrv.GetByID(context.Background(), id)
+			if err != nil {
+				t.Fatalf("expected GetByID() to return nil, got %v", err)
+			}
+			pokemons[id] = pokemon
+		}
+
+		record := func(battle *models.Battle) error {
+			return battleSrv.Create(context.Background(), battle)
+		}
+		if err := business.PlayRound(&tournament, pokemons, record, 6); err != nil {
+			t.Fatalf("expected PlayRound() to return nil, got %v", err)
+		}
+		if err := srv.Update(context.Background(), tournament); err != nil {
+			t.Fatalf("expected Update() to return nil, got %v", err)
+		}
+
+		stored, err := srv.GetByID(context.Background(), tournament.ID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if stored.CurrentRound != 2 || len(stored.Matches) != 4 {
+			t.Fatalf("expected the second round to be paired, got %+v", stored)
+		}
+
+		var battleID int
+		for _, match := range stored.Matches {
+			if match.Round == 1 && !match.IsBye() {
+				battleID = match.BattleID
+			}
+		}
+		battle, err := battleSrv.GetByID(context.Background(), battleID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if battle.TournamentID != tournament.ID || battle.Round != 1 {
+			t.Fatalf("expected the battle to be linked to the tournament, got %+v", battle)
+		}
+
+		// the battles are kept without the tournament
+		if err := srv.Delete(context.Background(), tournament.ID); err != nil {
+			t.Fatalf("expected Delete() to return nil, got %v", err)
+		}
+		if _, err := srv.GetByID(context.Background(), tournament.ID); err != sql.ErrNoRows {
+			t.Fatalf("expected GetByID() to return sql.ErrNoRows, got %v", err)
+		}
+
+		battle, err = battleSrv.GetByID(context.Background(), battleID)
+		if err != nil {
+			t.Fatalf("expected GetByID() to return nil, got %v", err)
+		}
+		if battle.TournamentID != 0 {
+			t.Fatalf("expected the battle to lose its tournament, got %d", battle.TournamentID)
+		}
+		cleanupBattle(t, battleSrv, battleID)
+	})
+}
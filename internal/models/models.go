@@ -1,14 +1,27 @@
 package models
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
 
 type Pokemon struct {
-	ID      int    `json:"id"`      // Identificador único del Pokémon
-	Name    string `json:"name"`    // Nombre del Pokémon
-	Type    string `json:"type"`    // Tipo del Pokémon (e.g., "Fuego", "Agua")
-	HP      int    `json:"hp"`      // Puntos de salud
-	Attack  int    `json:"attack"`  // Nivel de ataque
-	Defense int    `json:"defense"` // Nivel de defensa
+	ID      int    `json:"id"`              // Identificador único del Pokémon
+	Name    string `json:"name"`            // Nombre del Pokémon
+	Type    string `json:"type"`            // Tipo del Pokémon (e.g., "Fuego", "Agua")
+	HP      int    `json:"hp"`              // Puntos de salud
+	Attack  int    `json:"attack"`          // Nivel de ataque
+	Defense int    `json:"defense"`         // Nivel de defensa
+	Speed   int    `json:"speed"`           // Velocidad, decide quién ataca primero
+	Moves   []Move `json:"moves,omitempty"` // Movimientos aprendidos, como máximo MaxMoves
+
+	Level      int `json:"level"`      // Nivel, entre 1 y MaxLevel; 0 se considera nivel 1
+	Experience int `json:"experience"` // Puntos de experiencia acumulados
+
+	Ability string `json:"ability,omitempty"` // Nombre de la habilidad del Pokémon (e.g., "levitate"), vacío si no tiene
+	Item    *Item  `json:"item,omitempty"`    // Objeto que lleva el Pokémon al entrar en batalla, nil si no lleva ninguno
 }
 
 func (p *Pokemon) Validate() error {
@@ -27,15 +40,271 @@ func (p *Pokemon) Validate() error {
 	if p.Defense < 0 {
 		return errors.New("pokemon defense cannot be negative")
 	}
+	if p.Speed < 0 {
+		return errors.New("pokemon speed cannot be negative")
+	}
+	if len(p.Moves) > MaxMoves {
+		return fmt.Errorf("pokemon cannot learn more than %d moves", MaxMoves)
+	}
+	if p.Level < 0 || p.Level > MaxLevel {
+		return fmt.Errorf("pokemon level must be between 1 and %d", MaxLevel)
+	}
+	if p.Experience < 0 {
+		return errors.New("pokemon experience cannot be negative")
+	}
+	return nil
+}
+
+// MaxLevel es el nivel máximo de un Pokémon.
+const MaxLevel = 100
+
+// LevelUp es una subida de nivel de un Pokémon tras ganar una batalla,
+// y forma parte de su historial de niveles.
+type LevelUp struct {
+	PokemonID int       `json:"pokemon_id"` // ID del Pokémon
+	BattleID  int       `json:"battle_id"`  // ID de la batalla que dio la experiencia
+	Level     int       `json:"level"`      // Nuevo nivel
+	HP        int       `json:"hp"`         // Puntos de salud en el nuevo nivel
+	Attack    int       `json:"attack"`     // Ataque en el nuevo nivel
+	Defense   int       `json:"defense"`    // Defensa en el nuevo nivel
+	CreatedAt time.Time `json:"created_at"` // Momento de la subida de nivel
+}
+
+// Evolution es una relación de evolución entre dos entradas de Pokémon, como
+// Charmander → Charmeleon. Los Pokémon con el nombre de la entrada de origen
+// evolucionan a la especie de la entrada de destino al cumplir la condición:
+// un nivel mínimo, un número mínimo de victorias o ambos.
+type Evolution struct {
+	ID            int `json:"id"`              // Identificador único de la evolución
+	FromPokemonID int `json:"from_pokemon_id"` // ID de la entrada de la especie que evoluciona
+	ToPokemonID   int `json:"to_pokemon_id"`   // ID de la entrada de la especie evolucionada
+	MinLevel      int `json:"min_level"`       // Nivel mínimo para evolucionar, 0 si no se exige
+	MinWins       int `json:"min_wins"`        // Victorias mínimas para evolucionar, 0 si no se exigen
+}
+
+func (e *Evolution) Validate() error {
+	if e.FromPokemonID <= 0 || e.ToPokemonID <= 0 {
+		return errors.New("evolution pokemon IDs must be greater than 0")
+	}
+	if e.FromPokemonID == e.ToPokemonID {
+		return errors.New("a pokemon cannot evolve into itself")
+	}
+	if e.MinLevel < 0 || e.MinLevel > MaxLevel {
+		return fmt.Errorf("evolution min level must be between 0 and %d", MaxLevel)
+	}
+	if e.MinWins < 0 {
+		return errors.New("evolution min wins cannot be negative")
+	}
+	if e.MinLevel == 0 && e.MinWins == 0 {
+		return errors.New("evolution needs a min level or a number of wins")
+	}
+	return nil
+}
+
+// MaxMoves es el número máximo de movimientos que puede aprender un Pokémon.
+const MaxMoves = 4
+
+// Categorías de los movimientos.
+const (
+	MoveCategoryPhysical = "physical" // Movimiento de daño físico
+	MoveCategorySpecial  = "special"  // Movimiento de daño especial
+	MoveCategoryStatus   = "status"   // Movimiento sin daño
+)
+
+// Objetivos de los movimientos en las batallas dobles.
+const (
+	MoveTargetSingle  = "single"   // Uno de los Pokémon rivales, el objetivo por defecto
+	MoveTargetAllFoes = "all-foes" // Los dos Pokémon rivales, con menos daño a cada uno
+	MoveTargetAlly    = "ally"     // El Pokémon aliado
+)
+
+// Problemas de estado persistentes que puede sufrir un Pokémon.
+const (
+	StatusPoison    = "poison"    // Pierde HP al final de cada turno
+	StatusBurn      = "burn"      // Pierde HP al final de cada turno
+	StatusParalysis = "paralysis" // Puede perder el turno
+	StatusSleep     = "sleep"     // No puede atacar durante varios turnos
+	StatusFreeze    = "freeze"    // No puede atacar hasta que se descongela
+)
+
+// IsValidStatus indica si el estado es uno de los problemas de estado conocidos.
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusPoison, StatusBurn, StatusParalysis, StatusSleep, StatusFreeze:
+		return true
+	}
+	return false
+}
+
+// Condiciones del campo de batalla, que afectan a todos los Pokémon que combaten.
+const (
+	ConditionRain            = "rain"             // Potencia los movimientos de agua y debilita los de fuego
+	ConditionSun             = "sun"              // Potencia los movimientos de fuego y debilita los de agua
+	ConditionSandstorm       = "sandstorm"        // Daña al final de cada turno a los Pokémon que no son de roca, tierra o acero
+	ConditionHail            = "hail"             // Daña al final de cada turno a los Pokémon que no son de hielo
+	ConditionElectricTerrain = "electric-terrain" // Potencia los movimientos eléctricos
+	ConditionGrassyTerrain   = "grassy-terrain"   // Potencia los movimientos de planta y cura a los Pokémon al final de cada turno
+)
+
+// IsValidCondition indica si la condición es una de las condiciones del campo conocidas.
+func IsValidCondition(condition string) bool {
+	switch condition {
+	case ConditionRain, ConditionSun, ConditionSandstorm, ConditionHail, ConditionElectricTerrain, ConditionGrassyTerrain:
+		return true
+	}
+	return false
+}
+
+type Move struct {
+	ID           int    `json:"id"`                      // Identificador único del movimiento
+	Name         string `json:"name"`                    // Nombre del movimiento
+	Type         string `json:"type"`                    // Tipo del movimiento (e.g., "Fire", "Water")
+	Power        int    `json:"power"`                   // Potencia del movimiento, 0 para los movimientos de estado
+	Accuracy     int    `json:"accuracy"`                // Probabilidad de acertar, entre 1 y 100
+	Category     string `json:"category"`                // Categoría: physical, special o status
+	Effect       string `json:"effect,omitempty"`        // Problema de estado que puede causar el movimiento
+	EffectChance int    `json:"effect_chance,omitempty"` // Probabilidad de causar el problema de estado, entre 1 y 100
+	Target       string `json:"target,omitempty"`        // Objetivo en las batallas dobles: single, all-foes o ally; single si está vacío
+	Condition    string `json:"condition,omitempty"`     // Condición del campo que establece el movimiento, solo en los movimientos de estado
+}
+
+func (m *Move) Validate() error {
+	if m.Name == "" {
+		return errors.New("move name cannot be empty")
+	}
+	if m.Type == "" {
+		return errors.New("move type cannot be empty")
+	}
+	if m.Power < 0 {
+		return errors.New("move power cannot be negative")
+	}
+	if m.Accuracy < 1 || m.Accuracy > 100 {
+		return errors.New("move accuracy must be between 1 and 100")
+	}
+	switch m.Category {
+	case MoveCategoryPhysical, MoveCategorySpecial:
+		if m.Power == 0 {
+			return errors.New("damaging moves must have power")
+		}
+	case MoveCategoryStatus:
+		if m.Power != 0 {
+			return errors.New("status moves cannot have power")
+		}
+	default:
+		return errors.New("move category must be physical, special or status")
+	}
+	if m.Effect != "" {
+		if !IsValidStatus(m.Effect) {
+			return errors.New("move effect must be poison, burn, paralysis, sleep or freeze")
+		}
+		if m.EffectChance < 1 || m.EffectChance > 100 {
+			return errors.New("move effect chance must be between 1 and 100")
+		}
+	} else if m.EffectChance != 0 {
+		return errors.New("move effect chance requires an effect")
+	}
+	switch m.Target {
+	case "", MoveTargetSingle, MoveTargetAllFoes, MoveTargetAlly:
+	default:
+		return errors.New("move target must be single, all-foes or ally")
+	}
+	if m.Condition != "" {
+		if !IsValidCondition(m.Condition) {
+			return errors.New("move condition must be rain, sun, sandstorm, hail, electric-terrain or grassy-terrain")
+		}
+		if m.Category != MoveCategoryStatus {
+			return errors.New("only status moves can set a condition")
+		}
+	}
+	return nil
+}
+
+// Efectos de los objetos que pueden llevar los Pokémon en batalla.
+const (
+	ItemEffectHeal        = "heal"         // Recupera Value HP cuando el HP baja del Threshold% del HP máximo
+	ItemEffectRegen       = "regen"        // Recupera el Value% del HP máximo al final de cada turno
+	ItemEffectAttackBoost = "attack-boost" // Sube el ataque un Value% en cada ataque
+	ItemEffectCure        = "cure"         // Cura el problema de estado al final del turno
+)
+
+// IsValidItemEffect indica si el efecto es uno de los efectos de objeto conocidos.
+func IsValidItemEffect(effect string) bool {
+	switch effect {
+	case ItemEffectHeal, ItemEffectRegen, ItemEffectAttackBoost, ItemEffectCure:
+		return true
+	}
+	return false
+}
+
+// Item es un objeto del catálogo, que un Pokémon puede llevar en las batallas.
+type Item struct {
+	ID         int    `json:"id"`                  // Identificador único del objeto
+	Name       string `json:"name"`                // Nombre del objeto (e.g., "Potion", "Leftovers")
+	Effect     string `json:"effect"`              // Efecto: heal, regen, attack-boost o cure
+	Value      int    `json:"value,omitempty"`     // Magnitud del efecto: HP recuperado o porcentaje, según el efecto
+	Threshold  int    `json:"threshold,omitempty"` // Porcentaje del HP máximo por debajo del que se activa el efecto heal
+	Consumable bool   `json:"consumable"`          // Si el objeto se gasta la primera vez que se usa
+}
+
+func (i *Item) Validate() error {
+	if i.Name == "" {
+		return errors.New("item name cannot be empty")
+	}
+	if !IsValidItemEffect(i.Effect) {
+		return errors.New("item effect must be heal, regen, attack-boost or cure")
+	}
+	if i.Effect != ItemEffectCure && i.Value <= 0 {
+		return errors.New("item value must be greater than 0")
+	}
+	if i.Effect == ItemEffectHeal && (i.Threshold < 1 || i.Threshold > 100) {
+		return errors.New("heal item threshold must be between 1 and 100")
+	}
 	return nil
 }
 
+// ItemUse resume el uso del objeto de un Pokémon en una batalla.
+type ItemUse struct {
+	PokemonID int    `json:"pokemon_id"`       // ID del Pokémon que lleva el objeto
+	ItemID    int    `json:"item_id"`          // ID del objeto
+	Item      string `json:"item"`             // Nombre del objeto
+	Uses      int    `json:"uses"`             // Veces que se ha activado el objeto
+	Healed    int    `json:"healed,omitempty"` // HP recuperado con el objeto
+	Consumed  bool   `json:"consumed"`         // Si el objeto se ha gastado
+}
+
 type Battle struct {
-	ID         int `json:"id"`          // Identificador único de la batalla
-	Pokemon1ID int `json:"pokemon1_id"` // ID del primer Pokémon participante
-	Pokemon2ID int `json:"pokemon2_id"` // ID del segundo Pokémon participante
-	Turns      int `json:"turns"`       // Number of turns the battle lasted
-	WinnerID   int `json:"winner_id"`   // ID del Pokémon ganador
+	ID                int              `json:"id"`                      // Identificador único de la batalla
+	Pokemon1ID        int              `json:"pokemon1_id"`             // ID del primer Pokémon participante, o del primero del primer equipo
+	Pokemon2ID        int              `json:"pokemon2_id"`             // ID del segundo Pokémon participante, o del primero del segundo equipo
+	Turns             int              `json:"turns"`                   // Number of turns the battle lasted
+	WinnerID          int              `json:"winner_id"`               // ID del Pokémon ganador, 0 si la batalla termina en empate
+	Pokemon1Criticals int              `json:"pokemon1_criticals"`      // Golpes críticos causados por el primer Pokémon o equipo
+	Pokemon2Criticals int              `json:"pokemon2_criticals"`      // Golpes críticos causados por el segundo Pokémon o equipo
+	Seed              int64            `json:"seed"`                    // Semilla de los dados, para reproducir la batalla
+	Format            string           `json:"format"`                  // Formato de la batalla: singles o doubles
+	TournamentID      int              `json:"tournament_id,omitempty"` // ID del torneo de la batalla, 0 si no forma parte de ningún torneo
+	Round             int              `json:"round,omitempty"`         // Ronda del torneo en la que se libró la batalla
+	Settings          BattleSettings   `json:"settings"`                // Configuración de los dados de la batalla
+	Team1             []int            `json:"team1,omitempty"`         // IDs del primer equipo, en orden de salida, en las batallas por equipos
+	Team2             []int            `json:"team2,omitempty"`         // IDs del segundo equipo, en orden de salida, en las batallas por equipos
+	Participants      []Pokemon        `json:"participants,omitempty"`  // Estadísticas de los participantes al empezar la batalla, el primer equipo antes que el segundo
+	ItemUses          []ItemUse        `json:"item_uses,omitempty"`     // Uso de los objetos de los participantes durante la batalla
+	Conditions        []FieldCondition `json:"conditions,omitempty"`    // Condiciones del campo que ha habido durante la batalla, en orden
+	Log               []BattleEvent    `json:"log,omitempty"`           // Registro turno a turno de la batalla
+}
+
+// MaxTeamSize es el número máximo de Pokémon de un equipo.
+const MaxTeamSize = 6
+
+// Formatos de batalla.
+const (
+	FormatSingles = "singles" // Un Pokémon de cada bando combate a la vez
+	FormatDoubles = "doubles" // Dos Pokémon de cada bando combaten a la vez
+)
+
+// IsValidFormat indica si el formato es uno de los formatos de batalla conocidos.
+func IsValidFormat(format string) bool {
+	return format == FormatSingles || format == FormatDoubles
 }
 
 func (b *Battle) Validate() error {
@@ -47,8 +316,452 @@ func (b *Battle) Validate() error {
 		return errors.New("pokemon cannot battle itself")
 	}
 
-	if b.WinnerID != b.Pokemon1ID && b.WinnerID != b.Pokemon2ID {
+	if b.Format != "" && !IsValidFormat(b.Format) {
+		return errors.New("battle format must be singles or doubles")
+	}
+	if b.Format == FormatDoubles && (len(b.Team1) < 2 || len(b.Team2) < 2) {
+		return errors.New("double battles require teams of at least 2 pokemon")
+	}
+
+	if b.IsTeamBattle() {
+		if err := ValidateTeams(b.Team1, b.Team2); err != nil {
+			return err
+		}
+		if b.Team1[0] != b.Pokemon1ID || b.Team2[0] != b.Pokemon2ID {
+			return errors.New("the first pokemon of each team must lead the battle")
+		}
+		if !b.IsDraw() && b.Side(b.WinnerID) == 0 {
+			return errors.New("winner must be one of the battling pokemon")
+		}
+		return nil
+	}
+
+	if !b.IsDraw() && b.WinnerID != b.Pokemon1ID && b.WinnerID != b.Pokemon2ID {
 		return errors.New("winner must be one of the battling pokemon")
 	}
 	return nil
 }
+
+// ValidateTeams comprueba que los dos equipos de una batalla tienen entre 1 y
+// MaxTeamSize Pokémon y que ningún Pokémon aparece más de una vez.
+func ValidateTeams(team1, team2 []int) error {
+	if len(team1) == 0 || len(team2) == 0 {
+		return errors.New("both teams must have at least one pokemon")
+	}
+	if len(team1) > MaxTeamSize || len(team2) > MaxTeamSize {
+		return fmt.Errorf("teams cannot have more than %d pokemon", MaxTeamSize)
+	}
+
+	seen := make(map[int]bool, len(team1)+len(team2))
+	for _, id := range append(append([]int(nil), team1...), team2...) {
+		if id <= 0 {
+			return errors.New("invalid pokemon IDs")
+		}
+		if seen[id] {
+			return errors.New("a pokemon cannot appear more than once in a battle")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// IsTeamBattle indica si la batalla se ha librado entre dos equipos.
+func (b *Battle) IsTeamBattle() bool {
+	return len(b.Team1) > 0 || len(b.Team2) > 0
+}
+
+// Side devuelve el bando del Pokémon en la batalla: 1 o 2,
+// o 0 si el Pokémon no participa en ella.
+func (b *Battle) Side(pokemonID int) int {
+	if pokemonID == b.Pokemon1ID || slices.Contains(b.Team1, pokemonID) {
+		return 1
+	}
+	if pokemonID == b.Pokemon2ID || slices.Contains(b.Team2, pokemonID) {
+		return 2
+	}
+	return 0
+}
+
+// IsDraw indica si la batalla ha terminado en empate, sin ganador.
+func (b *Battle) IsDraw() bool {
+	return b.WinnerID == 0
+}
+
+// BattleSettings es la configuración con la que se ejecutó una batalla.
+// Junto con la semilla y los participantes permite repetir la batalla.
+type BattleSettings struct {
+	DiceSides           int    `json:"dice_sides"`                      // Número de caras de los dados de ataque y defensa
+	DiceExpression      string `json:"dice_expression,omitempty"`       // Expresión de los dados de ataque y defensa, en lugar de DiceSides
+	InitiativeDiceSides int    `json:"initiative_dice_sides,omitempty"` // Número de caras del dado de iniciativa
+	MaxExplosions       int    `json:"max_explosions"`                  // Máximo de explosiones de los dados de ataque y defensa
+	MaxTurns            int    `json:"max_turns,omitempty"`             // Máximo de turnos antes de declarar un empate
+	SwitchStrategy1     string `json:"switch_strategy1,omitempty"`      // Estrategia de cambios del primer equipo
+	SwitchStrategy2     string `json:"switch_strategy2,omitempty"`      // Estrategia de cambios del segundo equipo
+	Strategy1           string `json:"strategy1,omitempty"`             // Estrategia con la que decide cada turno el primer bando
+	Strategy2           string `json:"strategy2,omitempty"`             // Estrategia con la que decide cada turno el segundo bando
+	Condition           string `json:"condition,omitempty"`             // Condición del campo al empezar la batalla, que dura hasta que otra la sustituye
+}
+
+// FieldCondition es una condición del campo que ha estado activa durante una batalla.
+type FieldCondition struct {
+	Condition string `json:"condition"`  // Condición del campo (e.g., "rain", "sandstorm")
+	StartTurn int    `json:"start_turn"` // Turno en el que empieza la condición
+	Turns     int    `json:"turns"`      // Turnos que ha durado la condición
+}
+
+// Tipos de eventos del registro de una batalla.
+const (
+	EventInitiative   = "initiative"    // Un Pokémon gana la iniciativa del turno
+	EventAttack       = "attack"        // Un Pokémon ataca a otro
+	EventFaint        = "faint"         // Un Pokémon se queda sin HP
+	EventStatus       = "status"        // Un Pokémon sufre un problema de estado
+	EventResidual     = "residual"      // Un Pokémon pierde HP por su problema de estado al final del turno
+	EventImmobile     = "immobile"      // Un Pokémon pierde el turno por su problema de estado
+	EventCured        = "cured"         // Un Pokémon se recupera de su problema de estado
+	EventDraw         = "draw"          // La batalla termina en empate, al alcanzar el máximo de turnos o sin Pokémon en ambos bandos
+	EventSwitch       = "switch"        // Un Pokémon entra en combate en lugar de otro de su equipo
+	EventAbility      = "ability"       // Se activa la habilidad de un Pokémon
+	EventItem         = "item"          // Se activa el objeto que lleva un Pokémon
+	EventCondition    = "condition"     // Empieza una condición del campo
+	EventConditionEnd = "condition-end" // Termina una condición del campo
+)
+
+// BattleEvent es un evento del registro de una batalla.
+type BattleEvent struct {
+	Turn          int     `json:"turn"`                    // Turno en el que ocurre el evento
+	Type          string  `json:"type"`                    // Tipo de evento (e.g., "initiative", "attack")
+	PokemonID     int     `json:"pokemon_id"`              // ID del Pokémon que realiza la acción
+	TargetID      int     `json:"target_id,omitempty"`     // ID del Pokémon que recibe la acción
+	ReplacedID    int     `json:"replaced_id,omitempty"`   // ID del Pokémon que sale del combate en un cambio
+	Forced        bool    `json:"forced,omitempty"`        // Si el cambio se debe a que el Pokémon anterior se ha debilitado
+	Roll          int     `json:"roll,omitempty"`          // Tirada del Pokémon que realiza la acción
+	TargetRoll    int     `json:"target_roll,omitempty"`   // Tirada del Pokémon que recibe la acción
+	MoveID        int     `json:"move_id,omitempty"`       // ID del movimiento usado en el ataque
+	Move          string  `json:"move,omitempty"`          // Nombre del movimiento usado en el ataque
+	Missed        bool    `json:"missed,omitempty"`        // Si el movimiento ha fallado por su precisión
+	Critical      bool    `json:"critical,omitempty"`      // Si el ataque es un golpe crítico, por la explosión del dado de ataque
+	Status        string  `json:"status,omitempty"`        // Problema de estado del evento
+	Ability       string  `json:"ability,omitempty"`       // Habilidad que se activa en el evento
+	Item          string  `json:"item,omitempty"`          // Objeto que se activa en el evento
+	Condition     string  `json:"condition,omitempty"`     // Condición del campo del evento
+	Healed        int     `json:"healed,omitempty"`        // HP recuperado en el evento
+	Effectiveness float64 `json:"effectiveness,omitempty"` // Multiplicador de tipo aplicado al daño
+	Damage        int     `json:"damage"`                  // Daño causado
+	HP            int     `json:"hp"`                      // HP restante del Pokémon que realiza la acción
+	TargetHP      int     `json:"target_hp"`               // HP restante del Pokémon que recibe la acción
+}
+
+// BattleReplay es el resultado de repetir una batalla guardada
+// con la misma semilla, configuración y participantes.
+type BattleReplay struct {
+	BattleID       int  `json:"battle_id"`        // ID de la batalla repetida
+	WinnerID       int  `json:"winner_id"`        // ID del ganador guardado
+	Turns          int  `json:"turns"`            // Turnos guardados
+	ReplayWinnerID int  `json:"replay_winner_id"` // ID del ganador de la repetición
+	ReplayTurns    int  `json:"replay_turns"`     // Turnos de la repetición
+	Matches        bool `json:"matches"`          // Si el ganador y los turnos coinciden
+}
+
+// DiceRoll es el resultado de tirar una expresión de dados, como "2d6+3".
+type DiceRoll struct {
+	Expression string         `json:"expression"` // Expresión normalizada
+	Terms      []DiceTermRoll `json:"terms"`      // Tiradas de cada término de la expresión
+	Total      int            `json:"total"`      // Resultado total de la tirada
+}
+
+// DiceTermRoll es el resultado de un término de una expresión de dados.
+type DiceTermRoll struct {
+	Term    string `json:"term"`              // Término, como "4d6kh3" o "3"
+	Rolls   []int  `json:"rolls,omitempty"`   // Resultado de cada dado, sumando sus explosiones
+	Dropped []int  `json:"dropped,omitempty"` // Dados descartados por kh o kl
+	Total   int    `json:"total"`             // Resultado del término, con su signo
+}
+
+// SimulationResult es el resultado de simular muchas veces la batalla entre dos Pokémon.
+type SimulationResult struct {
+	Pokemon1ID       int                `json:"pokemon1_id"`       // ID del primer Pokémon
+	Pokemon2ID       int                `json:"pokemon2_id"`       // ID del segundo Pokémon
+	Runs             int                `json:"runs"`              // Número de batallas simuladas
+	Seed             int64              `json:"seed"`              // Semilla de la primera batalla; la batalla i usa Seed+i
+	Settings         BattleSettings     `json:"settings"`          // Configuración de los dados de las batallas
+	Pokemon1Wins     int                `json:"pokemon1_wins"`     // Batallas ganadas por el primer Pokémon
+	Pokemon2Wins     int                `json:"pokemon2_wins"`     // Batallas ganadas por el segundo Pokémon
+	Draws            int                `json:"draws"`             // Batallas terminadas en empate
+	Pokemon1WinRate  float64            `json:"pokemon1_win_rate"` // Probabilidad estimada de que gane el primer Pokémon
+	Pokemon2WinRate  float64            `json:"pokemon2_win_rate"` // Probabilidad estimada de que gane el segundo Pokémon
+	DrawRate         float64            `json:"draw_rate"`         // Probabilidad estimada de empate
+	Pokemon1WinCI    ConfidenceInterval `json:"pokemon1_win_ci"`   // Intervalo de confianza de la probabilidad de victoria del primer Pokémon
+	Pokemon2WinCI    ConfidenceInterval `json:"pokemon2_win_ci"`   // Intervalo de confianza de la probabilidad de victoria del segundo Pokémon
+	Turns            TurnStats          `json:"turns"`             // Estadísticas del número de turnos
+	TurnDistribution map[int]int        `json:"turn_distribution"` // Número de batallas que duran cada número de turnos
+}
+
+// ConfidenceInterval es un intervalo de confianza de una probabilidad.
+type ConfidenceInterval struct {
+	Level float64 `json:"level"` // Nivel de confianza, como 0.95
+	Lower float64 `json:"lower"` // Límite inferior
+	Upper float64 `json:"upper"` // Límite superior
+}
+
+// TurnStats son las estadísticas del número de turnos de un conjunto de batallas.
+type TurnStats struct {
+	Min  int     `json:"min"`  // Mínimo de turnos
+	Max  int     `json:"max"`  // Máximo de turnos
+	Mean float64 `json:"mean"` // Media de turnos
+	P50  int     `json:"p50"`  // Mediana
+	P90  int     `json:"p90"`  // Percentil 90
+	P95  int     `json:"p95"`  // Percentil 95
+	P99  int     `json:"p99"`  // Percentil 99
+}
+
+// Matchup es el resultado de simular muchas batallas entre dos Pokémon,
+// usado para construir la matriz de enfrentamientos.
+type Matchup struct {
+	Pokemon1ID   int       `json:"pokemon1_id"`   // ID del primer Pokémon
+	Pokemon2ID   int       `json:"pokemon2_id"`   // ID del segundo Pokémon
+	Runs         int       `json:"runs"`          // Número de batallas simuladas
+	Pokemon1Wins int       `json:"pokemon1_wins"` // Batallas ganadas por el primer Pokémon
+	Pokemon2Wins int       `json:"pokemon2_wins"` // Batallas ganadas por el segundo Pokémon
+	Draws        int       `json:"draws"`         // Batallas terminadas en empate
+	ComputedAt   time.Time `json:"computed_at"`   // Momento en el que se calculó el enfrentamiento
+}
+
+// WinRate devuelve la proporción de batallas ganadas por el Pokémon, o 0 si no participa.
+func (m *Matchup) WinRate(pokemonID int) float64 {
+	if m.Runs == 0 {
+		return 0
+	}
+	switch pokemonID {
+	case m.Pokemon1ID:
+		return float64(m.Pokemon1Wins) / float64(m.Runs)
+	case m.Pokemon2ID:
+		return float64(m.Pokemon2Wins) / float64(m.Runs)
+	}
+	return 0
+}
+
+// MatchupMatrix es la matriz de enfrentamientos de todos los Pokémon contra todos.
+type MatchupMatrix struct {
+	Pokemons   []MatchupPokemon `json:"pokemons"`    // Pokémon de las filas y columnas de la matriz, en orden
+	WinRates   [][]float64      `json:"win_rates"`   // WinRates[i][j] es la probabilidad de que el Pokémon i gane al j
+	Runs       int              `json:"runs"`        // Número de batallas simuladas por enfrentamiento
+	ComputedAt time.Time        `json:"computed_at"` // Momento en el que se calculó la matriz
+	Computing  bool             `json:"computing"`   // Si se está calculando una nueva matriz
+}
+
+// MatchupPokemon identifica a un Pokémon de la matriz de enfrentamientos.
+type MatchupPokemon struct {
+	ID   int    `json:"id"`   // Identificador del Pokémon
+	Name string `json:"name"` // Nombre del Pokémon
+}
+
+// Rating es la puntuación Glicko-2 de un Pokémon, actualizada después de cada
+// batalla guardada. Sirve para ordenar a los Pokémon mejor que las victorias,
+// porque tiene en cuenta la puntuación de los rivales.
+type Rating struct {
+	PokemonID  int       `json:"pokemon_id"` // ID del Pokémon
+	Rating     float64   `json:"rating"`     // Puntuación, 1500 al empezar
+	Deviation  float64   `json:"deviation"`  // Desviación de la puntuación, menor cuantas más batallas
+	Volatility float64   `json:"volatility"` // Volatilidad, cuánto se espera que cambie la puntuación
+	Battles    int       `json:"battles"`    // Número de batallas puntuadas
+	UpdatedAt  time.Time `json:"updated_at"` // Momento de la última actualización
+}
+
+// RatingChange es la puntuación de un Pokémon después de una batalla,
+// y forma parte de su historial de puntuaciones.
+type RatingChange struct {
+	BattleID   int       `json:"battle_id"`  // ID de la batalla que cambió la puntuación
+	PokemonID  int       `json:"pokemon_id"` // ID del Pokémon
+	Rating     float64   `json:"rating"`     // Puntuación después de la batalla
+	Deviation  float64   `json:"deviation"`  // Desviación después de la batalla
+	Volatility float64   `json:"volatility"` // Volatilidad después de la batalla
+	CreatedAt  time.Time `json:"created_at"` // Momento de la batalla
+}
+
+// Formatos de torneo.
+const (
+	TournamentSingleElimination = "single-elimination" // El perdedor de cada enfrentamiento queda eliminado
+	TournamentRoundRobin        = "round-robin"        // Todos los Pokémon se enfrentan contra todos
+	TournamentSwiss             = "swiss"              // Se enfrentan Pokémon con la misma puntuación, sin repetir rivales
+)
+
+// IsValidTournamentFormat indica si el formato es uno de los formatos de torneo conocidos.
+func IsValidTournamentFormat(format string) bool {
+	return format == TournamentSingleElimination || format == TournamentRoundRobin || format == TournamentSwiss
+}
+
+// Estados de un torneo.
+const (
+	TournamentInProgress = "in-progress" // Quedan rondas por jugar
+	TournamentFinished   = "finished"    // Se han jugado todas las rondas
+)
+
+// MinTournamentPokemons y MaxTournamentPokemons limitan el número de Pokémon de un torneo.
+const (
+	MinTournamentPokemons = 2
+	MaxTournamentPokemons = 64
+)
+
+// Tournament es un torneo entre Pokémon, cuyos enfrentamientos se libran ronda a ronda.
+type Tournament struct {
+	ID           int               `json:"id"`            // Identificador único del torneo
+	Name         string            `json:"name"`          // Nombre del torneo
+	Format       string            `json:"format"`        // Formato: single-elimination, round-robin o swiss
+	PokemonIDs   []int             `json:"pokemon_ids"`   // IDs de los Pokémon participantes, ordenados por cabeza de serie
+	Rounds       int               `json:"rounds"`        // Número total de rondas del torneo
+	CurrentRound int               `json:"current_round"` // Ronda que se jugará a continuación, o la última si ha terminado
+	Status       string            `json:"status"`        // Estado: in-progress o finished
+	WinnerID     int               `json:"winner_id"`     // ID del Pokémon ganador, 0 hasta que termina
+	Matches      []TournamentMatch `json:"matches"`       // Enfrentamientos de las rondas emparejadas, ronda a ronda
+	CreatedAt    time.Time         `json:"created_at"`    // Momento de creación del torneo
+}
+
+// Validate comprueba el nombre y el formato del torneo y que los Pokémon participantes
+// son entre MinTournamentPokemons y MaxTournamentPokemons, sin repetirse.
+func (t *Tournament) Validate() error {
+	if t.Name == "" {
+		return errors.New("tournament name is required")
+	}
+	if !IsValidTournamentFormat(t.Format) {
+		return errors.New("tournament format must be single-elimination, round-robin or swiss")
+	}
+	if len(t.PokemonIDs) < MinTournamentPokemons || len(t.PokemonIDs) > MaxTournamentPokemons {
+		return fmt.Errorf("tournaments must have between %d and %d pokemon", MinTournamentPokemons, MaxTournamentPokemons)
+	}
+
+	seen := make(map[int]bool, len(t.PokemonIDs))
+	for _, id := range t.PokemonIDs {
+		if id <= 0 {
+			return errors.New("invalid pokemon IDs")
+		}
+		if seen[id] {
+			return errors.New("a pokemon cannot enter a tournament more than once")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// TournamentMatch es un enfrentamiento de una ronda de un torneo. Un Pokémon sin
+// rival descansa esa ronda y se considera ganador del enfrentamiento.
+type TournamentMatch struct {
+	Round      int  `json:"round"`       // Ronda del enfrentamiento, empezando por 1
+	Position   int  `json:"position"`    // Posición del enfrentamiento en la ronda, empezando por 0
+	Pokemon1ID int  `json:"pokemon1_id"` // ID del primer Pokémon
+	Pokemon2ID int  `json:"pokemon2_id"` // ID del segundo Pokémon, 0 si el primero descansa
+	BattleID   int  `json:"battle_id"`   // ID de la batalla guardada, 0 si no se ha librado
+	WinnerID   int  `json:"winner_id"`   // ID del ganador, 0 si no se ha librado o ha sido empate
+	Played     bool `json:"played"`      // Si el enfrentamiento ya se ha resuelto
+}
+
+// IsBye indica si el enfrentamiento es un descanso, sin rival.
+func (m *TournamentMatch) IsBye() bool {
+	return m.Pokemon2ID == 0
+}
+
+// TournamentStanding es la clasificación de un Pokémon en un torneo.
+type TournamentStanding struct {
+	Rank      int `json:"rank"`       // Posición en la clasificación, empezando por 1
+	PokemonID int `json:"pokemon_id"` // ID del Pokémon
+	Played    int `json:"played"`     // Enfrentamientos resueltos, incluidos los descansos
+	Wins      int `json:"wins"`       // Victorias, incluidos los descansos
+	Draws     int `json:"draws"`      // Empates
+	Losses    int `json:"losses"`     // Derrotas
+	Points    int `json:"points"`     // Puntos: 3 por victoria y 1 por empate
+	Buchholz  int `json:"buchholz"`   // Suma de los puntos de los rivales, para desempatar
+}
+
+// BracketNode es un nodo del cuadro de un torneo de eliminación directa. La
+// raíz es la final, y los hijos de cada enfrentamiento son los enfrentamientos
+// de la ronda anterior de los que salen sus Pokémon.
+type BracketNode struct {
+	Match    *TournamentMatch `json:"match,omitempty"`    // Enfrentamiento, nil si todavía no se ha emparejado
+	Round    int              `json:"round"`              // Ronda del enfrentamiento
+	Children []*BracketNode   `json:"children,omitempty"` // Enfrentamientos de la ronda anterior
+}
+
+// Acciones que puede elegir un bando en cada turno.
+const (
+	ActionAttack = "attack" // El Pokémon que está combatiendo ataca
+	ActionSwitch = "switch" // El bando cambia de Pokémon y pierde su ataque del turno
+	ActionItem   = "item"   // El Pokémon usa el objeto que lleva y pierde su ataque del turno
+)
+
+// IsValidAction indica si el tipo de acción es uno de los conocidos.
+func IsValidAction(action string) bool {
+	return action == ActionAttack || action == ActionSwitch || action == ActionItem
+}
+
+// BattleAction es la acción de un bando en un turno de una sesión de batalla.
+type BattleAction struct {
+	Type   string `json:"type"`             // Tipo de acción: attack, switch o item
+	Move   int    `json:"move"`             // Posición del movimiento del ataque entre los del Pokémon; si es negativa se elige uno al azar
+	Switch int    `json:"switch,omitempty"` // Posición en el equipo del Pokémon que entra en combate en un cambio
+}
+
+// Estados de una sesión de batalla.
+const (
+	SessionInProgress = "in-progress" // Los entrenadores siguen enviando sus acciones
+	SessionFinished   = "finished"    // La batalla ha terminado y se ha guardado
+)
+
+// SessionTurn son las acciones de los dos bandos en un turno resuelto de una sesión.
+type SessionTurn struct {
+	Action1 BattleAction `json:"action1"` // Acción del primer bando
+	Action2 BattleAction `json:"action2"` // Acción del segundo bando
+}
+
+// BattleSession es una batalla interactiva entre dos entrenadores, que envían la
+// acción de su bando en cada turno. La sesión guarda la semilla, la configuración,
+// los participantes y las acciones de los turnos resueltos, con los que se vuelve
+// a librar la batalla para conocer su estado.
+type BattleSession struct {
+	ID           int            `json:"id"`                 // Identificador único de la sesión
+	Team1        []int          `json:"team1"`              // IDs del primer equipo, en orden de salida
+	Team2        []int          `json:"team2"`              // IDs del segundo equipo, en orden de salida
+	Seed         int64          `json:"seed"`               // Semilla de los dados de la batalla
+	Settings     BattleSettings `json:"settings"`           // Configuración de la batalla
+	Participants []Pokemon      `json:"participants"`       // Estadísticas de los participantes al empezar la batalla
+	Turns        []SessionTurn  `json:"turns"`              // Acciones de los turnos resueltos, en orden
+	Pending1     *BattleAction  `json:"pending1,omitempty"` // Acción del primer bando para el turno en curso, si ya la ha enviado
+	Pending2     *BattleAction  `json:"pending2,omitempty"` // Acción del segundo bando para el turno en curso, si ya la ha enviado
+	Status       string         `json:"status"`             // Estado: in-progress o finished
+	BattleID     int            `json:"battle_id"`          // ID de la batalla guardada al terminar, 0 hasta entonces
+	CreatedAt    time.Time      `json:"created_at"`         // Momento de creación de la sesión
+
+	// Estado de la batalla, que se calcula a partir de las acciones y no se guarda
+	Turn     int              `json:"turn"`          // Turno en curso, o el último si ha terminado
+	WinnerID int              `json:"winner_id"`     // ID del ganador, 0 hasta que termina o si termina en empate
+	Pokemons []SessionPokemon `json:"pokemons"`      // Estado de cada participante
+	Log      []BattleEvent    `json:"log,omitempty"` // Registro de los turnos resueltos
+}
+
+// Validate comprueba los equipos de la sesión y que hay un participante por cada Pokémon.
+func (s *BattleSession) Validate() error {
+	if err := ValidateTeams(s.Team1, s.Team2); err != nil {
+		return err
+	}
+	if len(s.Participants) != len(s.Team1)+len(s.Team2) {
+		return errors.New("every pokemon of the teams must be a participant")
+	}
+	return nil
+}
+
+// Pending devuelve la acción enviada por un bando (1 o 2) para el turno en curso, nil si no la ha enviado.
+func (s *BattleSession) Pending(side int) *BattleAction {
+	if side == 1 {
+		return s.Pending1
+	}
+	return s.Pending2
+}
+
+// SessionPokemon es el estado de un participante de una sesión de batalla.
+type SessionPokemon struct {
+	PokemonID int    `json:"pokemon_id"`       // ID del Pokémon
+	Side      int    `json:"side"`             // Bando del Pokémon: 1 o 2
+	Active    bool   `json:"active"`           // Si está combatiendo
+	HP        int    `json:"hp"`               // HP actual
+	MaxHP     int    `json:"max_hp"`           // HP al empezar la batalla
+	Status    string `json:"status,omitempty"` // Problema de estado que sufre, si sufre alguno
+	Item      string `json:"item,omitempty"`   // Objeto que todavía lleva, si lleva alguno
+}
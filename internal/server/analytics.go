@@ -0,0 +1,86 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+// analyticsServer is used to handle the analytics routes.
+// The matchup matrix is computed in the background by the matchup job.
+type analyticsServer struct {
+	pokemonSrv database.PokemonCRUDService
+	matchupSrv database.MatchupService
+	job        *matchupJob
+}
+
+// GetMatchups returns the last computed matchup matrix, where the row i and
+// column j is the win rate of the pokemon i against the pokemon j.
+// It returns JSON by default, and CSV with ?format=csv or Accept: text/csv.
+func (s *analyticsServer) GetMatchups(c *fiber.Ctx) error {
+	ctx := context.Background()
+	format := c.Query("format")
+	if format == "" && c.Accepts(fiber.MIMEApplicationJSON, "text/csv") == "text/csv" {
+		format = "csv"
+	}
+	if format != "" && format != "json" && format != "csv" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid format"})
+	}
+
+	pokemons, err := s.pokemonSrv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	matchups, err := s.matchupSrv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	matrix := business.NewMatchupMatrix(pokemons, matchups)
+	matrix.Computing = s.job.Computing()
+
+	if format == "csv" {
+		body, err := matchupsCSV(matrix)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		c.Set(fiber.HeaderContentType, "text/csv")
+		return c.Send(body)
+	}
+	return c.JSON(matrix)
+}
+
+// matchupsCSV writes the matrix as CSV, with a header with the pokemon IDs
+// and a row for each pokemon with its ID, its name and its win rates
+func matchupsCSV(matrix models.MatchupMatrix) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"pokemon_id", "name"}
+	for _, pokemon := range matrix.Pokemons {
+		header = append(header, strconv.Itoa(pokemon.ID))
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for i, pokemon := range matrix.Pokemons {
+		row := []string{strconv.Itoa(pokemon.ID), pokemon.Name}
+		for _, rate := range matrix.WinRates[i] {
+			row = append(row, strconv.FormatFloat(rate, 'f', 4, 64))
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
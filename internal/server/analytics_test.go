@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"pokemon-battle/internal/models"
+)
+
+// mockMatchupService is used for testing the analytics routes and the matchup job
+// including the ability to return an error so we can test error handling
+type mockMatchupService struct {
+	hasError bool
+
+	mu       sync.Mutex
+	matchups []models.Matchup
+	replaced chan struct{}
+}
+
+func (m *mockMatchupService) ReplaceAll(ctx context.Context, matchups []models.Matchup) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	m.mu.Lock()
+	m.matchups = matchups
+	m.mu.Unlock()
+	if m.replaced != nil {
+		m.replaced <- struct{}{}
+	}
+	return nil
+}
+
+func (m *mockMatchupService) GetAll(ctx context.Context) ([]models.Matchup, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.matchups, nil
+}
+
+func TestGetMatchups(t *testing.T) {
+	matchups := []models.Matchup{
+		{Pokemon1ID: 1, Pokemon2ID: 2, Runs: 10, Pokemon1Wins: 7, Pokemon2Wins: 3, ComputedAt: time.Now()},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		s := New()
+
+		analyticsServer := analyticsServer{pokemonSrv: &mockPokemonService{hasError: false}, matchupSrv: &mockMatchupService{matchups: matchups}}
+		s.App.Get("/analytics/matchups", analyticsServer.GetMatchups)
+
+		req, err := http.NewRequest("GET", "/analytics/matchups", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var matrix models.MatchupMatrix
+		err = json.NewDecoder(resp.Body).Decode(&matrix)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(matrix.Pokemons) != 2 || matrix.Runs != 10 {
+			t.Fatalf("expected 2 pokemons with 10 runs; got %+v", matrix)
+		}
+		if matrix.WinRates[0][1] != 0.7 || matrix.WinRates[1][0] != 0.3 {
+			t.Errorf("expected win rates 0.7 and 0.3; got %v", matrix.WinRates)
+		}
+	})
+
+	t.Run("success/csv", func(t *testing.T) {
+		for _, header := range []string{"", "text/csv"} {
+			s := New()
+
+			analyticsServer := analyticsServer{pokemonSrv: &mockPokemonService{hasError: false}, matchupSrv: &mockMatchupService{matchups: matchups}}
+			s.App.Get("/analytics/matchups", analyticsServer.GetMatchups)
+
+			url := "/analytics/matchups?format=csv"
+			if header != "" {
+				url = "/analytics/matchups"
+			}
+			req, err := http.NewRequest("GET", url, nil)
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+			if header != "" {
+				req.Header.Set("Accept", header)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("expected status OK; got %v", resp.Status)
+			}
+			if resp.Header.Get("Content-Type") != "text/csv" {
+				t.Errorf("expected content type text/csv; got %v", resp.Header.Get("Content-Type"))
+			}
+
+			records, err := csv.NewReader(resp.Body).ReadAll()
+			if err != nil {
+				t.Fatalf("error reading CSV body. Err: %v", err)
+			}
+			if len(records) != 3 {
+				t.Fatalf("expected a header and 2 rows; got %v", records)
+			}
+			if records[0][2] != "1" || records[1][1] != "Pikachu" || records[1][3] != "0.7000" {
+				t.Errorf("unexpected CSV records %v", records)
+			}
+		}
+	})
+
+	t.Run("error/invalid-format", func(t *testing.T) {
+		s := New()
+
+		analyticsServer := analyticsServer{pokemonSrv: &mockPokemonService{hasError: false}, matchupSrv: &mockMatchupService{}}
+		s.App.Get("/analytics/matchups", analyticsServer.GetMatchups)
+
+		req, err := http.NewRequest("GET", "/analytics/matchups?format=xml", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+
+		analyticsServer := analyticsServer{pokemonSrv: &mockPokemonService{hasError: false}, matchupSrv: &mockMatchupService{hasError: true}}
+		s.App.Get("/analytics/matchups", analyticsServer.GetMatchups)
+
+		req, err := http.NewRequest("GET", "/analytics/matchups", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+}
+
+func TestMatchupJob(t *testing.T) {
+	matchupSrv := &mockMatchupService{replaced: make(chan struct{}, 1)}
+	job := newMatchupJob(&mockPokemonService{hasError: false}, &mockPokemonMoveService{hasError: false}, matchupSrv, fightSettings{diceSides: 6, maxTurns: 100}, 10, 2)
+	job.Start()
+	defer job.Stop()
+
+	// nothing is stored, so the matrix is computed on start
+	select {
+	case <-matchupSrv.replaced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the matrix to be computed on start")
+	}
+
+	matchups, _ := matchupSrv.GetAll(context.Background())
+	if len(matchups) != 1 || matchups[0].Runs != 10 {
+		t.Fatalf("expected 1 matchup with 10 runs; got %+v", matchups)
+	}
+
+	// a pokemon changes, so the matrix is computed again
+	job.Trigger()
+	select {
+	case <-matchupSrv.replaced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the matrix to be computed again")
+	}
+}
@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 
 	"pokemon-battle/internal/business"
@@ -14,14 +17,147 @@ import (
 // battleServer is used to handle the battle routes.
 // It receives a database.BattleCRUDService and uses it to handle the routes.
 type battleServer struct {
-	srv        database.BattleCRUDService
-	pokemonSrv database.PokemonCRUDService
-	diceSides  int
+	srv                 database.BattleCRUDService
+	pokemonSrv          database.PokemonCRUDService
+	diceSides           int
+	initiativeDiceSides int
+	maxExplosions       int
+	attackDice          *business.DiceExpression
+	maxTurns            int
+	typeChart           business.TypeChart
+
+	// feed receives the battles created by the battle routes
+	feed *battleFeed
+}
+
+// fightRequest contains the settings of a fight that a request can override
+type fightRequest struct {
+	Seed *int64 `json:"seed,omitempty"` // optional, to reproduce a battle
+
+	// MaxExplosions overrides the maximum number of explosions of the attack dice
+	// configured in the server. 0 disables the explosions and the critical hits.
+	MaxExplosions *int `json:"max_explosions,omitempty"`
+
+	// Dice overrides the dice used for the attack and defense rolls
+	// with a dice expression, e.g. "2d6+3" or "1d20!"
+	Dice string `json:"dice,omitempty"`
+
+	// MaxTurns lowers the maximum number of turns configured in the server,
+	// after which the battle ends in a draw. It cannot exceed the server's maximum
+	MaxTurns *int `json:"max_turns,omitempty"`
+
+	// Condition is the field condition at the start of the battle, e.g. "rain"
+	// or "sandstorm", which lasts until a move replaces it
+	Condition string `json:"condition,omitempty"`
+}
+
+// fightSettings are the settings of the fights configured in the server
+type fightSettings struct {
+	diceSides           int
+	initiativeDiceSides int
+	maxExplosions       int
+	attackDice          *business.DiceExpression
+	maxTurns            int
+	typeChart           business.TypeChart
+}
+
+// options returns the options of a fight with the settings of the server,
+// overridden by the ones of the request. It fails if the request is invalid.
+func (r *fightRequest) options(settings fightSettings) ([]business.Option, error) {
+	maxExplosions := settings.maxExplosions
+	if r.MaxExplosions != nil {
+		if *r.MaxExplosions < 0 {
+			return nil, errors.New("max_explosions cannot be negative")
+		}
+		maxExplosions = *r.MaxExplosions
+	}
+
+	attackDice := settings.attackDice
+	if r.Dice != "" {
+		dice, err := business.ParseDice(r.Dice)
+		if err != nil {
+			return nil, err
+		}
+		attackDice = dice
+	}
+
+	maxTurns := settings.maxTurns
+	if maxTurns <= 0 {
+		maxTurns = business.DefaultMaxTurns
+	}
+	if r.MaxTurns != nil {
+		if *r.MaxTurns <= 0 {
+			return nil, errors.New("max_turns must be greater than 0")
+		}
+		if *r.MaxTurns > maxTurns {
+			return nil, fmt.Errorf("max_turns cannot exceed %d", maxTurns)
+		}
+		maxTurns = *r.MaxTurns
+	}
+
+	if r.Condition != "" && !models.IsValidCondition(r.Condition) {
+		return nil, errors.New("condition must be rain, sun, sandstorm, hail, electric-terrain or grassy-terrain")
+	}
+
+	opts := []business.Option{
+		business.WithTypeChart(settings.typeChart),
+		business.WithInitiativeDice(settings.initiativeDiceSides),
+		business.WithMaxExplosions(maxExplosions),
+		business.WithAttackDice(attackDice),
+		business.WithMaxTurns(maxTurns),
+		business.WithCondition(r.Condition),
+	}
+	if r.Seed != nil {
+		opts = append(opts, business.WithSeed(*r.Seed))
+	}
+	return opts, nil
 }
 
 type battleRequest struct {
 	Pokemon1ID int `json:"pokemon1_id"`
 	Pokemon2ID int `json:"pokemon2_id"`
+
+	fightRequest
+
+	// Team1 and Team2 are the ordered rosters of a team battle, e.g. 3v3 or 6v6,
+	// instead of Pokemon1ID and Pokemon2ID. When a pokemon faints, the next one
+	// of its team comes in
+	Team1 []int `json:"team1,omitempty"`
+	Team2 []int `json:"team2,omitempty"`
+
+	// SwitchStrategy1 and SwitchStrategy2 are the names of the strategies used by
+	// each team to switch pokemon voluntarily, e.g. "type-advantage".
+	// Without a strategy, a team only switches when its pokemon faints
+	SwitchStrategy1 string `json:"switch_strategy1,omitempty"`
+	SwitchStrategy2 string `json:"switch_strategy2,omitempty"`
+
+	// Strategy1 and Strategy2 are the names of the strategies that decide the
+	// action of each side every turn: "random", "greedy", "minimax" or "minimax-N".
+	// They take precedence over the switch strategies. Without a strategy,
+	// a side attacks with a random move
+	Strategy1 string `json:"strategy1,omitempty"`
+	Strategy2 string `json:"strategy2,omitempty"`
+
+	// Format is the battle format: "singles" (the default) or "doubles".
+	// Double battles require team1 and team2 with at least 2 pokemon each
+	Format string `json:"format,omitempty"`
+}
+
+// settings returns the settings of the fights configured in the server
+func (s *battleServer) settings() fightSettings {
+	return fightSettings{
+		diceSides:           s.diceSides,
+		initiativeDiceSides: s.initiativeDiceSides,
+		maxExplosions:       s.maxExplosions,
+		attackDice:          s.attackDice,
+		maxTurns:            s.maxTurns,
+		typeChart:           s.typeChart,
+	}
+}
+
+// isTeamBattle tells whether the request is for a battle between two teams
+func (r *battleRequest) isTeamBattle() bool {
+	return len(r.Team1) > 0 || len(r.Team2) > 0
 }
 
 func (s *battleServer) CreateBattle(c *fiber.Ctx) error {
@@ -31,28 +167,211 @@ func (s *battleServer) CreateBattle(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
 	}
 
+	battle, err := s.fight(ctx, req)
+	var reqErr requestError
+	if errors.As(err, &reqErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = createBattle(ctx, s.srv, s.feed, &battle)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(battle)
+}
+
+// requestError is an error caused by an invalid battle request, as opposed to
+// the errors retrieving the pokemons
+type requestError struct {
+	error
+}
+
+// fight validates the request and fights the battle, without storing it.
+// The options are added to the ones of the request, e.g. to receive the events.
+func (s *battleServer) fight(ctx context.Context, req battleRequest, extra ...business.Option) (models.Battle, error) {
+	opts, err := req.options(s.settings())
+	if err != nil {
+		return models.Battle{}, requestError{err}
+	}
+
+	switch1, err := business.SwitchStrategyByName(req.SwitchStrategy1)
+	if err != nil {
+		return models.Battle{}, requestError{err}
+	}
+	switch2, err := business.SwitchStrategyByName(req.SwitchStrategy2)
+	if err != nil {
+		return models.Battle{}, requestError{err}
+	}
+	strategy1, err := business.StrategyByName(req.Strategy1)
+	if err != nil {
+		return models.Battle{}, requestError{err}
+	}
+	strategy2, err := business.StrategyByName(req.Strategy2)
+	if err != nil {
+		return models.Battle{}, requestError{err}
+	}
+	if req.isTeamBattle() {
+		if err := models.ValidateTeams(req.Team1, req.Team2); err != nil {
+			return models.Battle{}, requestError{err}
+		}
+	} else if switch1 != nil || switch2 != nil {
+		return models.Battle{}, requestError{errors.New("switch strategies require team1 and team2")}
+	}
+
+	format := models.FormatSingles
+	if req.Format != "" {
+		if !models.IsValidFormat(req.Format) {
+			return models.Battle{}, requestError{errors.New("format must be singles or doubles")}
+		}
+		format = req.Format
+	}
+	if format == models.FormatDoubles {
+		if len(req.Team1) < 2 || len(req.Team2) < 2 {
+			return models.Battle{}, requestError{errors.New("double battles require teams of at least 2 pokemon")}
+		}
+		if switch1 != nil || switch2 != nil {
+			return models.Battle{}, requestError{errors.New("switch strategies are not supported in double battles")}
+		}
+		if strategy1 != nil || strategy2 != nil {
+			return models.Battle{}, requestError{errors.New("strategies are not supported in double battles")}
+		}
+	}
+	opts = append(opts, business.WithStrategies(strategy1, strategy2))
+	opts = append(opts, extra...)
+
+	if req.isTeamBattle() {
+		// retrieve the teams from the database
+		team1, err := getPokemons(ctx, s.pokemonSrv, req.Team1)
+		if err != nil {
+			return models.Battle{}, err
+		}
+		team2, err := getPokemons(ctx, s.pokemonSrv, req.Team2)
+		if err != nil {
+			return models.Battle{}, err
+		}
+
+		if format == models.FormatDoubles {
+			return business.FightDoubles(s.diceSides, team1, team2, opts...), nil
+		}
+		opts = append(opts, business.WithSwitchStrategies(switch1, switch2))
+		return business.FightTeams(s.diceSides, team1, team2, opts...), nil
+	}
+
 	// retrieve the pokemons from the database
 	pokemon1, err := s.pokemonSrv.GetByID(ctx, req.Pokemon1ID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return models.Battle{}, err
 	}
 	pokemon2, err := s.pokemonSrv.GetByID(ctx, req.Pokemon2ID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return models.Battle{}, err
 	}
+	return business.Fight(s.diceSides, pokemon1, pokemon2, opts...), nil
+}
 
-	battle := business.Fight(s.diceSides, pokemon1, pokemon2)
+// StreamBattle fights a battle over a WebSocket connection. It reads the battle
+// request as the first message, sends the events of each turn as soon as they
+// resolve and finally the battle, once it is stored.
+func (s *battleServer) StreamBattle(c *websocket.Conn) {
+	ctx := context.Background()
+	var req battleRequest
+	if err := c.ReadJSON(&req); err != nil {
+		closeStream(c, streamMessage{Error: "Invalid request"})
+		return
+	}
 
-	err = s.srv.Create(ctx, &battle)
+	// the battle is stored even if the connection is lost while it is fought
+	battle, err := s.fight(ctx, req, business.WithEventHandler(func(event models.BattleEvent) {
+		_ = c.WriteJSON(streamMessage{Event: &event})
+	}))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		closeStream(c, streamMessage{Error: err.Error()})
+		return
 	}
-	return c.Status(fiber.StatusCreated).JSON(battle)
+
+	err = createBattle(ctx, s.srv, s.feed, &battle)
+	if err != nil {
+		closeStream(c, streamMessage{Error: err.Error()})
+		return
+	}
+	closeStream(c, streamMessage{Battle: &battle})
+}
+
+// WatchBattle streams a stored battle over a WebSocket connection: the events
+// of its log and then the battle.
+func (s *battleServer) WatchBattle(c *websocket.Conn) {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		closeStream(c, streamMessage{Error: "Invalid ID"})
+		return
+	}
+
+	battle, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		closeStream(c, streamMessage{Error: err.Error()})
+		return
+	}
+
+	// the log is stored apart from the battle
+	battle.Log, err = s.srv.GetLog(ctx, id)
+	if err != nil {
+		closeStream(c, streamMessage{Error: err.Error()})
+		return
+	}
+	closeStream(c, append(eventMessages(battle.Log), streamMessage{Battle: &battle})...)
 }
 
+// getPokemons retrieves the pokemons with the given IDs from the database, in the same order
+func getPokemons(ctx context.Context, pokemonSrv database.PokemonCRUDService, ids []int) ([]models.Pokemon, error) {
+	pokemons := make([]models.Pokemon, 0, len(ids))
+	for _, id := range ids {
+		pokemon, err := pokemonSrv.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		pokemons = append(pokemons, pokemon)
+	}
+	return pokemons, nil
+}
+
+// GetAllBattles returns all the battles. The draw query parameter
+// selects the battles that ended in a draw (true) or with a winner (false),
+// the format query parameter the battles of a format (singles or doubles)
+// and the condition query parameter the battles with a field condition, e.g. rain.
 func (s *battleServer) GetAllBattles(c *fiber.Ctx) error {
 	ctx := context.Background()
-	battles, err := s.srv.GetAll(ctx)
+
+	var filter database.BattleFilter
+	if draw := c.Query("draw"); draw != "" {
+		isDraw, err := strconv.ParseBool(draw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid draw filter"})
+		}
+		filter.Draw = &isDraw
+	}
+	if format := c.Query("format"); format != "" {
+		if !models.IsValidFormat(format) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid format filter"})
+		}
+		filter.Format = format
+	}
+	if condition := c.Query("condition"); condition != "" {
+		if !models.IsValidCondition(condition) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid condition filter"})
+		}
+		filter.Condition = condition
+	}
+
+	var battles []models.Battle
+	var err error
+	if filter == (database.BattleFilter{}) {
+		battles, err = s.srv.GetAll(ctx)
+	} else {
+		battles, err = s.srv.Find(ctx, filter)
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -73,6 +392,43 @@ func (s *battleServer) GetBattleByID(c *fiber.Ctx) error {
 	return c.JSON(battle)
 }
 
+func (s *battleServer) GetBattleLog(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	events, err := s.srv.GetLog(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(events)
+}
+
+// ReplayBattle re-runs a stored battle with the same seed, settings and participants,
+// reporting whether the winner and the number of turns match the stored ones.
+func (s *battleServer) ReplayBattle(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	battle, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	replay, err := business.Replay(battle, business.WithTypeChart(s.typeChart))
+	if errors.Is(err, business.ErrNotReplayable) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(replay)
+}
+
 func (s *battleServer) UpdateBattle(c *fiber.Ctx) error {
 	ctx := context.Background()
 	id, err := strconv.Atoi(c.Params("id"))
@@ -20,7 +20,7 @@ func TestBattle_IT(t *testing.T) {
 	pokemonSrv := database.NewPokemonService(databaseSrv)
 	battleSrv := database.NewBattleService(databaseSrv)
 
-	s.RegisterFiberRoutes(pokemonSrv, battleSrv)
+	s.RegisterFiberRoutes(Services{Pokemons: pokemonSrv, Battles: battleSrv})
 
 	t.Run("create", func(t *testing.T) {
 		t.Run("post-ok", func(t *testing.T) {
@@ -57,6 +57,55 @@ func TestBattle_IT(t *testing.T) {
 			if battleResponse.Pokemon1ID != 1 || battleResponse.Pokemon2ID != 2 {
 				t.Errorf("expected Pokemon1ID to be 1 and Pokemon2ID to be 2; got %v and %v", battleResponse.Pokemon1ID, battleResponse.Pokemon2ID)
 			}
+			if len(battleResponse.Log) == 0 {
+				t.Fatalf("expected battle log not to be empty")
+			}
+
+			// the log must be persisted with the battle
+			req = createAuthenticatedRequest(t, "GET", "/battles/"+strconv.Itoa(battleResponse.ID)+"/log", nil)
+
+			logResp, err := s.App.Test(req, -1) // disable timeout
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer logResp.Body.Close()
+
+			if logResp.StatusCode != http.StatusOK {
+				t.Errorf("expected status OK; got %v", logResp.Status)
+			}
+
+			var events []models.BattleEvent
+			err = json.NewDecoder(logResp.Body).Decode(&events)
+			if err != nil {
+				t.Fatalf("error decoding response. Err: %v", err)
+			}
+
+			if len(events) != len(battleResponse.Log) {
+				t.Errorf("expected %d events; got %d", len(battleResponse.Log), len(events))
+			}
+
+			// the stored battle must be replayable with the same result
+			req = createAuthenticatedRequest(t, "POST", "/battles/"+strconv.Itoa(battleResponse.ID)+"/replay", nil)
+
+			replayResp, err := s.App.Test(req, -1) // disable timeout
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer replayResp.Body.Close()
+
+			if replayResp.StatusCode != http.StatusOK {
+				t.Errorf("expected status OK; got %v", replayResp.Status)
+			}
+
+			var replay models.BattleReplay
+			err = json.NewDecoder(replayResp.Body).Decode(&replay)
+			if err != nil {
+				t.Fatalf("error decoding response. Err: %v", err)
+			}
+
+			if !replay.Matches {
+				t.Errorf("expected replay to match the stored battle; got %+v", replay)
+			}
 		})
 	})
 
@@ -7,8 +7,11 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"slices"
 	"testing"
 
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
 	"pokemon-battle/internal/models"
 )
 
@@ -16,6 +19,9 @@ import (
 // including the ability to return an error so we can test error handling
 type mockBattleService struct {
 	hasError bool
+
+	// stored is returned by GetByID when it's set
+	stored *models.Battle
 }
 
 func (m *mockBattleService) Create(ctx context.Context, battle *models.Battle) error {
@@ -36,13 +42,56 @@ func (m *mockBattleService) GetAll(ctx context.Context) ([]models.Battle, error)
 	}, nil
 }
 
+func (m *mockBattleService) Find(ctx context.Context, filter database.BattleFilter) ([]models.Battle, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+
+	battles := []models.Battle{}
+	for _, battle := range []models.Battle{
+		{ID: 1, Pokemon1ID: 1, Pokemon2ID: 2, WinnerID: 1, Format: models.FormatSingles},
+		{ID: 2, Pokemon1ID: 3, Pokemon2ID: 4, WinnerID: 4, Format: models.FormatDoubles, Team1: []int{3, 5}, Team2: []int{4, 6}},
+		{ID: 3, Pokemon1ID: 1, Pokemon2ID: 4, Format: models.FormatSingles, Conditions: []models.FieldCondition{{Condition: models.ConditionRain, StartTurn: 1, Turns: 5}}},
+	} {
+		if filter.Draw != nil && *filter.Draw != battle.IsDraw() {
+			continue
+		}
+		if filter.Format != "" && filter.Format != battle.Format {
+			continue
+		}
+		if filter.WinnerID != 0 && filter.WinnerID != battle.WinnerID {
+			continue
+		}
+		if filter.Condition != "" && !slices.ContainsFunc(battle.Conditions, func(c models.FieldCondition) bool {
+			return c.Condition == filter.Condition
+		}) {
+			continue
+		}
+		battles = append(battles, battle)
+	}
+	return battles, nil
+}
+
 func (m *mockBattleService) GetByID(ctx context.Context, id int) (models.Battle, error) {
 	if m.hasError {
 		return models.Battle{}, errors.New("mock error")
 	}
+	if m.stored != nil {
+		return *m.stored, nil
+	}
 	return models.Battle{ID: id, Pokemon1ID: 1, Pokemon2ID: 2, WinnerID: 1}, nil
 }
 
+func (m *mockBattleService) GetLog(ctx context.Context, id int) ([]models.BattleEvent, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return []models.BattleEvent{
+		{Turn: 1, Type: models.EventInitiative, PokemonID: 1, TargetID: 2},
+		{Turn: 1, Type: models.EventAttack, PokemonID: 1, TargetID: 2, Damage: 10},
+	}, nil
+}
+
 func (m *mockBattleService) Update(ctx context.Context, battle models.Battle) error {
 	if m.hasError {
 		return errors.New("mock error")
@@ -90,23 +139,52 @@ func TestCreateBattle(t *testing.T) {
 		}
 	})
 
-	t.Run("error", func(t *testing.T) {
+	t.Run("success/seed", func(t *testing.T) {
 		s := New()
+
 		battleRoutes := s.App.Group("/battles")
 
-		// init the battle routes from a mock battle service that returns an error
-		// the pokemon service is mocked to not return an error
-		battleServer := battleServer{srv: &mockBattleService{hasError: true}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
 		battleRoutes.Post("/", battleServer.CreateBattle)
 
-		battle := models.Battle{
-			Pokemon1ID: 1,
-			Pokemon2ID: 2,
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "seed": 42}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
 		}
-		body, err := json.Marshal(battle)
+
+		resp, err := s.App.Test(req)
 		if err != nil {
-			t.Fatalf("error marshalling battle. Err: %v", err)
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
+		}
+
+		var battle models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battle)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
 		}
+		if battle.Seed != 42 {
+			t.Errorf("expected seed to be 42; got %v", battle.Seed)
+		}
+	})
+
+	t.Run("success/max-explosions", func(t *testing.T) {
+		s := New()
+
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6, maxExplosions: 3}
+		battleRoutes.Post("/", battleServer.CreateBattle)
+
+		// the request overrides the maximum number of explosions of the server
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "max_explosions": 0}`)
 
 		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
@@ -118,29 +196,69 @@ func TestCreateBattle(t *testing.T) {
 		if err != nil {
 			t.Fatalf("error making request to server. Err: %v", err)
 		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
+		}
 
-		if resp.StatusCode != http.StatusInternalServerError {
-			t.Errorf("expected status 500; got %v", resp.Status)
+		var battle models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battle)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if battle.Settings.MaxExplosions != 0 {
+			t.Errorf("expected max explosions to be 0; got %v", battle.Settings.MaxExplosions)
+		}
+		if battle.Pokemon1Criticals != 0 || battle.Pokemon2Criticals != 0 {
+			t.Errorf("expected no critical hits; got %v and %v", battle.Pokemon1Criticals, battle.Pokemon2Criticals)
 		}
 	})
 
-	t.Run("error/pokemon-failed", func(t *testing.T) {
+	t.Run("success/dice", func(t *testing.T) {
 		s := New()
+
 		battleRoutes := s.App.Group("/battles")
 
-		// init the battle routes from a mock battle service that returns an error
-		// the pokemon service is mocked to not return an error
-		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: true}, diceSides: 6}
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
 		battleRoutes.Post("/", battleServer.CreateBattle)
 
-		battle := models.Battle{
-			Pokemon1ID: 1,
-			Pokemon2ID: 2,
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "dice": "2d6+3"}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
 		}
-		body, err := json.Marshal(battle)
+
+		resp, err := s.App.Test(req)
 		if err != nil {
-			t.Fatalf("error marshalling battle. Err: %v", err)
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
+		}
+
+		var battle models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battle)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
 		}
+		if battle.Settings.DiceExpression != "2d6+3" {
+			t.Errorf("expected dice expression to be 2d6+3; got %v", battle.Settings.DiceExpression)
+		}
+	})
+
+	t.Run("error/dice", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
+
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "dice": "2x6"}`)
 
 		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
@@ -153,22 +271,23 @@ func TestCreateBattle(t *testing.T) {
 			t.Fatalf("error making request to server. Err: %v", err)
 		}
 
-		if resp.StatusCode != http.StatusInternalServerError {
-			t.Errorf("expected status 500; got %v", resp.Status)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
 		}
 	})
-}
 
-func TestGetAllBattles(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
+	t.Run("success/condition", func(t *testing.T) {
 		s := New()
+
 		battleRoutes := s.App.Group("/battles")
 
-		// init the battle routes from a mock battle service that doesn't return an error
-		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
-		battleRoutes.Get("/", battleServer.GetAllBattles)
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
 
-		req, err := http.NewRequest("GET", "/battles", nil)
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "condition": "rain"}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			t.Fatalf("error creating request. Err: %v", err)
 		}
@@ -179,34 +298,59 @@ func TestGetAllBattles(t *testing.T) {
 		}
 		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			t.Errorf("expected status OK; got %v", resp.Status)
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		var battle models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battle)
 		if err != nil {
-			t.Fatalf("error reading response body. Err: %v", err)
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if battle.Settings.Condition != models.ConditionRain {
+			t.Errorf("expected condition to be rain; got %v", battle.Settings.Condition)
+		}
+		if len(battle.Conditions) != 1 || battle.Conditions[0].Turns != battle.Turns {
+			t.Errorf("expected the rain to last the whole battle; got %+v", battle.Conditions)
 		}
+	})
 
-		var battles []models.Battle
-		err = json.Unmarshal(body, &battles)
+	t.Run("error/condition", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
+
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "condition": "fog"}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
-			t.Fatalf("error unmarshalling response body. Err: %v", err)
+			t.Fatalf("error creating request. Err: %v", err)
 		}
-		if len(battles) != 2 {
-			t.Errorf("expected 2 battles; got %v", len(battles))
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
 		}
 	})
 
-	t.Run("error", func(t *testing.T) {
+	t.Run("error/max-explosions", func(t *testing.T) {
 		s := New()
 		battleRoutes := s.App.Group("/battles")
 
-		// init the battle routes from a mock battle service that returns an error
-		battleServer := battleServer{srv: &mockBattleService{hasError: true}}
-		battleRoutes.Get("/", battleServer.GetAllBattles)
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
 
-		req, err := http.NewRequest("GET", "/battles", nil)
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "max_explosions": -1}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			t.Fatalf("error creating request. Err: %v", err)
 		}
@@ -216,22 +360,49 @@ func TestGetAllBattles(t *testing.T) {
 			t.Fatalf("error making request to server. Err: %v", err)
 		}
 
-		if resp.StatusCode != http.StatusInternalServerError {
-			t.Errorf("expected status 500; got %v", resp.Status)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
 		}
 	})
-}
 
-func TestGetBattleByID(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
+	t.Run("error/max-turns", func(t *testing.T) {
 		s := New()
 		battleRoutes := s.App.Group("/battles")
 
-		// init the battle routes from a mock battle service that doesn't return an error
-		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
-		battleRoutes.Get("/:id", battleServer.GetBattleByID)
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6, maxTurns: 100}
+		battleRoutes.Post("/", battleServer.CreateBattle)
 
-		req, err := http.NewRequest("GET", "/battles/1", nil)
+		// the request cannot raise the maximum number of turns of the server
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "max_turns": 101}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+
+	t.Run("success/teams", func(t *testing.T) {
+		s := New()
+
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
+
+		body := []byte(`{"team1": [1, 2, 3], "team2": [4, 5, 6], "switch_strategy1": "type-advantage"}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			t.Fatalf("error creating request. Err: %v", err)
 		}
@@ -242,20 +413,626 @@ func TestGetBattleByID(t *testing.T) {
 		}
 		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			t.Errorf("expected status OK; got %v", resp.Status)
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
+		}
+
+		var battle models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battle)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(battle.Team1) != 3 || len(battle.Team2) != 3 {
+			t.Errorf("expected two teams of 3 pokemon; got %v and %v", battle.Team1, battle.Team2)
+		}
+		if battle.Settings.SwitchStrategy1 != "type-advantage" {
+			t.Errorf("expected switch strategy to be type-advantage; got %v", battle.Settings.SwitchStrategy1)
 		}
 	})
 
-	t.Run("error", func(t *testing.T) {
+	t.Run("success/strategies", func(t *testing.T) {
 		s := New()
+
 		battleRoutes := s.App.Group("/battles")
 
-		// init the battle routes from a mock battle service that returns an error
-		battleServer := battleServer{srv: &mockBattleService{hasError: true}}
-		battleRoutes.Get("/:id", battleServer.GetBattleByID)
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
 
-		req, err := http.NewRequest("GET", "/battles/1", nil)
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "strategy1": "greedy", "strategy2": "minimax"}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
+		}
+
+		var battle models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battle)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if battle.Settings.Strategy1 != "greedy" || battle.Settings.Strategy2 != "minimax" {
+			t.Errorf("expected strategies to be greedy and minimax; got %v and %v", battle.Settings.Strategy1, battle.Settings.Strategy2)
+		}
+	})
+
+	t.Run("success/doubles", func(t *testing.T) {
+		s := New()
+
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
+
+		body := []byte(`{"team1": [1, 2], "team2": [3, 4], "format": "doubles"}`)
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
+		}
+
+		var battle models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battle)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if battle.Format != models.FormatDoubles {
+			t.Errorf("expected format to be doubles; got %v", battle.Format)
+		}
+	})
+
+	t.Run("error/teams", func(t *testing.T) {
+		for _, body := range []string{
+			`{"team1": [1, 2, 3, 4, 5, 6, 7], "team2": [8]}`,
+			`{"team1": [1, 2, 3], "team2": [3, 4, 5]}`,
+			`{"team1": [1, 2, 3]}`,
+			`{"team1": [1], "team2": [2], "switch_strategy2": "unknown"}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "switch_strategy1": "type-advantage"}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "format": "doubles"}`,
+			`{"team1": [1, 2], "team2": [3, 4], "format": "triples"}`,
+			`{"team1": [1, 2], "team2": [3, 4], "format": "doubles", "switch_strategy1": "type-advantage"}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "strategy1": "unknown"}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "strategy2": "minimax-9"}`,
+			`{"team1": [1, 2], "team2": [3, 4], "format": "doubles", "strategy1": "greedy"}`,
+		} {
+			s := New()
+			battleRoutes := s.App.Group("/battles")
+
+			battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+			battleRoutes.Post("/", battleServer.CreateBattle)
+
+			req, err := http.NewRequest("POST", "/battles", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected status 400 for %s; got %v", body, resp.Status)
+			}
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that returns an error
+		// the pokemon service is mocked to not return an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: true}, pokemonSrv: &mockPokemonService{hasError: false}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
+
+		battle := models.Battle{
+			Pokemon1ID: 1,
+			Pokemon2ID: 2,
+		}
+		body, err := json.Marshal(battle)
+		if err != nil {
+			t.Fatalf("error marshalling battle. Err: %v", err)
+		}
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error/pokemon-failed", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that returns an error
+		// the pokemon service is mocked to not return an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}, pokemonSrv: &mockPokemonService{hasError: true}, diceSides: 6}
+		battleRoutes.Post("/", battleServer.CreateBattle)
+
+		battle := models.Battle{
+			Pokemon1ID: 1,
+			Pokemon2ID: 2,
+		}
+		body, err := json.Marshal(battle)
+		if err != nil {
+			t.Fatalf("error marshalling battle. Err: %v", err)
+		}
+
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+}
+
+func TestGetAllBattles(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that doesn't return an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("error reading response body. Err: %v", err)
+		}
+
+		var battles []models.Battle
+		err = json.Unmarshal(body, &battles)
+		if err != nil {
+			t.Fatalf("error unmarshalling response body. Err: %v", err)
+		}
+		if len(battles) != 2 {
+			t.Errorf("expected 2 battles; got %v", len(battles))
+		}
+	})
+
+	t.Run("success/draw", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles?draw=true", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var battles []models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battles)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(battles) != 1 || !battles[0].IsDraw() {
+			t.Errorf("expected 1 draw; got %+v", battles)
+		}
+	})
+
+	t.Run("success/format", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles?format=doubles", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var battles []models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battles)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(battles) != 1 || battles[0].Format != models.FormatDoubles {
+			t.Errorf("expected 1 double battle; got %+v", battles)
+		}
+	})
+
+	t.Run("error/invalid-format", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles?format=triples", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+
+	t.Run("success/condition", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles?condition=rain", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var battles []models.Battle
+		err = json.NewDecoder(resp.Body).Decode(&battles)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(battles) != 1 || battles[0].ID != 3 {
+			t.Errorf("expected the rainy battle 3; got %+v", battles)
+		}
+	})
+
+	t.Run("error/invalid-condition", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles?condition=fog", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error/invalid-draw", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles?draw=maybe", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that returns an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: true}}
+		battleRoutes.Get("/", battleServer.GetAllBattles)
+
+		req, err := http.NewRequest("GET", "/battles", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+}
+
+func TestGetBattleByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that doesn't return an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/:id", battleServer.GetBattleByID)
+
+		req, err := http.NewRequest("GET", "/battles/1", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that returns an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: true}}
+		battleRoutes.Get("/:id", battleServer.GetBattleByID)
+
+		req, err := http.NewRequest("GET", "/battles/1", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+}
+
+func TestGetBattleLog(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that doesn't return an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/:id/log", battleServer.GetBattleLog)
+
+		req, err := http.NewRequest("GET", "/battles/1/log", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var events []models.BattleEvent
+		err = json.NewDecoder(resp.Body).Decode(&events)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(events) != 2 {
+			t.Errorf("expected 2 events; got %v", len(events))
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that returns an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: true}}
+		battleRoutes.Get("/:id/log", battleServer.GetBattleLog)
+
+		req, err := http.NewRequest("GET", "/battles/1/log", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error/invalid-id", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Get("/:id/log", battleServer.GetBattleLog)
+
+		req, err := http.NewRequest("GET", "/battles/abc/log", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+}
+
+func TestReplayBattle(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		pokemon1 := models.Pokemon{ID: 1, Name: "Pikachu", Type: "Electric", HP: 100, Attack: 55, Defense: 40}
+		pokemon2 := models.Pokemon{ID: 2, Name: "Charmander", Type: "Fire", HP: 90, Attack: 62, Defense: 58}
+		stored := business.Fight(6, pokemon1, pokemon2)
+		stored.ID = 1
+
+		// init the battle routes from a mock battle service that returns a replayable battle
+		battleServer := battleServer{srv: &mockBattleService{hasError: false, stored: &stored}}
+		battleRoutes.Post("/:id/replay", battleServer.ReplayBattle)
+
+		req, err := http.NewRequest("POST", "/battles/1/replay", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var replay models.BattleReplay
+		err = json.NewDecoder(resp.Body).Decode(&replay)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if !replay.Matches {
+			t.Errorf("expected replay to match; got %+v", replay)
+		}
+	})
+
+	t.Run("error/not-replayable", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// the default mock battle doesn't store the participants
+		battleServer := battleServer{srv: &mockBattleService{hasError: false}}
+		battleRoutes.Post("/:id/replay", battleServer.ReplayBattle)
+
+		req, err := http.NewRequest("POST", "/battles/1/replay", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+		battleRoutes := s.App.Group("/battles")
+
+		// init the battle routes from a mock battle service that returns an error
+		battleServer := battleServer{srv: &mockBattleService{hasError: true}}
+		battleRoutes.Post("/:id/replay", battleServer.ReplayBattle)
+
+		req, err := http.NewRequest("POST", "/battles/1/replay", nil)
 		if err != nil {
 			t.Fatalf("error creating request. Err: %v", err)
 		}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/business"
+)
+
+// diceServer is used to handle the dice routes.
+type diceServer struct{}
+
+type diceRollRequest struct {
+	Expression string `json:"expression"`     // dice expression, e.g. "2d6+3" or "4d6kh3"
+	Seed       *int64 `json:"seed,omitempty"` // optional, to reproduce a roll
+}
+
+// RollDice rolls a dice expression, returning the individual rolls and the total.
+func (s *diceServer) RollDice(c *fiber.Ctx) error {
+	var req diceRollRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	dice, err := business.ParseDice(req.Expression)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if req.Seed != nil {
+		dice.Rand = business.NewRandomizer(*req.Seed)
+	}
+
+	dice.Roll()
+	return c.JSON(dice.LastRoll())
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+func TestRollDice(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := New()
+
+		diceServer := diceServer{}
+		s.App.Post("/dice/roll", diceServer.RollDice)
+
+		body := []byte(`{"expression": "4d6kh3+2", "seed": 42}`)
+
+		req, err := http.NewRequest("POST", "/dice/roll", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var roll models.DiceRoll
+		err = json.NewDecoder(resp.Body).Decode(&roll)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if roll.Expression != "4d6kh3+2" {
+			t.Errorf("expected expression to be 4d6kh3+2; got %v", roll.Expression)
+		}
+		if len(roll.Terms) != 2 || len(roll.Terms[0].Rolls) != 4 {
+			t.Fatalf("expected 2 terms with 4 rolls in the first one; got %+v", roll.Terms)
+		}
+		if roll.Total < 5 || roll.Total > 20 {
+			t.Errorf("expected total to be between 5 and 20; got %v", roll.Total)
+		}
+	})
+
+	t.Run("error/invalid-expression", func(t *testing.T) {
+		s := New()
+
+		diceServer := diceServer{}
+		s.App.Post("/dice/roll", diceServer.RollDice)
+
+		body := []byte(`{"expression": "2d6+"}`)
+
+		req, err := http.NewRequest("POST", "/dice/roll", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+}
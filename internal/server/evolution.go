@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+// evolutionServer is used to handle the evolution routes.
+// The wins of a pokemon are counted from its stored battles.
+type evolutionServer struct {
+	srv        database.EvolutionService
+	pokemonSrv database.PokemonCRUDService
+	battleSrv  database.BattleCRUDService
+
+	// matchups is triggered to compute the matchup matrix again when a pokemon evolves
+	matchups *matchupJob
+}
+
+type evolveRequest struct {
+	// ToPokemonID chooses the evolution when the species has several, optional
+	ToPokemonID int `json:"to_pokemon_id,omitempty"`
+}
+
+func (s *evolutionServer) CreateEvolution(c *fiber.Ctx) error {
+	ctx := context.Background()
+	var evolution models.Evolution
+	if err := c.BodyParser(&evolution); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if err := evolution.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err := s.srv.Create(ctx, &evolution)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(evolution)
+}
+
+func (s *evolutionServer) GetAllEvolutions(c *fiber.Ctx) error {
+	ctx := context.Background()
+	evolutions, err := s.srv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(evolutions)
+}
+
+func (s *evolutionServer) DeleteEvolution(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	err = s.srv.Delete(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetPokemonEvolutions returns the evolutions available to the species of a pokemon
+func (s *evolutionServer) GetPokemonEvolutions(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	pokemon, err := s.pokemonSrv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	evolutions, err := s.srv.GetBySpecies(ctx, pokemon.Name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(evolutions)
+}
+
+// EvolvePokemon evolves a pokemon into the next species of its evolution chain,
+// if it meets the condition of the evolution. The pokemon keeps its ID, so its
+// battles are kept. The body is optional and chooses the evolution when the
+// species has several.
+func (s *evolutionServer) EvolvePokemon(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req evolveRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+		}
+	}
+
+	pokemon, err := s.pokemonSrv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	evolutions, err := s.srv.GetBySpecies(ctx, pokemon.Name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	evolution, ok := chooseEvolution(evolutions, req.ToPokemonID)
+	if !ok {
+		if len(evolutions) > 1 && req.ToPokemonID == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Pokemon has several evolutions, choose one with to_pokemon_id"})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Pokemon cannot evolve"})
+	}
+
+	target, err := s.pokemonSrv.GetByID(ctx, evolution.ToPokemonID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	wins, err := s.battleSrv.Find(ctx, database.BattleFilter{WinnerID: pokemon.ID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	evolved, err := business.Evolve(pokemon, target, evolution, len(wins))
+	if errors.Is(err, business.ErrEvolutionNotReady) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = s.pokemonSrv.Update(ctx, evolved)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// the stats changed, so the matchups of the pokemon are outdated
+	s.matchups.Trigger()
+	return c.JSON(evolved)
+}
+
+// chooseEvolution returns the evolution into the given entry, or the only
+// evolution of the species if no entry is given
+func chooseEvolution(evolutions []models.Evolution, toPokemonID int) (models.Evolution, bool) {
+	if toPokemonID == 0 {
+		if len(evolutions) != 1 {
+			return models.Evolution{}, false
+		}
+		return evolutions[0], true
+	}
+
+	for _, evolution := range evolutions {
+		if evolution.ToPokemonID == toPokemonID {
+			return evolution, true
+		}
+	}
+	return models.Evolution{}, false
+}
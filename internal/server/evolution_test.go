@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+// mockEvolutionService is used for testing the evolution routes
+// including the ability to return an error so we can test error handling.
+// Every species has the given evolutions.
+type mockEvolutionService struct {
+	hasError   bool
+	evolutions []models.Evolution
+}
+
+func (m *mockEvolutionService) Create(ctx context.Context, evolution *models.Evolution) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	evolution.ID = 1
+	return nil
+}
+
+func (m *mockEvolutionService) Delete(ctx context.Context, id int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+func (m *mockEvolutionService) GetAll(ctx context.Context) ([]models.Evolution, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return m.evolutions, nil
+}
+
+func (m *mockEvolutionService) GetBySpecies(ctx context.Context, name string) ([]models.Evolution, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return m.evolutions, nil
+}
+
+func TestEvolutionRoutes(t *testing.T) {
+	// the pokemon 1 won a single battle in the mock battle service
+	byWins := models.Evolution{ID: 1, FromPokemonID: 1, ToPokemonID: 14, MinWins: 1}
+	byLevel := models.Evolution{ID: 2, FromPokemonID: 1, ToPokemonID: 26, MinLevel: 30}
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     *mockEvolutionService
+		expected int
+	}{
+		{name: "create/success", method: "POST", path: "/evolutions", body: `{"from_pokemon_id": 2, "to_pokemon_id": 10, "min_level": 36}`, mock: &mockEvolutionService{}, expected: http.StatusCreated},
+		{name: "create/no-condition", method: "POST", path: "/evolutions", body: `{"from_pokemon_id": 2, "to_pokemon_id": 10}`, mock: &mockEvolutionService{}, expected: http.StatusBadRequest},
+		{name: "create/same-pokemon", method: "POST", path: "/evolutions", body: `{"from_pokemon_id": 2, "to_pokemon_id": 2, "min_wins": 1}`, mock: &mockEvolutionService{}, expected: http.StatusBadRequest},
+		{name: "create/error", method: "POST", path: "/evolutions", body: `{"from_pokemon_id": 2, "to_pokemon_id": 10, "min_level": 36}`, mock: &mockEvolutionService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get-all/success", method: "GET", path: "/evolutions", mock: &mockEvolutionService{}, expected: http.StatusOK},
+		{name: "get-all/error", method: "GET", path: "/evolutions", mock: &mockEvolutionService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "delete/success", method: "DELETE", path: "/evolutions/1", mock: &mockEvolutionService{}, expected: http.StatusNoContent},
+		{name: "delete/invalid-id", method: "DELETE", path: "/evolutions/abc", mock: &mockEvolutionService{}, expected: http.StatusBadRequest},
+		{name: "pokemon/success", method: "GET", path: "/pokemons/1/evolutions", mock: &mockEvolutionService{evolutions: []models.Evolution{byWins}}, expected: http.StatusOK},
+		{name: "pokemon/error", method: "GET", path: "/pokemons/1/evolutions", mock: &mockEvolutionService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "evolve/success", method: "POST", path: "/pokemons/1/evolve", mock: &mockEvolutionService{evolutions: []models.Evolution{byWins}}, expected: http.StatusOK},
+		{name: "evolve/chosen", method: "POST", path: "/pokemons/1/evolve", body: `{"to_pokemon_id": 14}`, mock: &mockEvolutionService{evolutions: []models.Evolution{byWins, byLevel}}, expected: http.StatusOK},
+		{name: "evolve/not-ready", method: "POST", path: "/pokemons/1/evolve", body: `{"to_pokemon_id": 26}`, mock: &mockEvolutionService{evolutions: []models.Evolution{byWins, byLevel}}, expected: http.StatusConflict},
+		{name: "evolve/ambiguous", method: "POST", path: "/pokemons/1/evolve", mock: &mockEvolutionService{evolutions: []models.Evolution{byWins, byLevel}}, expected: http.StatusBadRequest},
+		{name: "evolve/no-evolution", method: "POST", path: "/pokemons/1/evolve", mock: &mockEvolutionService{}, expected: http.StatusBadRequest},
+		{name: "evolve/invalid-id", method: "POST", path: "/pokemons/abc/evolve", mock: &mockEvolutionService{}, expected: http.StatusBadRequest},
+		{name: "evolve/error", method: "POST", path: "/pokemons/1/evolve", mock: &mockEvolutionService{hasError: true}, expected: http.StatusInternalServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+
+			evolutionServer := evolutionServer{
+				srv:        testCase.mock,
+				pokemonSrv: &mockPokemonService{hasError: false},
+				battleSrv:  &mockBattleService{hasError: false},
+			}
+			s.App.Post("/evolutions", evolutionServer.CreateEvolution)
+			s.App.Get("/evolutions", evolutionServer.GetAllEvolutions)
+			s.App.Delete("/evolutions/:id", evolutionServer.DeleteEvolution)
+			s.App.Get("/pokemons/:id/evolutions", evolutionServer.GetPokemonEvolutions)
+			s.App.Post("/pokemons/:id/evolve", evolutionServer.EvolvePokemon)
+
+			req, err := http.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+}
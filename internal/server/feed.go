@@ -0,0 +1,226 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+const (
+	// feedHistory is the number of battles kept to resume the feed
+	feedHistory = 100
+
+	// feedKeepAlive is the interval between the comments sent to idle clients,
+	// which detect the disconnected ones
+	feedKeepAlive = 15 * time.Second
+)
+
+// feedEvent is a battle of the feed, with the ID of its event
+type feedEvent struct {
+	id     int
+	battle models.Battle
+}
+
+// battleFeed broadcasts the newly created battles to the clients of the battle
+// stream. The events are numbered in publication order, and the last ones are
+// kept so a client can resume the feed from the last event it received.
+// The numbering restarts with the server.
+//
+// All its methods can be called on a nil feed, which has no clients.
+type battleFeed struct {
+	mu          sync.Mutex
+	lastID      int
+	history     []feedEvent
+	subscribers map[chan feedEvent]struct{}
+	closed      bool
+}
+
+func newBattleFeed() *battleFeed {
+	return &battleFeed{
+		subscribers: make(map[chan feedEvent]struct{}),
+	}
+}
+
+// createBattle stores a battle with the battle service and publishes it to the feed.
+// Every route that creates a battle goes through it, so the feed never misses one.
+func createBattle(ctx context.Context, srv database.BattleCRUDService, feed *battleFeed, battle *models.Battle) error {
+	if err := srv.Create(ctx, battle); err != nil {
+		return err
+	}
+	feed.publish(*battle)
+	return nil
+}
+
+// publish sends a battle to the clients of the feed. It never blocks:
+// the clients that cannot keep up are disconnected instead.
+func (f *battleFeed) publish(battle models.Battle) {
+	if f == nil {
+		return
+	}
+
+	// the clients receive the battles like in the list of battles, without their log
+	battle.Log = nil
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return
+	}
+
+	f.lastID++
+	event := feedEvent{id: f.lastID, battle: battle}
+	f.history = append(f.history, event)
+	if len(f.history) > feedHistory {
+		f.history = f.history[len(f.history)-feedHistory:]
+	}
+
+	for subscriber := range f.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			f.remove(subscriber)
+		}
+	}
+}
+
+// subscribe returns the kept events after lastID, the channel that receives the
+// next ones and the function that cancels the subscription. The channel is
+// closed when the subscription is cancelled, the client falls too far behind
+// or the feed is closed. A lastID of 0, or one the feed does not know, resumes
+// nothing.
+func (f *battleFeed) subscribe(lastID int) ([]feedEvent, <-chan feedEvent, func()) {
+	events := make(chan feedEvent, streamBuffer)
+	if f == nil {
+		close(events)
+		return nil, events, func() {}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		close(events)
+		return nil, events, func() {}
+	}
+	f.subscribers[events] = struct{}{}
+
+	var missed []feedEvent
+	if lastID > 0 && lastID < f.lastID {
+		for _, event := range f.history {
+			if event.id > lastID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	return missed, events, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.remove(events)
+	}
+}
+
+// close disconnects all the clients of the feed, e.g. when the server shuts down
+func (f *battleFeed) close() {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	for subscriber := range f.subscribers {
+		f.remove(subscriber)
+	}
+}
+
+// remove closes the channel of a subscriber, if it is still subscribed.
+// It must be called with the lock held.
+func (f *battleFeed) remove(subscriber chan feedEvent) {
+	if _, ok := f.subscribers[subscriber]; !ok {
+		return
+	}
+	delete(f.subscribers, subscriber)
+	close(subscriber)
+}
+
+// writeFeedEvent writes a battle as a Server-Sent Event and flushes it
+func writeFeedEvent(w *bufio.Writer, event feedEvent) error {
+	data, err := json.Marshal(event.battle)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: battle\ndata: %s\n\n", event.id, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// StreamBattles pushes the newly created battles as Server-Sent Events.
+// A client that reconnects with the Last-Event-ID header first receives
+// the battles it missed, as long as the feed still keeps them.
+func (s *battleServer) StreamBattles(c *fiber.Ctx) error {
+	lastID := 0
+	if header := c.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.Atoi(header)
+		if err != nil || id < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid Last-Event-ID"})
+		}
+		lastID = id
+	}
+
+	missed, events, unsubscribe := s.feed.subscribe(lastID)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		// the headers are only sent with the first bytes of the body
+		if _, err := w.WriteString(": connected\n\n"); err != nil {
+			return
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for _, event := range missed {
+			if err := writeFeedEvent(w, event); err != nil {
+				return
+			}
+		}
+
+		keepAlive := time.NewTicker(feedKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if err := writeFeedEvent(w, event); err != nil {
+					return
+				}
+			case <-keepAlive.C:
+				// a failed flush means that the client is gone
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	return nil
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+func TestBattleFeed(t *testing.T) {
+	t.Run("publish", func(t *testing.T) {
+		feed := newBattleFeed()
+
+		missed, events, unsubscribe := feed.subscribe(0)
+		defer unsubscribe()
+		if len(missed) != 0 {
+			t.Errorf("expected no missed battles; got %d", len(missed))
+		}
+
+		feed.publish(models.Battle{ID: 7, Log: []models.BattleEvent{{Turn: 1, Type: models.EventInitiative}}})
+		event := <-events
+		if event.id != 1 || event.battle.ID != 7 || event.battle.Log != nil {
+			t.Errorf("expected the first event with the battle without its log; got %+v", event)
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		feed := newBattleFeed()
+		for i := 1; i <= feedHistory+10; i++ {
+			feed.publish(models.Battle{ID: i})
+		}
+
+		missed, _, unsubscribe := feed.subscribe(feedHistory + 5)
+		defer unsubscribe()
+		if len(missed) != 5 || missed[0].id != feedHistory+6 {
+			t.Errorf("expected the 5 battles after the last event; got %+v", missed)
+		}
+
+		// only the last battles are kept
+		missed, _, unsubscribe = feed.subscribe(1)
+		defer unsubscribe()
+		if len(missed) != feedHistory || missed[0].id != 11 {
+			t.Errorf("expected the %d kept battles; got %d", feedHistory, len(missed))
+		}
+
+		// an unknown event, e.g. from before a restart, resumes nothing
+		missed, _, unsubscribe = feed.subscribe(1000)
+		defer unsubscribe()
+		if len(missed) != 0 {
+			t.Errorf("expected no missed battles; got %d", len(missed))
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		feed := newBattleFeed()
+
+		_, events, unsubscribe := feed.subscribe(0)
+		defer unsubscribe()
+		feed.close()
+		if _, ok := <-events; ok {
+			t.Error("expected the channel to be closed with the feed")
+		}
+
+		// a closed feed ignores the battles and the new clients
+		feed.publish(models.Battle{ID: 1})
+		if feed.lastID != 0 {
+			t.Errorf("expected no events after closing the feed; got %d", feed.lastID)
+		}
+		_, events, _ = feed.subscribe(0)
+		if _, ok := <-events; ok {
+			t.Error("expected the channel of a closed feed to be closed")
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var feed *battleFeed
+
+		feed.publish(models.Battle{ID: 1})
+		feed.close()
+		_, events, unsubscribe := feed.subscribe(0)
+		defer unsubscribe()
+		if _, ok := <-events; ok {
+			t.Error("expected the channel of a nil feed to be closed")
+		}
+	})
+}
+
+func TestStreamBattles(t *testing.T) {
+	s := New()
+
+	battleServer := battleServer{
+		srv:        &mockBattleService{hasError: false},
+		pokemonSrv: &mockPokemonService{hasError: false},
+		diceSides:  6,
+		maxTurns:   100,
+		feed:       s.battleFeed,
+	}
+	s.App.Post("/battles", battleServer.CreateBattle)
+	s.App.Get("/battles/stream", battleServer.StreamBattles)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening. Err: %v", err)
+	}
+	go s.App.Listener(ln)
+	defer s.ShutdownWithContext(context.Background())
+
+	createBattle := func(t *testing.T) {
+		t.Helper()
+		req, err := http.NewRequest("POST", "/battles", bytes.NewBufferString(`{"pokemon1_id": 1, "pokemon2_id": 2}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status Created; got %v", resp.Status)
+		}
+	}
+
+	connect := func(t *testing.T, lastEventID string) (*http.Response, *bufio.Reader) {
+		t.Helper()
+		req, err := http.NewRequest("GET", "http://"+ln.Addr().String()+"/battles/stream", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		return resp, bufio.NewReader(resp.Body)
+	}
+
+	// readEvent returns the lines of the next event, without the blank line that
+	// ends it and skipping the comments
+	readEvent := func(t *testing.T, r *bufio.Reader) []string {
+		t.Helper()
+		var lines []string
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatalf("error reading event. Err: %v", err)
+			}
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" && len(lines) > 0 {
+				return lines
+			}
+			if line != "" && !strings.HasPrefix(line, ":") {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	t.Run("live", func(t *testing.T) {
+		resp, r := connect(t, "")
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+			t.Fatalf("expected an event stream; got %v %s", resp.Status, resp.Header.Get("Content-Type"))
+		}
+
+		createBattle(t)
+		lines := readEvent(t, r)
+		if len(lines) != 3 || lines[0] != "id: 1" || lines[1] != "event: battle" || !strings.HasPrefix(lines[2], `data: {"id":1,`) {
+			t.Errorf("expected the created battle; got %q", lines)
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		createBattle(t)
+		createBattle(t)
+
+		resp, r := connect(t, "1")
+		defer resp.Body.Close()
+		for _, id := range []string{"id: 2", "id: 3"} {
+			if lines := readEvent(t, r); len(lines) == 0 || lines[0] != id {
+				t.Errorf("expected the missed event %q; got %q", id, lines)
+			}
+		}
+	})
+
+	t.Run("invalid-last-event-id", func(t *testing.T) {
+		resp, _ := connect(t, "abc")
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status %d; got %v", http.StatusBadRequest, resp.Status)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+// itemServer is used to handle the routes of the items catalog.
+// It receives a database.ItemCRUDService and uses it to handle the routes.
+type itemServer struct {
+	srv database.ItemCRUDService
+}
+
+type itemRequest struct {
+	Name       string `json:"name"`
+	Effect     string `json:"effect"` // heal, regen, attack-boost or cure
+	Value      int    `json:"value"`
+	Threshold  int    `json:"threshold"`
+	Consumable bool   `json:"consumable"`
+}
+
+func (s *itemServer) CreateItem(c *fiber.Ctx) error {
+	ctx := context.Background()
+	var req itemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	item := models.Item{
+		Name:       req.Name,
+		Effect:     req.Effect,
+		Value:      req.Value,
+		Threshold:  req.Threshold,
+		Consumable: req.Consumable,
+	}
+	if err := item.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err := s.srv.Create(ctx, &item)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+func (s *itemServer) GetAllItems(c *fiber.Ctx) error {
+	ctx := context.Background()
+	items, err := s.srv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(items)
+}
+
+func (s *itemServer) GetItemByID(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	item, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(item)
+}
+
+func (s *itemServer) UpdateItem(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var item models.Item
+	if err := c.BodyParser(&item); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+	item.ID = id
+
+	if err := item.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = s.srv.Update(ctx, item)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(item)
+}
+
+func (s *itemServer) DeleteItem(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	err = s.srv.Delete(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// heldItemServer is used to handle the routes of the item held by each pokemon.
+// It receives a database.HeldItemService and uses it to handle the routes.
+type heldItemServer struct {
+	srv database.HeldItemService
+
+	// matchups is triggered to compute the matchup matrix again when the
+	// item of a pokemon changes, it can be nil
+	matchups *matchupJob
+}
+
+type giveItemRequest struct {
+	ItemID int `json:"item_id"`
+}
+
+func (s *heldItemServer) GetPokemonItem(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	item, err := s.srv.GetItem(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Pokemon holds no item"})
+	} else if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(item)
+}
+
+// GivePokemonItem makes a pokemon hold an item of the catalog, replacing the one it held
+func (s *heldItemServer) GivePokemonItem(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req giveItemRequest
+	if err := c.BodyParser(&req); err != nil || req.ItemID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	err = s.srv.Give(ctx, id, req.ItemID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	item, err := s.srv.GetItem(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	s.matchups.Trigger()
+	return c.JSON(item)
+}
+
+func (s *heldItemServer) TakePokemonItem(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	err = s.srv.Take(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	s.matchups.Trigger()
+	return c.SendStatus(fiber.StatusNoContent)
+}
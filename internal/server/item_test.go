@@ -0,0 +1,194 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+// mockItemService is used for testing the item routes
+// including the ability to return an error so we can test error handling
+type mockItemService struct {
+	hasError bool
+}
+
+func (m *mockItemService) Create(ctx context.Context, item *models.Item) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	item.ID = 1
+	return nil
+}
+
+func (m *mockItemService) Delete(ctx context.Context, id int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+func (m *mockItemService) GetAll(ctx context.Context) ([]models.Item, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return []models.Item{{ID: 1, Name: "Potion", Effect: models.ItemEffectHeal, Value: 20, Threshold: 50, Consumable: true}}, nil
+}
+
+func (m *mockItemService) GetByID(ctx context.Context, id int) (models.Item, error) {
+	if m.hasError {
+		return models.Item{}, errors.New("mock error")
+	}
+	return models.Item{ID: id, Name: "Potion", Effect: models.ItemEffectHeal, Value: 20, Threshold: 50, Consumable: true}, nil
+}
+
+func (m *mockItemService) Update(ctx context.Context, item models.Item) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+// mockHeldItemService is used for testing the held item routes.
+// The pokemon holds the given item, or none if it is nil.
+type mockHeldItemService struct {
+	hasError bool
+	item     *models.Item
+}
+
+func (m *mockHeldItemService) GetItem(ctx context.Context, pokemonID int) (models.Item, error) {
+	if m.hasError {
+		return models.Item{}, errors.New("mock error")
+	}
+	if m.item == nil {
+		return models.Item{}, sql.ErrNoRows
+	}
+	return *m.item, nil
+}
+
+func (m *mockHeldItemService) Give(ctx context.Context, pokemonID int, itemID int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	m.item = &models.Item{ID: itemID, Name: "Leftovers", Effect: models.ItemEffectRegen, Value: 6}
+	return nil
+}
+
+func (m *mockHeldItemService) Take(ctx context.Context, pokemonID int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	m.item = nil
+	return nil
+}
+
+func TestItemRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     *mockItemService
+		expected int
+	}{
+		{name: "create/success", method: "POST", path: "/items", body: `{"name": "Potion", "effect": "heal", "value": 20, "threshold": 50, "consumable": true}`, mock: &mockItemService{}, expected: http.StatusCreated},
+		{name: "create/unknown-effect", method: "POST", path: "/items", body: `{"name": "Mystery Box", "effect": "teleport", "value": 1}`, mock: &mockItemService{}, expected: http.StatusBadRequest},
+		{name: "create/no-threshold", method: "POST", path: "/items", body: `{"name": "Potion", "effect": "heal", "value": 20}`, mock: &mockItemService{}, expected: http.StatusBadRequest},
+		{name: "create/error", method: "POST", path: "/items", body: `{"name": "Leftovers", "effect": "regen", "value": 6}`, mock: &mockItemService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get-all/success", method: "GET", path: "/items", mock: &mockItemService{}, expected: http.StatusOK},
+		{name: "get-all/error", method: "GET", path: "/items", mock: &mockItemService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get-by-id/success", method: "GET", path: "/items/1", mock: &mockItemService{}, expected: http.StatusOK},
+		{name: "get-by-id/invalid-id", method: "GET", path: "/items/abc", mock: &mockItemService{}, expected: http.StatusBadRequest},
+		{name: "update/success", method: "PUT", path: "/items/1", body: `{"name": "Lum Berry", "effect": "cure", "consumable": true}`, mock: &mockItemService{}, expected: http.StatusOK},
+		{name: "update/invalid", method: "PUT", path: "/items/1", body: `{"name": "Choice Band", "effect": "attack-boost"}`, mock: &mockItemService{}, expected: http.StatusBadRequest},
+		{name: "update/error", method: "PUT", path: "/items/1", body: `{"name": "Lum Berry", "effect": "cure"}`, mock: &mockItemService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "delete/success", method: "DELETE", path: "/items/1", mock: &mockItemService{}, expected: http.StatusNoContent},
+		{name: "delete/invalid-id", method: "DELETE", path: "/items/abc", mock: &mockItemService{}, expected: http.StatusBadRequest},
+		{name: "delete/error", method: "DELETE", path: "/items/1", mock: &mockItemService{hasError: true}, expected: http.StatusInternalServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+
+			itemServer := itemServer{srv: testCase.mock}
+			s.App.Post("/items", itemServer.CreateItem)
+			s.App.Get("/items", itemServer.GetAllItems)
+			s.App.Get("/items/:id", itemServer.GetItemByID)
+			s.App.Put("/items/:id", itemServer.UpdateItem)
+			s.App.Delete("/items/:id", itemServer.DeleteItem)
+
+			req, err := http.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+}
+
+func TestHeldItemRoutes(t *testing.T) {
+	leftovers := &models.Item{ID: 3, Name: "Leftovers", Effect: models.ItemEffectRegen, Value: 6}
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     *mockHeldItemService
+		expected int
+	}{
+		{name: "get/success", method: "GET", path: "/pokemons/1/item", mock: &mockHeldItemService{item: leftovers}, expected: http.StatusOK},
+		{name: "get/no-item", method: "GET", path: "/pokemons/1/item", mock: &mockHeldItemService{}, expected: http.StatusNotFound},
+		{name: "get/invalid-id", method: "GET", path: "/pokemons/abc/item", mock: &mockHeldItemService{}, expected: http.StatusBadRequest},
+		{name: "get/error", method: "GET", path: "/pokemons/1/item", mock: &mockHeldItemService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "give/success", method: "PUT", path: "/pokemons/1/item", body: `{"item_id": 3}`, mock: &mockHeldItemService{}, expected: http.StatusOK},
+		{name: "give/no-item", method: "PUT", path: "/pokemons/1/item", body: `{}`, mock: &mockHeldItemService{}, expected: http.StatusBadRequest},
+		{name: "give/invalid-id", method: "PUT", path: "/pokemons/abc/item", body: `{"item_id": 3}`, mock: &mockHeldItemService{}, expected: http.StatusBadRequest},
+		{name: "give/error", method: "PUT", path: "/pokemons/1/item", body: `{"item_id": 3}`, mock: &mockHeldItemService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "take/success", method: "DELETE", path: "/pokemons/1/item", mock: &mockHeldItemService{item: leftovers}, expected: http.StatusNoContent},
+		{name: "take/invalid-id", method: "DELETE", path: "/pokemons/abc/item", mock: &mockHeldItemService{}, expected: http.StatusBadRequest},
+		{name: "take/error", method: "DELETE", path: "/pokemons/1/item", mock: &mockHeldItemService{hasError: true}, expected: http.StatusInternalServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+
+			heldItemServer := heldItemServer{srv: testCase.mock}
+			s.App.Get("/pokemons/:id/item", heldItemServer.GetPokemonItem)
+			s.App.Put("/pokemons/:id/item", heldItemServer.GivePokemonItem)
+			s.App.Delete("/pokemons/:id/item", heldItemServer.TakePokemonItem)
+
+			req, err := http.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+}
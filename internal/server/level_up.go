@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/database"
+)
+
+// levelUpServer is used to handle the level-up routes.
+// The level-ups are inserted by the battle service when a battle grants experience.
+type levelUpServer struct {
+	srv database.LevelUpService
+}
+
+// GetPokemonLevelUps returns the level-ups of a pokemon, from the oldest to the newest
+func (s *levelUpServer) GetPokemonLevelUps(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	levelUps, err := s.srv.GetByPokemonID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(levelUps)
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+// mockLevelUpService is used for testing the level-up routes
+// including the ability to return an error so we can test error handling
+type mockLevelUpService struct {
+	hasError bool
+}
+
+func (m *mockLevelUpService) GetByPokemonID(ctx context.Context, pokemonID int) ([]models.LevelUp, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return []models.LevelUp{
+		{PokemonID: pokemonID, BattleID: 1, Level: 2, HP: 37, Attack: 57, Defense: 41},
+	}, nil
+}
+
+func TestLevelUpRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		mock     *mockLevelUpService
+		expected int
+	}{
+		{name: "success", path: "/pokemons/1/level-ups", mock: &mockLevelUpService{}, expected: http.StatusOK},
+		{name: "error", path: "/pokemons/1/level-ups", mock: &mockLevelUpService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "invalid-id", path: "/pokemons/abc/level-ups", mock: &mockLevelUpService{}, expected: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+
+			levelUpServer := levelUpServer{srv: testCase.mock}
+			s.App.Get("/pokemons/:id/level-ups", levelUpServer.GetPokemonLevelUps)
+
+			req, err := http.NewRequest("GET", testCase.path, nil)
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+)
+
+// matchupJob computes the matchup matrix of all the pokemons in the background
+// and stores it. The computation runs once on start, if the matrix was never
+// computed, and again every time it is triggered, e.g. when a pokemon changes.
+// Triggers received during a computation are coalesced into a single one.
+//
+// All its methods can be called on a nil job, which does nothing.
+type matchupJob struct {
+	pokemonSrv     database.PokemonCRUDService
+	pokemonMoveSrv database.PokemonMoveService
+	srv            database.MatchupService
+	settings       fightSettings
+
+	// runs is the number of battles simulated for each pair of pokemons
+	runs int
+
+	// workers is the maximum number of pairs simulated at the same time
+	workers int
+
+	trigger   chan struct{}
+	computing atomic.Bool
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func newMatchupJob(pokemonSrv database.PokemonCRUDService, pokemonMoveSrv database.PokemonMoveService, srv database.MatchupService, settings fightSettings, runs int, workers int) *matchupJob {
+	return &matchupJob{
+		pokemonSrv:     pokemonSrv,
+		pokemonMoveSrv: pokemonMoveSrv,
+		srv:            srv,
+		settings:       settings,
+		runs:           runs,
+		workers:        workers,
+		trigger:        make(chan struct{}, 1),
+	}
+}
+
+// Start runs the job in a new goroutine until Stop is called
+func (j *matchupJob) Start() {
+	if j == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.done = make(chan struct{})
+
+	go j.loop(ctx)
+}
+
+// Stop cancels the running computation, if any, and waits for the job to finish
+func (j *matchupJob) Stop() {
+	if j == nil || j.cancel == nil {
+		return
+	}
+
+	j.cancel()
+	<-j.done
+}
+
+// Trigger asks the job to compute the matrix again. It never blocks.
+func (j *matchupJob) Trigger() {
+	if j == nil {
+		return
+	}
+
+	j.computing.Store(true)
+	select {
+	case j.trigger <- struct{}{}:
+	default:
+		// a computation is already pending
+	}
+}
+
+// Computing tells whether the matrix is being computed or pending to be computed
+func (j *matchupJob) Computing() bool {
+	if j == nil {
+		return false
+	}
+	return j.computing.Load()
+}
+
+func (j *matchupJob) loop(ctx context.Context) {
+	defer close(j.done)
+
+	// compute the matrix on start only if it was never computed
+	matchups, err := j.srv.GetAll(ctx)
+	if err != nil {
+		log.Printf("could not retrieve the matchups: %v", err)
+	} else if len(matchups) == 0 {
+		j.Trigger()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-j.trigger:
+			j.computing.Store(true)
+			if err := j.compute(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("could not compute the matchups: %v", err)
+			}
+			j.computing.Store(len(j.trigger) > 0)
+		}
+	}
+}
+
+// compute simulates the battles between every pair of pokemons, with their
+// moves, and replaces the stored matrix with the new one
+func (j *matchupJob) compute(ctx context.Context) error {
+	pokemons, err := j.pokemonSrv.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range pokemons {
+		moves, err := j.pokemonMoveSrv.GetMoves(ctx, pokemons[i].ID)
+		if err != nil {
+			return err
+		}
+		pokemons[i].Moves = moves
+	}
+
+	// the settings of the server without overrides are always valid
+	opts, err := (&fightRequest{}).options(j.settings)
+	if err != nil {
+		return err
+	}
+
+	matchups, err := business.ComputeMatchups(ctx, pokemons, j.runs, j.workers, j.settings.diceSides, opts...)
+	if err != nil {
+		return err
+	}
+	return j.srv.ReplaceAll(ctx, matchups)
+}
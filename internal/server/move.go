@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+// moveServer is used to handle the move routes.
+// It receives a database.MoveCRUDService and uses it to handle the routes.
+type moveServer struct {
+	srv database.MoveCRUDService
+}
+
+type moveRequest struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Power        int    `json:"power"`
+	Accuracy     int    `json:"accuracy"`
+	Category     string `json:"category"`
+	Effect       string `json:"effect"`
+	EffectChance int    `json:"effect_chance"`
+	Target       string `json:"target"`
+	Condition    string `json:"condition"`
+}
+
+func (s *moveServer) CreateMove(c *fiber.Ctx) error {
+	ctx := context.Background()
+	var req moveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	move := models.Move{
+		Name:         req.Name,
+		Type:         req.Type,
+		Power:        req.Power,
+		Accuracy:     req.Accuracy,
+		Category:     req.Category,
+		Effect:       req.Effect,
+		EffectChance: req.EffectChance,
+		Target:       req.Target,
+		Condition:    req.Condition,
+	}
+
+	err := s.srv.Create(ctx, &move)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(move)
+}
+
+func (s *moveServer) GetAllMoves(c *fiber.Ctx) error {
+	ctx := context.Background()
+	moves, err := s.srv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(moves)
+}
+
+func (s *moveServer) GetMoveByID(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	move, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(move)
+}
+
+func (s *moveServer) UpdateMove(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var move models.Move
+	if err := c.BodyParser(&move); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+	move.ID = id
+
+	err = s.srv.Update(ctx, move)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(move)
+}
+
+func (s *moveServer) DeleteMove(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	err = s.srv.Delete(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+func TestMove_IT(t *testing.T) {
+	s := New()
+
+	databaseSrv := MustNewWithDatabase(t)
+
+	s.RegisterFiberRoutes(Services{
+		Pokemons:     database.NewPokemonService(databaseSrv),
+		Battles:      database.NewBattleService(databaseSrv),
+		Moves:        database.NewMoveService(databaseSrv),
+		PokemonMoves: database.NewPokemonMoveService(databaseSrv),
+	})
+
+	t.Run("create-and-learn", func(t *testing.T) {
+		body, err := json.Marshal(moveRequest{Name: "Bubble Beam", Type: "Water", Power: 65, Accuracy: 100, Category: models.MoveCategorySpecial})
+		if err != nil {
+			t.Fatalf("error marshalling move request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(createAuthenticatedRequest(t, "POST", "/moves", body), -1) // disable timeout
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status Created; got %v", resp.Status)
+		}
+
+		var move models.Move
+		if err := json.NewDecoder(resp.Body).Decode(&move); err != nil {
+			t.Fatalf("error decoding response. Err: %v", err)
+		}
+
+		// Pikachu already knows 4 moves in the testdata, so it cannot learn another one
+		learnBody := []byte(`{"move_id": ` + strconv.Itoa(move.ID) + `}`)
+		resp, err = s.App.Test(createAuthenticatedRequest(t, "POST", "/pokemons/1/moves", learnBody), -1)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != http.StatusConflict {
+			t.Fatalf("expected status Conflict; got %v", resp.Status)
+		}
+
+		// Charizard doesn't know any move in the testdata
+		resp, err = s.App.Test(createAuthenticatedRequest(t, "POST", "/pokemons/10/moves", learnBody), -1)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status Created; got %v", resp.Status)
+		}
+
+		resp, err = s.App.Test(createAuthenticatedRequest(t, "GET", "/pokemons/10", nil), -1)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		var pokemon models.Pokemon
+		if err := json.NewDecoder(resp.Body).Decode(&pokemon); err != nil {
+			t.Fatalf("error decoding response. Err: %v", err)
+		}
+
+		if len(pokemon.Moves) != 1 || pokemon.Moves[0].ID != move.ID {
+			t.Errorf("expected Charizard to know move %d; got %+v", move.ID, pokemon.Moves)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/models"
+)
+
+// mockMoveService is used for testing the move routes
+// including the ability to return an error so we can test error handling
+type mockMoveService struct {
+	hasError bool
+}
+
+func (m *mockMoveService) Create(ctx context.Context, move *models.Move) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	move.ID = 1
+	return nil
+}
+
+func (m *mockMoveService) Delete(ctx context.Context, id int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+func (m *mockMoveService) GetAll(ctx context.Context) ([]models.Move, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return []models.Move{
+		{ID: 1, Name: "Tackle", Type: "Normal", Power: 40, Accuracy: 100, Category: models.MoveCategoryPhysical},
+		{ID: 2, Name: "Ember", Type: "Fire", Power: 40, Accuracy: 100, Category: models.MoveCategorySpecial},
+	}, nil
+}
+
+func (m *mockMoveService) GetByID(ctx context.Context, id int) (models.Move, error) {
+	if m.hasError {
+		return models.Move{}, errors.New("mock error")
+	}
+	return models.Move{ID: id, Name: "Tackle", Type: "Normal", Power: 40, Accuracy: 100, Category: models.MoveCategoryPhysical}, nil
+}
+
+func (m *mockMoveService) Update(ctx context.Context, move models.Move) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+func TestCreateMove(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hasError bool
+		expected int
+	}{
+		{name: "success", hasError: false, expected: http.StatusCreated},
+		{name: "error", hasError: true, expected: http.StatusInternalServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+			moveRoutes := s.App.Group("/moves")
+
+			moveServer := moveServer{srv: &mockMoveService{hasError: testCase.hasError}}
+			moveRoutes.Post("/", moveServer.CreateMove)
+
+			body, err := json.Marshal(moveRequest{Name: "Tackle", Type: "Normal", Power: 40, Accuracy: 100, Category: models.MoveCategoryPhysical})
+			if err != nil {
+				t.Fatalf("error marshalling move. Err: %v", err)
+			}
+
+			req, err := http.NewRequest("POST", "/moves", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+}
+
+func TestGetAllMoves(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := New()
+		moveRoutes := s.App.Group("/moves")
+
+		moveServer := moveServer{srv: &mockMoveService{hasError: false}}
+		moveRoutes.Get("/", moveServer.GetAllMoves)
+
+		req, err := http.NewRequest("GET", "/moves", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var moves []models.Move
+		err = json.NewDecoder(resp.Body).Decode(&moves)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(moves) != 2 {
+			t.Errorf("expected 2 moves; got %v", len(moves))
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+		moveRoutes := s.App.Group("/moves")
+
+		moveServer := moveServer{srv: &mockMoveService{hasError: true}}
+		moveRoutes.Get("/", moveServer.GetAllMoves)
+
+		req, err := http.NewRequest("GET", "/moves", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+}
+
+func TestMoveByIDRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		hasError bool
+		expected int
+	}{
+		{name: "get/success", method: "GET", path: "/moves/1", expected: http.StatusOK},
+		{name: "get/error", method: "GET", path: "/moves/1", hasError: true, expected: http.StatusInternalServerError},
+		{name: "get/invalid-id", method: "GET", path: "/moves/abc", expected: http.StatusBadRequest},
+		{name: "put/success", method: "PUT", path: "/moves/1", expected: http.StatusOK},
+		{name: "put/error", method: "PUT", path: "/moves/1", hasError: true, expected: http.StatusInternalServerError},
+		{name: "delete/success", method: "DELETE", path: "/moves/1", expected: http.StatusNoContent},
+		{name: "delete/error", method: "DELETE", path: "/moves/1", hasError: true, expected: http.StatusInternalServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+			moveRoutes := s.App.Group("/moves")
+
+			moveServer := moveServer{srv: &mockMoveService{hasError: testCase.hasError}}
+			moveRoutes.Get("/:id", moveServer.GetMoveByID)
+			moveRoutes.Put("/:id", moveServer.UpdateMove)
+			moveRoutes.Delete("/:id", moveServer.DeleteMove)
+
+			body, err := json.Marshal(models.Move{Name: "Tackle", Type: "Normal", Power: 50, Accuracy: 100, Category: models.MoveCategoryPhysical})
+			if err != nil {
+				t.Fatalf("error marshalling move. Err: %v", err)
+			}
+
+			req, err := http.NewRequest(testCase.method, testCase.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+}
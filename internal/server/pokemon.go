@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"pokemon-battle/internal/business"
 	"pokemon-battle/internal/database"
 	"pokemon-battle/internal/models"
 )
@@ -14,6 +15,10 @@ import (
 // It receives a database.PokemonCRUDService and uses it to handle the routes.
 type pokemonServer struct {
 	srv database.PokemonCRUDService
+
+	// matchups is triggered to compute the matchup matrix again when the
+	// pokemons change, it can be nil
+	matchups *matchupJob
 }
 
 type pokemonRequest struct {
@@ -22,6 +27,14 @@ type pokemonRequest struct {
 	HP      int    `json:"hp"`
 	Attack  int    `json:"attack"`
 	Defense int    `json:"defense"`
+	Speed   int    `json:"speed"`
+
+	// Level is the starting level, 1 if empty. The pokemon starts with the
+	// experience needed to reach it
+	Level int `json:"level,omitempty"`
+
+	// Ability is the name of the ability of the pokemon, optional
+	Ability string `json:"ability,omitempty"`
 }
 
 func (s *pokemonServer) CreatePokemon(c *fiber.Ctx) error {
@@ -37,12 +50,22 @@ func (s *pokemonServer) CreatePokemon(c *fiber.Ctx) error {
 		HP:      req.HP,
 		Attack:  req.Attack,
 		Defense: req.Defense,
+		Speed:   req.Speed,
+		Ability: req.Ability,
+	}
+	if _, err := business.AbilityByName(pokemon.Ability); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if req.Level > 0 {
+		pokemon.Level = req.Level
+		pokemon.Experience = business.ExperienceForLevel(business.DefaultGrowthTable().Of(req.Name).Curve, req.Level)
 	}
 
 	err := s.srv.Create(ctx, &pokemon)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	s.matchups.Trigger()
 	return c.Status(fiber.StatusCreated).JSON(pokemon)
 }
 
@@ -82,10 +105,24 @@ func (s *pokemonServer) UpdatePokemon(c *fiber.Ctx) error {
 	}
 	pokemon.ID = id
 
+	if _, err := business.AbilityByName(pokemon.Ability); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// the level and the experience are earned in battles, so the stored ones are kept
+	stored, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	pokemon.Level = stored.Level
+	pokemon.Experience = stored.Experience
+
 	err = s.srv.Update(ctx, pokemon)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	// the stats changed, so the matchups of the pokemon are outdated
+	s.matchups.Trigger()
 	return c.JSON(pokemon)
 }
 
@@ -100,5 +137,6 @@ func (s *pokemonServer) DeletePokemon(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	s.matchups.Trigger()
 	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -18,7 +18,7 @@ func TestPokemon_IT(t *testing.T) {
 	pokemonSrv := database.NewPokemonService(databaseSrv)
 
 	// the battle service is mocked because it's not needed for this test
-	s.RegisterFiberRoutes(pokemonSrv, &mockBattleService{hasError: true})
+	s.RegisterFiberRoutes(Services{Pokemons: pokemonSrv, Battles: &mockBattleService{hasError: true}})
 
 	t.Run("create", func(t *testing.T) {
 		t.Run("post-ok", func(t *testing.T) {
@@ -28,6 +28,7 @@ func TestPokemon_IT(t *testing.T) {
 				HP:      45,
 				Attack:  49,
 				Defense: 49,
+				Speed:   45,
 			}
 			body, _ := json.Marshal(pokemonReq)
 
@@ -56,7 +57,8 @@ func TestPokemon_IT(t *testing.T) {
 				pokemonResponse.Type != pokemonReq.Type ||
 				pokemonResponse.HP != pokemonReq.HP ||
 				pokemonResponse.Attack != pokemonReq.Attack ||
-				pokemonResponse.Defense != pokemonReq.Defense {
+				pokemonResponse.Defense != pokemonReq.Defense ||
+				pokemonResponse.Speed != pokemonReq.Speed {
 
 				t.Errorf("expected pokemon to be %v; got %v", pokemonReq, pokemonResponse)
 			}
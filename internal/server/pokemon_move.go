@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/database"
+)
+
+// pokemonMoveServer is used to handle the moveset routes of the pokemons.
+// It receives a database.PokemonMoveService and uses it to handle the routes.
+type pokemonMoveServer struct {
+	srv database.PokemonMoveService
+}
+
+type learnMoveRequest struct {
+	MoveID int `json:"move_id"`
+}
+
+func (s *pokemonMoveServer) GetPokemonMoves(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	moves, err := s.srv.GetMoves(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(moves)
+}
+
+func (s *pokemonMoveServer) LearnMove(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req learnMoveRequest
+	if err := c.BodyParser(&req); err != nil || req.MoveID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	err = s.srv.Learn(ctx, id, req.MoveID)
+	if errors.Is(err, database.ErrMovesetFull) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	moves, err := s.srv.GetMoves(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(moves)
+}
+
+func (s *pokemonMoveServer) ForgetMove(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	moveID, err := strconv.Atoi(c.Params("moveId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid move ID"})
+	}
+
+	err = s.srv.Forget(ctx, id, moveID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+// mockPokemonMoveService is used for testing the moveset routes
+// including the ability to return an error so we can test error handling
+type mockPokemonMoveService struct {
+	hasError bool
+
+	// full makes Learn return database.ErrMovesetFull
+	full bool
+}
+
+func (m *mockPokemonMoveService) GetMoves(ctx context.Context, pokemonID int) ([]models.Move, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return []models.Move{
+		{ID: 1, Name: "Tackle", Type: "Normal", Power: 40, Accuracy: 100, Category: models.MoveCategoryPhysical},
+	}, nil
+}
+
+func (m *mockPokemonMoveService) Learn(ctx context.Context, pokemonID int, moveID int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	if m.full {
+		return database.ErrMovesetFull
+	}
+	return nil
+}
+
+func (m *mockPokemonMoveService) Forget(ctx context.Context, pokemonID int, moveID int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+func TestPokemonMoveRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     *mockPokemonMoveService
+		expected int
+	}{
+		{name: "get/success", method: "GET", path: "/pokemons/1/moves", mock: &mockPokemonMoveService{}, expected: http.StatusOK},
+		{name: "get/error", method: "GET", path: "/pokemons/1/moves", mock: &mockPokemonMoveService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get/invalid-id", method: "GET", path: "/pokemons/abc/moves", mock: &mockPokemonMoveService{}, expected: http.StatusBadRequest},
+		{name: "learn/success", method: "POST", path: "/pokemons/1/moves", body: `{"move_id": 1}`, mock: &mockPokemonMoveService{}, expected: http.StatusCreated},
+		{name: "learn/full", method: "POST", path: "/pokemons/1/moves", body: `{"move_id": 1}`, mock: &mockPokemonMoveService{full: true}, expected: http.StatusConflict},
+		{name: "learn/error", method: "POST", path: "/pokemons/1/moves", body: `{"move_id": 1}`, mock: &mockPokemonMoveService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "learn/invalid-request", method: "POST", path: "/pokemons/1/moves", body: `{}`, mock: &mockPokemonMoveService{}, expected: http.StatusBadRequest},
+		{name: "forget/success", method: "DELETE", path: "/pokemons/1/moves/1", mock: &mockPokemonMoveService{}, expected: http.StatusNoContent},
+		{name: "forget/error", method: "DELETE", path: "/pokemons/1/moves/1", mock: &mockPokemonMoveService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "forget/invalid-move-id", method: "DELETE", path: "/pokemons/1/moves/abc", mock: &mockPokemonMoveService{}, expected: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+			pokemonRoutes := s.App.Group("/pokemons")
+
+			pokemonMoveServer := pokemonMoveServer{srv: testCase.mock}
+			pokemonRoutes.Get("/:id/moves", pokemonMoveServer.GetPokemonMoves)
+			pokemonRoutes.Post("/:id/moves", pokemonMoveServer.LearnMove)
+			pokemonRoutes.Delete("/:id/moves/:moveId", pokemonMoveServer.ForgetMove)
+
+			req, err := http.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+}
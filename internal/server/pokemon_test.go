@@ -16,6 +16,9 @@ import (
 // including the ability to return an error so we can test error handling
 type mockPokemonService struct {
 	hasError bool
+
+	// stored is returned by GetByID when it's set
+	stored *models.Pokemon
 }
 
 func (m *mockPokemonService) Create(ctx context.Context, pokemon *models.Pokemon) error {
@@ -46,6 +49,9 @@ func (m *mockPokemonService) GetByID(ctx context.Context, id int) (models.Pokemo
 	if m.hasError {
 		return models.Pokemon{}, errors.New("mock error")
 	}
+	if m.stored != nil {
+		return *m.stored, nil
+	}
 	return models.Pokemon{}, nil
 }
 
@@ -168,6 +174,67 @@ func TestCreatePokemon(t *testing.T) {
 		}
 	})
 
+	t.Run("success/level", func(t *testing.T) {
+		s := New()
+		pokemonRoutes := s.App.Group("/pokemons")
+
+		pokemonServer := pokemonServer{srv: &mockPokemonService{hasError: false}}
+		pokemonRoutes.Post("/", pokemonServer.CreatePokemon)
+
+		body := []byte(`{"name": "Bulbasaur", "type": "Grass", "hp": 45, "attack": 49, "defense": 49, "level": 10}`)
+
+		req, err := http.NewRequest("POST", "/pokemons", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated {
+			t.Errorf("expected status Created; got %v", resp.Status)
+		}
+
+		var pokemon models.Pokemon
+		if err := json.NewDecoder(resp.Body).Decode(&pokemon); err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		// Bulbasaur has the medium-slow curve, 6*1000/5 - 15*100 + 100*10 - 140 = 560
+		if pokemon.Level != 10 || pokemon.Experience != 560 {
+			t.Errorf("expected level 10 with 560 experience; got level %d with %d", pokemon.Level, pokemon.Experience)
+		}
+	})
+
+	t.Run("unknown-ability", func(t *testing.T) {
+		s := New()
+		pokemonRoutes := s.App.Group("/pokemons")
+
+		pokemonServer := pokemonServer{srv: &mockPokemonService{hasError: false}}
+		pokemonRoutes.Post("/", pokemonServer.CreatePokemon)
+
+		body := []byte(`{"name": "Gastly", "type": "Ghost", "hp": 30, "attack": 35, "defense": 30, "ability": "cloud-nine"}`)
+
+		req, err := http.NewRequest("POST", "/pokemons", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400; got %v", resp.Status)
+		}
+	})
+
 	t.Run("error", func(t *testing.T) {
 		s := New()
 		pokemonRoutes := s.App.Group("/pokemons")
@@ -287,6 +354,42 @@ func TestUpdatePokemon(t *testing.T) {
 		}
 	})
 
+	t.Run("success/keeps-experience", func(t *testing.T) {
+		s := New()
+		pokemonRoutes := s.App.Group("/pokemons")
+
+		stored := models.Pokemon{ID: 1, Name: "Bulbasaur", Type: "Grass", HP: 45, Attack: 49, Defense: 49, Level: 5, Experience: 150}
+		pokemonServer := pokemonServer{srv: &mockPokemonService{hasError: false, stored: &stored}}
+		pokemonRoutes.Put("/:id", pokemonServer.UpdatePokemon)
+
+		// the level and the experience of the request are ignored
+		body := []byte(`{"name": "Bulbasaur", "type": "Grass", "hp": 50, "attack": 49, "defense": 49, "level": 50, "experience": 0}`)
+
+		req, err := http.NewRequest("PUT", "/pokemons/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var pokemon models.Pokemon
+		if err := json.NewDecoder(resp.Body).Decode(&pokemon); err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if pokemon.HP != 50 || pokemon.Level != 5 || pokemon.Experience != 150 {
+			t.Errorf("expected the new stats with the stored level and experience; got %+v", pokemon)
+		}
+	})
+
 	t.Run("error", func(t *testing.T) {
 		s := New()
 		pokemonRoutes := s.App.Group("/pokemons")
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+)
+
+// ratingServer is used to handle the rating routes.
+// The ratings are updated by the battle service when a battle is created.
+type ratingServer struct {
+	srv database.RatingService
+}
+
+// GetAllRatings returns the ranking of the rated pokemon, from the highest rating to the lowest
+func (s *ratingServer) GetAllRatings(c *fiber.Ctx) error {
+	ctx := context.Background()
+	ratings, err := s.srv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(ratings)
+}
+
+// GetPokemonRating returns the current rating of a pokemon,
+// which is the initial rating if it has not battled yet
+func (s *ratingServer) GetPokemonRating(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	rating, err := s.srv.GetByPokemonID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(business.NewRating(id))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(rating)
+}
+
+// GetPokemonRatingHistory returns the rating of a pokemon after each of its battles
+func (s *ratingServer) GetPokemonRatingHistory(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	history, err := s.srv.GetHistory(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(history)
+}
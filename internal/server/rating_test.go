@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+// mockRatingService is used for testing the rating routes
+// including the ability to return an error so we can test error handling
+type mockRatingService struct {
+	hasError bool
+}
+
+func (m *mockRatingService) GetAll(ctx context.Context) ([]models.Rating, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return []models.Rating{
+		{PokemonID: 2, Rating: 1662.3, Deviation: 290.3, Volatility: 0.06, Battles: 1},
+		{PokemonID: 1, Rating: 1337.7, Deviation: 290.3, Volatility: 0.06, Battles: 1},
+	}, nil
+}
+
+func (m *mockRatingService) GetByPokemonID(ctx context.Context, pokemonID int) (models.Rating, error) {
+	if m.hasError {
+		return models.Rating{}, errors.New("mock error")
+	}
+	// only pikachu has battled
+	if pokemonID != 1 {
+		return models.Rating{}, sql.ErrNoRows
+	}
+	return models.Rating{PokemonID: 1, Rating: 1337.7, Deviation: 290.3, Volatility: 0.06, Battles: 1}, nil
+}
+
+func (m *mockRatingService) GetHistory(ctx context.Context, pokemonID int) ([]models.RatingChange, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	return []models.RatingChange{
+		{BattleID: 1, PokemonID: pokemonID, Rating: 1337.7, Deviation: 290.3, Volatility: 0.06},
+	}, nil
+}
+
+func TestRatingRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		mock     *mockRatingService
+		expected int
+	}{
+		{name: "ranking/success", path: "/ratings", mock: &mockRatingService{}, expected: http.StatusOK},
+		{name: "ranking/error", path: "/ratings", mock: &mockRatingService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "rating/success", path: "/pokemons/1/rating", mock: &mockRatingService{}, expected: http.StatusOK},
+		{name: "rating/error", path: "/pokemons/1/rating", mock: &mockRatingService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "rating/invalid-id", path: "/pokemons/abc/rating", mock: &mockRatingService{}, expected: http.StatusBadRequest},
+		{name: "history/success", path: "/pokemons/1/rating/history", mock: &mockRatingService{}, expected: http.StatusOK},
+		{name: "history/error", path: "/pokemons/1/rating/history", mock: &mockRatingService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "history/invalid-id", path: "/pokemons/abc/rating/history", mock: &mockRatingService{}, expected: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+
+			ratingServer := ratingServer{srv: testCase.mock}
+			s.App.Get("/ratings", ratingServer.GetAllRatings)
+			s.App.Get("/pokemons/:id/rating", ratingServer.GetPokemonRating)
+			s.App.Get("/pokemons/:id/rating/history", ratingServer.GetPokemonRatingHistory)
+
+			req, err := http.NewRequest("GET", testCase.path, nil)
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+
+	t.Run("rating/not-rated", func(t *testing.T) {
+		s := New()
+
+		ratingServer := ratingServer{srv: &mockRatingService{}}
+		s.App.Get("/pokemons/:id/rating", ratingServer.GetPokemonRating)
+
+		req, err := http.NewRequest("GET", "/pokemons/2/rating", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var rating models.Rating
+		if err := json.NewDecoder(resp.Body).Decode(&rating); err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if rating.PokemonID != 2 || rating.Rating != business.DefaultRating || rating.Deviation != business.DefaultRatingDeviation {
+			t.Errorf("expected the initial rating; got %+v", rating)
+		}
+	})
+}
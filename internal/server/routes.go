@@ -3,12 +3,31 @@ package server
 import (
 	"pokemon-battle/internal/database"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/basicauth"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-func (s *FiberServer) RegisterFiberRoutes(pokemonSrv database.PokemonCRUDService, battleSrv database.BattleCRUDService) {
+// Services groups the database services used to handle the routes.
+type Services struct {
+	Pokemons       database.PokemonCRUDService
+	Battles        database.BattleCRUDService
+	Moves          database.MoveCRUDService
+	PokemonMoves   database.PokemonMoveService
+	Ratings        database.RatingService
+	Tournaments    database.TournamentCRUDService
+	BattleSessions database.BattleSessionCRUDService
+	LevelUps       database.LevelUpService
+	Evolutions     database.EvolutionService
+	Items          database.ItemCRUDService
+	HeldItems      database.HeldItemService
+
+	// Matchups stores the matchup matrix, the matrix is not computed without it
+	Matchups database.MatchupService
+}
+
+func (s *FiberServer) RegisterFiberRoutes(srv Services) {
 	// Apply CORS middleware
 	s.App.Use(cors.New(cors.Config{
 		AllowOrigins:     "*",
@@ -32,8 +51,24 @@ func (s *FiberServer) RegisterFiberRoutes(pokemonSrv database.PokemonCRUDService
 
 	s.App.Get("/health", s.healthHandler)
 
+	// init the matchup job, which fights with the same settings as the battles
+	if srv.Matchups != nil {
+		s.matchupJob = newMatchupJob(srv.Pokemons, srv.PokemonMoves, srv.Matchups, s.fightSettings(), s.matchupRuns, s.matchupWorkers)
+		s.matchupJob.Start()
+	}
+
 	// init the pokemon routes from a pokemon service
-	pokemonServer := pokemonServer{srv: pokemonSrv}
+	pokemonServer := pokemonServer{srv: srv.Pokemons, matchups: s.matchupJob}
+	pokemonMoveServer := pokemonMoveServer{srv: srv.PokemonMoves}
+	ratingServer := ratingServer{srv: srv.Ratings}
+	levelUpServer := levelUpServer{srv: srv.LevelUps}
+	heldItemServer := heldItemServer{srv: srv.HeldItems, matchups: s.matchupJob}
+	evolutionServer := evolutionServer{
+		srv:        srv.Evolutions,
+		pokemonSrv: srv.Pokemons,
+		battleSrv:  srv.Battles,
+		matchups:   s.matchupJob,
+	}
 
 	pokemonRoutes := s.App.Group("/pokemons")
 	pokemonRoutes.Post("/", pokemonServer.CreatePokemon)
@@ -41,16 +76,133 @@ func (s *FiberServer) RegisterFiberRoutes(pokemonSrv database.PokemonCRUDService
 	pokemonRoutes.Get("/:id", pokemonServer.GetPokemonByID)
 	pokemonRoutes.Put("/:id", pokemonServer.UpdatePokemon)
 	pokemonRoutes.Delete("/:id", pokemonServer.DeletePokemon)
+	pokemonRoutes.Get("/:id/moves", pokemonMoveServer.GetPokemonMoves)
+	pokemonRoutes.Post("/:id/moves", pokemonMoveServer.LearnMove)
+	pokemonRoutes.Delete("/:id/moves/:moveId", pokemonMoveServer.ForgetMove)
+	pokemonRoutes.Get("/:id/rating", ratingServer.GetPokemonRating)
+	pokemonRoutes.Get("/:id/rating/history", ratingServer.GetPokemonRatingHistory)
+	pokemonRoutes.Get("/:id/level-ups", levelUpServer.GetPokemonLevelUps)
+	pokemonRoutes.Get("/:id/item", heldItemServer.GetPokemonItem)
+	pokemonRoutes.Put("/:id/item", heldItemServer.GivePokemonItem)
+	pokemonRoutes.Delete("/:id/item", heldItemServer.TakePokemonItem)
+	pokemonRoutes.Get("/:id/evolutions", evolutionServer.GetPokemonEvolutions)
+	pokemonRoutes.Post("/:id/evolve", evolutionServer.EvolvePokemon)
+
+	// the evolution relations between pokemon entries
+	evolutionRoutes := s.App.Group("/evolutions")
+	evolutionRoutes.Post("/", evolutionServer.CreateEvolution)
+	evolutionRoutes.Get("/", evolutionServer.GetAllEvolutions)
+	evolutionRoutes.Delete("/:id", evolutionServer.DeleteEvolution)
+
+	// the ranking of the pokemon by their rating
+	s.App.Get("/ratings", ratingServer.GetAllRatings)
+
+	// init the move routes from a move service
+	moveServer := moveServer{srv: srv.Moves}
+
+	moveRoutes := s.App.Group("/moves")
+	moveRoutes.Post("/", moveServer.CreateMove)
+	moveRoutes.Get("/", moveServer.GetAllMoves)
+	moveRoutes.Get("/:id", moveServer.GetMoveByID)
+	moveRoutes.Put("/:id", moveServer.UpdateMove)
+	moveRoutes.Delete("/:id", moveServer.DeleteMove)
+
+	// init the item routes from an item service
+	itemServer := itemServer{srv: srv.Items}
+
+	itemRoutes := s.App.Group("/items")
+	itemRoutes.Post("/", itemServer.CreateItem)
+	itemRoutes.Get("/", itemServer.GetAllItems)
+	itemRoutes.Get("/:id", itemServer.GetItemByID)
+	itemRoutes.Put("/:id", itemServer.UpdateItem)
+	itemRoutes.Delete("/:id", itemServer.DeleteItem)
 
 	// init the battle routes from a battle service
-	battleServer := battleServer{srv: battleSrv, pokemonSrv: pokemonSrv, diceSides: s.diceSides}
+	battleServer := battleServer{
+		srv:                 srv.Battles,
+		pokemonSrv:          srv.Pokemons,
+		diceSides:           s.diceSides,
+		initiativeDiceSides: s.initiativeDiceSides,
+		maxExplosions:       s.maxExplosions,
+		attackDice:          s.attackDice,
+		maxTurns:            s.maxTurns,
+		typeChart:           s.typeChart,
+		feed:                s.battleFeed,
+	}
 
 	battleRoutes := s.App.Group("/battles")
 	battleRoutes.Post("/", battleServer.CreateBattle)
 	battleRoutes.Get("/", battleServer.GetAllBattles)
+	battleRoutes.Get("/stream", battleServer.StreamBattles)
 	battleRoutes.Get("/:id", battleServer.GetBattleByID)
+	battleRoutes.Get("/:id/log", battleServer.GetBattleLog)
+	battleRoutes.Post("/:id/replay", battleServer.ReplayBattle)
 	battleRoutes.Put("/:id", battleServer.UpdateBattle)
 	battleRoutes.Delete("/:id", battleServer.DeleteBattle)
+
+	// init the tournament routes, whose battles are stored like the rest
+	tournamentServer := tournamentServer{
+		srv:        srv.Tournaments,
+		battleSrv:  srv.Battles,
+		pokemonSrv: srv.Pokemons,
+		settings:   battleServer.settings(),
+		feed:       s.battleFeed,
+	}
+
+	tournamentRoutes := s.App.Group("/tournaments")
+	tournamentRoutes.Post("/", tournamentServer.CreateTournament)
+	tournamentRoutes.Get("/", tournamentServer.GetAllTournaments)
+	tournamentRoutes.Get("/:id", tournamentServer.GetTournamentByID)
+	tournamentRoutes.Delete("/:id", tournamentServer.DeleteTournament)
+	tournamentRoutes.Get("/:id/standings", tournamentServer.GetTournamentStandings)
+	tournamentRoutes.Get("/:id/bracket", tournamentServer.GetTournamentBracket)
+	tournamentRoutes.Post("/:id/rounds", tournamentServer.PlayTournamentRound)
+
+	// init the battle session routes, whose finished battles are stored with the session
+	sessionServer := sessionServer{
+		srv:        srv.BattleSessions,
+		pokemonSrv: srv.Pokemons,
+		settings:   battleServer.settings(),
+		feed:       s.battleFeed,
+		hub:        newBattleHub(),
+	}
+
+	sessionRoutes := s.App.Group("/battle-sessions")
+	sessionRoutes.Post("/", sessionServer.CreateBattleSession)
+	sessionRoutes.Get("/", sessionServer.GetAllBattleSessions)
+	sessionRoutes.Get("/:id", sessionServer.GetBattleSessionByID)
+	sessionRoutes.Delete("/:id", sessionServer.DeleteBattleSession)
+	sessionRoutes.Post("/:id/actions", sessionServer.SubmitAction)
+
+	// init the WebSocket routes, which stream the events of the battles as they resolve
+	streamRoutes := s.App.Group("/ws", requireUpgrade)
+	streamRoutes.Get("/battles", websocket.New(battleServer.StreamBattle))
+	streamRoutes.Get("/battles/:id", websocket.New(battleServer.WatchBattle))
+	streamRoutes.Get("/battle-sessions/:id", websocket.New(sessionServer.WatchBattleSession))
+
+	// init the simulation routes with the same settings as the battles
+	simulationServer := simulationServer{
+		pokemonSrv: srv.Pokemons,
+		settings:   battleServer.settings(),
+	}
+
+	s.App.Post("/simulations", simulationServer.RunSimulation)
+
+	// init the analytics routes from the matchups computed by the matchup job
+	if srv.Matchups != nil {
+		analyticsServer := analyticsServer{
+			pokemonSrv: srv.Pokemons,
+			matchupSrv: srv.Matchups,
+			job:        s.matchupJob,
+		}
+
+		s.App.Get("/analytics/matchups", analyticsServer.GetMatchups)
+	}
+
+	// init the dice routes
+	diceServer := diceServer{}
+
+	s.App.Post("/dice/roll", diceServer.RollDice)
 }
 
 func (s *FiberServer) HelloWorldHandler(c *fiber.Ctx) error {
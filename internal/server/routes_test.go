@@ -9,7 +9,13 @@ import (
 func TestHandler(t *testing.T) {
 	s := New()
 
-	s.RegisterFiberRoutes(&mockPokemonService{hasError: false}, &mockBattleService{hasError: false})
+	s.RegisterFiberRoutes(Services{
+		Pokemons:     &mockPokemonService{hasError: false},
+		Battles:      &mockBattleService{hasError: false},
+		Moves:        &mockMoveService{hasError: false},
+		PokemonMoves: &mockPokemonMoveService{hasError: false},
+		Ratings:      &mockRatingService{hasError: false},
+	})
 
 	t.Run("get/", func(t *testing.T) {
 		// Create a test HTTP request
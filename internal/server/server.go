@@ -1,7 +1,10 @@
 package server
 
 import (
+	"context"
+	"log"
 	"os"
+	"runtime"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,8 +16,22 @@ import (
 type FiberServer struct {
 	*fiber.App
 
-	db        database.Service
-	diceSides int
+	db                  database.Service
+	diceSides           int
+	initiativeDiceSides int
+	maxExplosions       int
+	attackDice          *business.DiceExpression
+	maxTurns            int
+	typeChart           business.TypeChart
+	matchupRuns         int
+	matchupWorkers      int
+
+	// matchupJob computes the matchup matrix in the background, it is nil
+	// until the routes are registered with a matchup service
+	matchupJob *matchupJob
+
+	// battleFeed broadcasts the created battles to the clients of the battle stream
+	battleFeed *battleFeed
 }
 
 func New() *FiberServer {
@@ -24,13 +41,41 @@ func New() *FiberServer {
 			AppName:      "pokemon-battle",
 		}),
 
-		db:        database.New(),
-		diceSides: initalizeDiceSides(),
+		db:                  database.New(),
+		diceSides:           initalizeDiceSides(),
+		initiativeDiceSides: initializeInitiativeDiceSides(),
+		maxExplosions:       initializeMaxExplosions(),
+		attackDice:          initializeAttackDice(),
+		maxTurns:            initializeMaxTurns(),
+		typeChart:           initializeTypeChart(),
+		matchupRuns:         initializeMatchupRuns(),
+		matchupWorkers:      initializeMatchupWorkers(),
+		battleFeed:          newBattleFeed(),
 	}
 
 	return server
 }
 
+// ShutdownWithContext stops the matchup job, if any, disconnects the clients
+// of the battle stream and shuts down the server
+func (s *FiberServer) ShutdownWithContext(ctx context.Context) error {
+	s.matchupJob.Stop()
+	s.battleFeed.close()
+	return s.App.ShutdownWithContext(ctx)
+}
+
+// fightSettings returns the settings of the fights configured in the server
+func (s *FiberServer) fightSettings() fightSettings {
+	return fightSettings{
+		diceSides:           s.diceSides,
+		initiativeDiceSides: s.initiativeDiceSides,
+		maxExplosions:       s.maxExplosions,
+		attackDice:          s.attackDice,
+		maxTurns:            s.maxTurns,
+		typeChart:           s.typeChart,
+	}
+}
+
 func initalizeDiceSides() int {
 	sides, err := strconv.Atoi(os.Getenv("POKEMON_BATTLE_DICE_SIDES"))
 	if err != nil {
@@ -38,3 +83,93 @@ func initalizeDiceSides() int {
 	}
 	return sides
 }
+
+// initializeInitiativeDiceSides reads the sides of the initiative dice from the
+// POKEMON_BATTLE_INITIATIVE_DICE_SIDES environment variable, falling back to the default.
+// A dice with less than business.MinInitiativeDiceSides sides could not break the speed ties.
+func initializeInitiativeDiceSides() int {
+	sides, err := strconv.Atoi(os.Getenv("POKEMON_BATTLE_INITIATIVE_DICE_SIDES"))
+	if err != nil || sides < business.MinInitiativeDiceSides {
+		return business.DefaultInitiativeDiceSides
+	}
+	return sides
+}
+
+// initializeMaxExplosions reads the maximum number of explosions of the attack dice
+// from the POKEMON_BATTLE_MAX_EXPLOSIONS environment variable, falling back to the default.
+// Setting it to 0 disables the explosions and the critical hits.
+func initializeMaxExplosions() int {
+	maxExplosions, err := strconv.Atoi(os.Getenv("POKEMON_BATTLE_MAX_EXPLOSIONS"))
+	if err != nil || maxExplosions < 0 {
+		return business.DefaultMaxExplosions
+	}
+	return maxExplosions
+}
+
+// initializeAttackDice parses the dice expression defined in the POKEMON_BATTLE_DICE
+// environment variable, e.g. "2d6+3", used for the attack and defense rolls instead
+// of the dice with POKEMON_BATTLE_DICE_SIDES sides. It returns nil if the variable
+// is not set or the expression is not valid.
+func initializeAttackDice() *business.DiceExpression {
+	expression := os.Getenv("POKEMON_BATTLE_DICE")
+	if expression == "" {
+		return nil
+	}
+
+	dice, err := business.ParseDice(expression)
+	if err != nil {
+		log.Printf("could not parse the dice expression %q, using %d-sided dice: %v", expression, initalizeDiceSides(), err)
+		return nil
+	}
+	return dice
+}
+
+// initializeMaxTurns reads the maximum number of turns of a battle, after which
+// it ends in a draw, from the POKEMON_BATTLE_MAX_TURNS environment variable,
+// falling back to the default.
+func initializeMaxTurns() int {
+	maxTurns, err := strconv.Atoi(os.Getenv("POKEMON_BATTLE_MAX_TURNS"))
+	if err != nil || maxTurns <= 0 {
+		return business.DefaultMaxTurns
+	}
+	return maxTurns
+}
+
+// initializeTypeChart loads the type chart from the file defined in the
+// POKEMON_BATTLE_TYPE_CHART environment variable, falling back to the
+// default type chart if the variable is not set or the file is not valid.
+func initializeTypeChart() business.TypeChart {
+	path := os.Getenv("POKEMON_BATTLE_TYPE_CHART")
+	if path == "" {
+		return business.DefaultTypeChart()
+	}
+
+	chart, err := business.LoadTypeChartFile(path)
+	if err != nil {
+		log.Printf("could not load type chart from %s, using the default one: %v", path, err)
+		return business.DefaultTypeChart()
+	}
+	return chart
+}
+
+// initializeMatchupRuns reads the number of battles simulated for each pair of
+// pokemons of the matchup matrix from the POKEMON_BATTLE_MATCHUP_RUNS environment
+// variable, falling back to the default.
+func initializeMatchupRuns() int {
+	runs, err := strconv.Atoi(os.Getenv("POKEMON_BATTLE_MATCHUP_RUNS"))
+	if err != nil || runs <= 0 || runs > business.MaxSimulationRuns {
+		return business.DefaultMatchupRuns
+	}
+	return runs
+}
+
+// initializeMatchupWorkers reads the maximum number of pairs of pokemons simulated
+// at the same time by the matchup job from the POKEMON_BATTLE_MATCHUP_WORKERS
+// environment variable, falling back to the number of processors.
+func initializeMatchupWorkers() int {
+	workers, err := strconv.Atoi(os.Getenv("POKEMON_BATTLE_MATCHUP_WORKERS"))
+	if err != nil || workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+// sessionServer is used to handle the routes of the interactive battle sessions.
+// The battle of a finished session is stored in the same transaction as the session.
+type sessionServer struct {
+	srv        database.BattleSessionCRUDService
+	pokemonSrv database.PokemonCRUDService
+	settings   fightSettings
+
+	// feed broadcasts the battles of the finished sessions to the clients of the battle stream
+	feed *battleFeed
+
+	// hub streams the events of the resolved turns to the spectators of the sessions
+	hub *battleHub
+
+	// mu serializes the actions, so the same turn is never resolved twice at once
+	mu sync.Mutex
+}
+
+type sessionRequest struct {
+	Pokemon1ID int `json:"pokemon1_id"`
+	Pokemon2ID int `json:"pokemon2_id"`
+
+	fightRequest
+
+	// Team1 and Team2 are the ordered rosters of a team session,
+	// instead of Pokemon1ID and Pokemon2ID
+	Team1 []int `json:"team1,omitempty"`
+	Team2 []int `json:"team2,omitempty"`
+
+	// Strategy1 and Strategy2 are the names of the strategies that play a side
+	// without a trainer, e.g. "greedy". Without a strategy, the trainer of the
+	// side sends its actions
+	Strategy1 string `json:"strategy1,omitempty"`
+	Strategy2 string `json:"strategy2,omitempty"`
+}
+
+type actionRequest struct {
+	// Side is the side of the trainer that sends the action: 1 or 2
+	Side int `json:"side"`
+
+	models.BattleAction
+}
+
+// teams returns the rosters of the session, a single pokemon each outside team sessions
+func (r *sessionRequest) teams() ([]int, []int) {
+	if len(r.Team1) > 0 || len(r.Team2) > 0 {
+		return r.Team1, r.Team2
+	}
+	return []int{r.Pokemon1ID}, []int{r.Pokemon2ID}
+}
+
+// CreateBattleSession creates a battle session, which waits for the actions of the first turn
+func (s *sessionServer) CreateBattleSession(c *fiber.Ctx) error {
+	ctx := context.Background()
+	var req sessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	opts, err := req.options(s.settings)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	strategy1, err := business.StrategyByName(req.Strategy1)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	strategy2, err := business.StrategyByName(req.Strategy2)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	opts = append(opts, business.WithStrategies(strategy1, strategy2))
+
+	ids1, ids2 := req.teams()
+	if err := models.ValidateTeams(ids1, ids2); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// retrieve the teams from the database
+	team1, err := getPokemons(ctx, s.pokemonSrv, ids1)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	team2, err := getPokemons(ctx, s.pokemonSrv, ids2)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	battleSession := business.NewSession(s.settings.diceSides, team1, team2, opts...)
+	battle := battleSession.Battle()
+
+	session := models.BattleSession{
+		Team1:        ids1,
+		Team2:        ids2,
+		Seed:         battle.Seed,
+		Settings:     battle.Settings,
+		Participants: battle.Participants,
+	}
+	battleSession.Fill(&session)
+
+	err = s.srv.Create(ctx, &session)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+// GetAllBattleSessions returns all the battle sessions, without the state of their battles
+func (s *sessionServer) GetAllBattleSessions(c *fiber.Ctx) error {
+	ctx := context.Background()
+	sessions, err := s.srv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(sessions)
+}
+
+// GetBattleSessionByID returns a battle session with the state of its battle
+func (s *sessionServer) GetBattleSessionByID(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	session, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	battleSession, err := business.RestoreSession(session, business.WithTypeChart(s.settings.typeChart))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	battleSession.Fill(&session)
+	return c.JSON(session)
+}
+
+func (s *sessionServer) DeleteBattleSession(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	err = s.srv.Delete(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SubmitAction stores the action of a side for the current turn of a session.
+// Once both sides have sent their action, or the other side is played by a
+// strategy, the turn is resolved. When the battle ends, it is stored like the
+// rest of the battles.
+func (s *sessionServer) SubmitAction(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req actionRequest
+	if err := c.BodyParser(&req); err != nil || (req.Side != 1 && req.Side != 2) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if session.Status == models.SessionFinished {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": business.ErrSessionFinished.Error()})
+	}
+
+	battleSession, err := business.RestoreSession(session, business.WithTypeChart(s.settings.typeChart))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := battleSession.Check(req.Side, req.BattleAction); errors.Is(err, business.ErrInvalidAction) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	} else if err != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if session.Pending(req.Side) != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "the side has already sent its action for this turn"})
+	}
+
+	action := req.BattleAction
+	if req.Side == 1 {
+		session.Pending1 = &action
+	} else {
+		session.Pending2 = &action
+	}
+
+	// the turn is resolved when no trainer is missing its action
+	var messages []streamMessage
+	var finished *models.Battle
+	other := 3 - req.Side
+	if session.Pending(other) != nil || !battleSession.IsTrainer(other) {
+		resolved := len(battleSession.Battle().Log)
+		turn := models.SessionTurn{}
+		if session.Pending1 != nil {
+			turn.Action1 = *session.Pending1
+		}
+		if session.Pending2 != nil {
+			turn.Action2 = *session.Pending2
+		}
+
+		if err := battleSession.Play(turn.Action1, turn.Action2); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		session.Turns = append(session.Turns, turn)
+		session.Pending1, session.Pending2 = nil, nil
+
+		battle := battleSession.Battle()
+		messages = eventMessages(battle.Log[resolved:])
+		if battleSession.Finished() {
+			finished = &battle
+		}
+	}
+
+	battleSession.Fill(&session)
+	if finished != nil {
+		// the battle is stored together with the finished session
+		if err := s.srv.Finish(ctx, &session, finished); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		s.feed.publish(*finished)
+		messages = append(messages, streamMessage{Battle: finished})
+	} else if err := s.srv.Update(ctx, session); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	s.hub.publish(session.ID, messages...)
+	return c.JSON(session)
+}
+
+// WatchBattleSession streams the battle of a session over a WebSocket connection:
+// the events of the turns already resolved, then the events of each turn as soon
+// as it resolves and finally the battle, once it is stored.
+func (s *sessionServer) WatchBattleSession(c *websocket.Conn) {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		closeStream(c, streamMessage{Error: "Invalid ID"})
+		return
+	}
+
+	// subscribe while no turn is being resolved, so the spectator receives
+	// every event exactly once
+	s.mu.Lock()
+	messages, unsubscribe := s.hub.subscribe(id)
+	session, err := s.srv.GetByID(ctx, id)
+	s.mu.Unlock()
+	defer unsubscribe()
+	if err != nil {
+		closeStream(c, streamMessage{Error: err.Error()})
+		return
+	}
+
+	battleSession, err := business.RestoreSession(session, business.WithTypeChart(s.settings.typeChart))
+	if err != nil {
+		closeStream(c, streamMessage{Error: err.Error()})
+		return
+	}
+
+	battle := battleSession.Battle()
+	if battleSession.Finished() {
+		battle.ID = session.BattleID
+		closeStream(c, append(eventMessages(battle.Log), streamMessage{Battle: &battle})...)
+		return
+	}
+	for _, message := range eventMessages(battle.Log) {
+		if err := c.WriteJSON(message); err != nil {
+			return
+		}
+	}
+	stream(c, messages)
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+// mockBattleSessionService is used for testing the battle session routes
+// including the ability to return an error so we can test error handling.
+// The session 1 is waiting for the action of the second trainer, the session 2
+// is finished and the second side of the session 3 is played by the greedy strategy.
+// The session 4 is like the session 3, but its first attack ends the battle.
+type mockBattleSessionService struct {
+	hasError bool
+
+	// updated is the last session passed to Update or Finish
+	updated *models.BattleSession
+
+	// finished is the last battle passed to Finish
+	finished *models.Battle
+}
+
+func (m *mockBattleSessionService) Create(ctx context.Context, session *models.BattleSession) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	session.ID = 1
+	return nil
+}
+
+func (m *mockBattleSessionService) Delete(ctx context.Context, id int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+func (m *mockBattleSessionService) GetAll(ctx context.Context) ([]models.BattleSession, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	session, _ := m.GetByID(ctx, 1)
+	return []models.BattleSession{session}, nil
+}
+
+func (m *mockBattleSessionService) GetByID(ctx context.Context, id int) (models.BattleSession, error) {
+	if m.hasError {
+		return models.BattleSession{}, errors.New("mock error")
+	}
+
+	pikachu := models.Pokemon{ID: 1, Name: "Pikachu", Type: "Electric", HP: 35, Attack: 55, Defense: 40, Speed: 90}
+	eevee := models.Pokemon{ID: 2, Name: "Eevee", Type: "Normal", HP: 55, Attack: 55, Defense: 50, Speed: 55}
+	opts := []business.Option{business.WithSeed(42)}
+	if id == 3 || id == 4 {
+		opts = append(opts, business.WithStrategies(nil, business.GreedyStrategy{}))
+	}
+	if id == 4 {
+		eevee.HP = 1
+	}
+
+	battleSession := business.NewSession(6, []models.Pokemon{pikachu}, []models.Pokemon{eevee}, opts...)
+	battle := battleSession.Battle()
+	session := models.BattleSession{
+		ID:           id,
+		Team1:        []int{pikachu.ID},
+		Team2:        []int{eevee.ID},
+		Seed:         battle.Seed,
+		Settings:     battle.Settings,
+		Participants: battle.Participants,
+		Status:       models.SessionInProgress,
+	}
+
+	switch id {
+	case 1:
+		session.Pending1 = &models.BattleAction{Type: models.ActionAttack, Move: -1}
+	case 2:
+		session.Status = models.SessionFinished
+	}
+	return session, nil
+}
+
+func (m *mockBattleSessionService) Update(ctx context.Context, session models.BattleSession) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	m.updated = &session
+	return nil
+}
+
+func (m *mockBattleSessionService) Finish(ctx context.Context, session *models.BattleSession, battle *models.Battle) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	battle.ID = 1
+	session.BattleID = battle.ID
+	updated := *session
+	m.updated, m.finished = &updated, battle
+	return nil
+}
+
+func TestBattleSessionRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     *mockBattleSessionService
+		expected int
+	}{
+		{name: "create/success", method: "POST", path: "/battle-sessions", body: `{"pokemon1_id": 1, "pokemon2_id": 2}`, mock: &mockBattleSessionService{}, expected: http.StatusCreated},
+		{name: "create/strategy", method: "POST", path: "/battle-sessions", body: `{"team1": [1, 2], "team2": [3], "strategy2": "greedy"}`, mock: &mockBattleSessionService{}, expected: http.StatusCreated},
+		{name: "create/unknown-strategy", method: "POST", path: "/battle-sessions", body: `{"pokemon1_id": 1, "pokemon2_id": 2, "strategy1": "unknown"}`, mock: &mockBattleSessionService{}, expected: http.StatusBadRequest},
+		{name: "create/same-pokemon", method: "POST", path: "/battle-sessions", body: `{"pokemon1_id": 1, "pokemon2_id": 1}`, mock: &mockBattleSessionService{}, expected: http.StatusBadRequest},
+		{name: "create/error", method: "POST", path: "/battle-sessions", body: `{"pokemon1_id": 1, "pokemon2_id": 2}`, mock: &mockBattleSessionService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get-all/success", method: "GET", path: "/battle-sessions", mock: &mockBattleSessionService{}, expected: http.StatusOK},
+		{name: "get-all/error", method: "GET", path: "/battle-sessions", mock: &mockBattleSessionService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get/success", method: "GET", path: "/battle-sessions/1", mock: &mockBattleSessionService{}, expected: http.StatusOK},
+		{name: "get/invalid-id", method: "GET", path: "/battle-sessions/abc", mock: &mockBattleSessionService{}, expected: http.StatusBadRequest},
+		{name: "get/error", method: "GET", path: "/battle-sessions/1", mock: &mockBattleSessionService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "delete/success", method: "DELETE", path: "/battle-sessions/1", mock: &mockBattleSessionService{}, expected: http.StatusNoContent},
+		{name: "delete/error", method: "DELETE", path: "/battle-sessions/1", mock: &mockBattleSessionService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "action/strategy-turn", method: "POST", path: "/battle-sessions/3/actions", body: `{"side": 1, "type": "attack", "move": -1}`, mock: &mockBattleSessionService{}, expected: http.StatusOK},
+		{name: "action/invalid-side", method: "POST", path: "/battle-sessions/1/actions", body: `{"side": 3, "type": "attack"}`, mock: &mockBattleSessionService{}, expected: http.StatusBadRequest},
+		{name: "action/invalid-type", method: "POST", path: "/battle-sessions/1/actions", body: `{"side": 2, "type": "run"}`, mock: &mockBattleSessionService{}, expected: http.StatusBadRequest},
+		{name: "action/strategy-side", method: "POST", path: "/battle-sessions/3/actions", body: `{"side": 2, "type": "attack"}`, mock: &mockBattleSessionService{}, expected: http.StatusBadRequest},
+		{name: "action/already-sent", method: "POST", path: "/battle-sessions/1/actions", body: `{"side": 1, "type": "attack"}`, mock: &mockBattleSessionService{}, expected: http.StatusConflict},
+		{name: "action/finished", method: "POST", path: "/battle-sessions/2/actions", body: `{"side": 1, "type": "attack"}`, mock: &mockBattleSessionService{}, expected: http.StatusConflict},
+		{name: "action/error", method: "POST", path: "/battle-sessions/1/actions", body: `{"side": 2, "type": "attack"}`, mock: &mockBattleSessionService{hasError: true}, expected: http.StatusInternalServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+
+			sessionServer := sessionServer{
+				srv:        testCase.mock,
+				pokemonSrv: &mockPokemonService{hasError: false},
+				settings:   fightSettings{diceSides: 6, maxTurns: 100},
+			}
+			sessionRoutes := s.App.Group("/battle-sessions")
+			sessionRoutes.Post("/", sessionServer.CreateBattleSession)
+			sessionRoutes.Get("/", sessionServer.GetAllBattleSessions)
+			sessionRoutes.Get("/:id", sessionServer.GetBattleSessionByID)
+			sessionRoutes.Delete("/:id", sessionServer.DeleteBattleSession)
+			sessionRoutes.Post("/:id/actions", sessionServer.SubmitAction)
+
+			req, err := http.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+
+	t.Run("action/resolves-turn", func(t *testing.T) {
+		s := New()
+
+		mock := &mockBattleSessionService{}
+		sessionServer := sessionServer{
+			srv:        mock,
+			pokemonSrv: &mockPokemonService{hasError: false},
+			settings:   fightSettings{diceSides: 6, maxTurns: 100},
+		}
+		s.App.Post("/battle-sessions/:id/actions", sessionServer.SubmitAction)
+
+		req, err := http.NewRequest("POST", "/battle-sessions/1/actions", bytes.NewBufferString(`{"side": 2, "type": "attack", "move": -1}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status OK; got %v", resp.Status)
+		}
+
+		var session models.BattleSession
+		if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		// the pending action of the first trainer and the new one resolve the first turn
+		if len(session.Turns) != 1 || session.Pending1 != nil || session.Pending2 != nil || len(session.Log) == 0 {
+			t.Errorf("expected the first turn to be resolved; got %+v", session)
+		}
+		if mock.updated == nil || len(mock.updated.Turns) != 1 {
+			t.Errorf("expected the session to be updated; got %+v", mock.updated)
+		}
+	})
+
+	t.Run("action/finishes-battle", func(t *testing.T) {
+		s := New()
+
+		mock := &mockBattleSessionService{}
+		feed := newBattleFeed()
+		sessionServer := sessionServer{
+			srv:        mock,
+			pokemonSrv: &mockPokemonService{hasError: false},
+			settings:   fightSettings{diceSides: 6, maxTurns: 100},
+			feed:       feed,
+		}
+		s.App.Post("/battle-sessions/:id/actions", sessionServer.SubmitAction)
+
+		_, events, unsubscribe := feed.subscribe(0)
+		defer unsubscribe()
+
+		req, err := http.NewRequest("POST", "/battle-sessions/4/actions", bytes.NewBufferString(`{"side": 1, "type": "attack", "move": -1}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status OK; got %v", resp.Status)
+		}
+		if mock.finished == nil || mock.updated == nil || mock.updated.BattleID != mock.finished.ID {
+			t.Fatalf("expected the session to be finished with its battle; got %+v", mock.updated)
+		}
+
+		// the battle of the session is published like the rest of the created battles
+		select {
+		case event := <-events:
+			if event.battle.ID != mock.finished.ID {
+				t.Errorf("expected the battle %d to be published; got %+v", mock.finished.ID, event.battle)
+			}
+		default:
+			t.Error("expected the battle to be published")
+		}
+	})
+}
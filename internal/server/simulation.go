@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+)
+
+// simulationTimeout is the maximum time a simulation can run before it is cancelled
+const simulationTimeout = 30 * time.Second
+
+// simulationServer is used to handle the simulation routes.
+// The simulated battles are never stored, so it only needs the pokemons.
+type simulationServer struct {
+	pokemonSrv database.PokemonCRUDService
+	settings   fightSettings
+	timeout    time.Duration // simulationTimeout if zero
+}
+
+type simulationRequest struct {
+	Pokemon1ID int `json:"pokemon1_id"`
+	Pokemon2ID int `json:"pokemon2_id"`
+
+	// Runs is the number of battles to simulate, business.DefaultSimulationRuns if empty
+	Runs int `json:"runs,omitempty"`
+
+	// with a seed, the battle i of the simulation uses the seed plus i
+	fightRequest
+}
+
+// RunSimulation fights many battles between two pokemon, concurrently and without
+// storing them, and returns the odds of each pokemon and the distribution of turns.
+// The simulation is cancelled with the request context or when it runs for too long.
+func (s *simulationServer) RunSimulation(c *fiber.Ctx) error {
+	timeout := s.timeout
+	if timeout == 0 {
+		timeout = simulationTimeout
+	}
+	ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+	defer cancel()
+
+	var req simulationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if req.Pokemon1ID == req.Pokemon2ID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "pokemon cannot battle itself"})
+	}
+
+	opts, err := req.options(s.settings)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	runs := req.Runs
+	if runs == 0 {
+		runs = business.DefaultSimulationRuns
+	}
+
+	// retrieve the pokemons from the database
+	pokemon1, err := s.pokemonSrv.GetByID(ctx, req.Pokemon1ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	pokemon2, err := s.pokemonSrv.GetByID(ctx, req.Pokemon2ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := business.Simulate(ctx, runs, s.settings.diceSides, pokemon1, pokemon2, opts...)
+	if errors.Is(err, business.ErrInvalidSimulationRuns) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "simulation timed out"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(result)
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"pokemon-battle/internal/models"
+)
+
+func TestRunSimulation(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s := New()
+
+		simulationServer := simulationServer{pokemonSrv: &mockPokemonService{hasError: false}, settings: fightSettings{diceSides: 6}}
+		s.App.Post("/simulations", simulationServer.RunSimulation)
+
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "runs": 100, "seed": 42}`)
+
+		req, err := http.NewRequest("POST", "/simulations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected status OK; got %v", resp.Status)
+		}
+
+		var result models.SimulationResult
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if result.Runs != 100 || result.Seed != 42 {
+			t.Errorf("expected 100 runs with seed 42; got %v runs with seed %v", result.Runs, result.Seed)
+		}
+		if result.Pokemon1Wins+result.Pokemon2Wins+result.Draws != 100 {
+			t.Errorf("expected 100 results; got %+v", result)
+		}
+	})
+
+	t.Run("error/invalid-request", func(t *testing.T) {
+		for _, body := range []string{
+			`{"pokemon1_id": 1, "pokemon2_id": 1}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "runs": -5}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "runs": 1000000}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "dice": "2x6"}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "max_turns": 0}`,
+			`{"pokemon1_id": 1, "pokemon2_id": 2, "max_turns": 1000000}`,
+		} {
+			s := New()
+
+			simulationServer := simulationServer{pokemonSrv: &mockPokemonService{hasError: false}, settings: fightSettings{diceSides: 6}}
+			s.App.Post("/simulations", simulationServer.RunSimulation)
+
+			req, err := http.NewRequest("POST", "/simulations", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected status 400 for %s; got %v", body, resp.Status)
+			}
+		}
+	})
+
+	t.Run("error/timeout", func(t *testing.T) {
+		s := New()
+
+		simulationServer := simulationServer{pokemonSrv: &mockPokemonService{hasError: false}, settings: fightSettings{diceSides: 6}, timeout: time.Nanosecond}
+		s.App.Post("/simulations", simulationServer.RunSimulation)
+
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2, "runs": 100000}`)
+
+		req, err := http.NewRequest("POST", "/simulations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected status 503; got %v", resp.Status)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := New()
+
+		simulationServer := simulationServer{pokemonSrv: &mockPokemonService{hasError: true}, settings: fightSettings{diceSides: 6}}
+		s.App.Post("/simulations", simulationServer.RunSimulation)
+
+		body := []byte(`{"pokemon1_id": 1, "pokemon2_id": 2}`)
+
+		req, err := http.NewRequest("POST", "/simulations", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status 500; got %v", resp.Status)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/models"
+)
+
+// streamBuffer is the number of messages a spectator can fall behind a battle
+// before it is disconnected
+const streamBuffer = 256
+
+// streamMessage is a message of the live stream of a battle: an event of a turn,
+// as soon as it resolves, the battle once it ends and is stored, or an error
+type streamMessage struct {
+	Event  *models.BattleEvent `json:"event,omitempty"`
+	Battle *models.Battle      `json:"battle,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// eventMessages returns the messages of the events of a battle, in the same order
+func eventMessages(events []models.BattleEvent) []streamMessage {
+	messages := make([]streamMessage, 0, len(events))
+	for i := range events {
+		messages = append(messages, streamMessage{Event: &events[i]})
+	}
+	return messages
+}
+
+// battleHub broadcasts the messages of the live battles to their spectators.
+// The battles are identified by the ID of their battle session.
+//
+// All its methods can be called on a nil hub, which has no spectators.
+type battleHub struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan streamMessage]struct{}
+}
+
+func newBattleHub() *battleHub {
+	return &battleHub{
+		subscribers: make(map[int]map[chan streamMessage]struct{}),
+	}
+}
+
+// subscribe returns the channel that receives the messages of a battle and the
+// function that cancels the subscription. The channel is closed when the
+// subscription is cancelled or when the spectator falls too far behind.
+func (h *battleHub) subscribe(id int) (<-chan streamMessage, func()) {
+	messages := make(chan streamMessage, streamBuffer)
+	if h == nil {
+		close(messages)
+		return messages, func() {}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[id] == nil {
+		h.subscribers[id] = make(map[chan streamMessage]struct{})
+	}
+	h.subscribers[id][messages] = struct{}{}
+
+	return messages, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(id, messages)
+	}
+}
+
+// publish sends the messages to the spectators of a battle. It never blocks:
+// the spectators that cannot keep up are disconnected instead.
+func (h *battleHub) publish(id int, messages ...streamMessage) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscriber := range h.subscribers[id] {
+		for _, message := range messages {
+			select {
+			case subscriber <- message:
+				continue
+			default:
+			}
+			h.remove(id, subscriber)
+			break
+		}
+	}
+}
+
+// remove closes the channel of a subscriber, if it is still subscribed.
+// It must be called with the lock held.
+func (h *battleHub) remove(id int, subscriber chan streamMessage) {
+	if _, ok := h.subscribers[id][subscriber]; !ok {
+		return
+	}
+	delete(h.subscribers[id], subscriber)
+	close(subscriber)
+	if len(h.subscribers[id]) == 0 {
+		delete(h.subscribers, id)
+	}
+}
+
+// requireUpgrade rejects the requests to the stream routes that are not a WebSocket upgrade
+func requireUpgrade(c *fiber.Ctx) error {
+	if websocket.IsWebSocketUpgrade(c) {
+		return c.Next()
+	}
+	return c.Status(fiber.StatusUpgradeRequired).JSON(fiber.Map{"error": "WebSocket upgrade required"})
+}
+
+// stream writes the messages to the connection until the battle ends, the
+// subscription is closed or the spectator disconnects
+func stream(c *websocket.Conn, messages <-chan streamMessage) {
+	// the spectators only send control messages, so reading just detects
+	// the disconnection. The handler waits for the reader before returning,
+	// since the connection is released afterwards.
+	conn := c.Conn
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	defer func() {
+		conn.Close()
+		<-done
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case message, ok := <-messages:
+			if !ok {
+				closeStream(c)
+				return
+			}
+			if err := c.WriteJSON(message); err != nil {
+				return
+			}
+			if message.Battle != nil {
+				closeStream(c)
+				return
+			}
+		}
+	}
+}
+
+// closeStream writes the last messages of a stream and closes it normally
+func closeStream(c *websocket.Conn, messages ...streamMessage) {
+	for _, message := range messages {
+		if err := c.WriteJSON(message); err != nil {
+			return
+		}
+	}
+	_ = c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	contribws "github.com/gofiber/contrib/websocket"
+
+	"pokemon-battle/internal/models"
+)
+
+func TestBattleHub(t *testing.T) {
+	t.Run("publish", func(t *testing.T) {
+		hub := newBattleHub()
+
+		messages1, unsubscribe1 := hub.subscribe(1)
+		messages2, unsubscribe2 := hub.subscribe(2)
+		defer unsubscribe2()
+
+		hub.publish(1, streamMessage{Error: "first"}, streamMessage{Error: "second"})
+		if message := <-messages1; message.Error != "first" {
+			t.Errorf("expected the first message; got %+v", message)
+		}
+		if message := <-messages1; message.Error != "second" {
+			t.Errorf("expected the second message; got %+v", message)
+		}
+		if len(messages2) != 0 {
+			t.Errorf("expected no messages for the other battle; got %d", len(messages2))
+		}
+
+		unsubscribe1()
+		if _, ok := <-messages1; ok {
+			t.Error("expected the channel to be closed after unsubscribing")
+		}
+		// unsubscribing twice does nothing
+		unsubscribe1()
+	})
+
+	t.Run("slow-spectator", func(t *testing.T) {
+		hub := newBattleHub()
+
+		messages, unsubscribe := hub.subscribe(1)
+		defer unsubscribe()
+
+		for i := 0; i <= streamBuffer; i++ {
+			hub.publish(1, streamMessage{})
+		}
+		received := 0
+		for range messages {
+			received++
+		}
+		if received != streamBuffer {
+			t.Errorf("expected %d messages before the disconnection; got %d", streamBuffer, received)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var hub *battleHub
+
+		hub.publish(1, streamMessage{})
+		messages, unsubscribe := hub.subscribe(1)
+		defer unsubscribe()
+		if _, ok := <-messages; ok {
+			t.Error("expected the channel of a nil hub to be closed")
+		}
+	})
+}
+
+func TestStreamRoutes(t *testing.T) {
+	s := New()
+
+	battleServer := battleServer{
+		srv:        &mockBattleService{hasError: false},
+		pokemonSrv: &mockPokemonService{hasError: false},
+		diceSides:  6,
+		maxTurns:   100,
+	}
+	sessionServer := sessionServer{
+		srv:        &mockBattleSessionService{},
+		pokemonSrv: &mockPokemonService{hasError: false},
+		settings:   battleServer.settings(),
+		hub:        newBattleHub(),
+	}
+	s.App.Post("/battle-sessions/:id/actions", sessionServer.SubmitAction)
+	streamRoutes := s.App.Group("/ws", requireUpgrade)
+	streamRoutes.Get("/battles", contribws.New(battleServer.StreamBattle))
+	streamRoutes.Get("/battles/:id", contribws.New(battleServer.WatchBattle))
+	streamRoutes.Get("/battle-sessions/:id", contribws.New(sessionServer.WatchBattleSession))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening. Err: %v", err)
+	}
+	go s.App.Listener(ln)
+	defer s.App.Shutdown()
+
+	dial := func(t *testing.T, path string) *websocket.Conn {
+		t.Helper()
+		conn, _, err := websocket.DefaultDialer.Dial("ws://"+ln.Addr().String()+path, nil)
+		if err != nil {
+			t.Fatalf("error dialing %s. Err: %v", path, err)
+		}
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		return conn
+	}
+	read := func(t *testing.T, conn *websocket.Conn) streamMessage {
+		t.Helper()
+		var message streamMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("error reading message. Err: %v", err)
+		}
+		return message
+	}
+
+	t.Run("battles", func(t *testing.T) {
+		conn := dial(t, "/ws/battles")
+		defer conn.Close()
+
+		if err := conn.WriteJSON(map[string]any{"pokemon1_id": 1, "pokemon2_id": 2, "seed": 42}); err != nil {
+			t.Fatalf("error writing request. Err: %v", err)
+		}
+
+		var events []models.BattleEvent
+		message := read(t, conn)
+		for message.Event != nil {
+			events = append(events, *message.Event)
+			message = read(t, conn)
+		}
+		if message.Battle == nil || message.Battle.ID != 1 {
+			t.Fatalf("expected the stored battle at the end; got %+v", message)
+		}
+		if len(events) != len(message.Battle.Log) {
+			t.Errorf("expected the %d events of the log; got %d", len(message.Battle.Log), len(events))
+		}
+	})
+
+	t.Run("battles/invalid-request", func(t *testing.T) {
+		conn := dial(t, "/ws/battles")
+		defer conn.Close()
+
+		if err := conn.WriteJSON(map[string]any{"pokemon1_id": 1, "pokemon2_id": 2, "format": "triples"}); err != nil {
+			t.Fatalf("error writing request. Err: %v", err)
+		}
+		if message := read(t, conn); message.Error == "" {
+			t.Errorf("expected an error; got %+v", message)
+		}
+	})
+
+	t.Run("battles/watch", func(t *testing.T) {
+		conn := dial(t, "/ws/battles/7")
+		defer conn.Close()
+
+		// the mock returns the battle without its log, which is read with GetLog
+		log, _ := battleServer.srv.GetLog(context.Background(), 7)
+		for _, event := range log {
+			if message := read(t, conn); message.Event == nil || *message.Event != event {
+				t.Fatalf("expected the event %+v; got %+v", event, message)
+			}
+		}
+		if message := read(t, conn); message.Battle == nil || message.Battle.ID != 7 || len(message.Battle.Log) != len(log) {
+			t.Errorf("expected the stored battle with its log; got %+v", message)
+		}
+		if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			t.Errorf("expected the stream to be closed; got %v", err)
+		}
+	})
+
+	t.Run("battle-sessions", func(t *testing.T) {
+		// the second side of the session 3 is played by a strategy, so the
+		// action of the first trainer resolves the turn
+		conn := dial(t, "/ws/battle-sessions/3")
+		defer conn.Close()
+
+		// the spectator subscribes after the handshake
+		for subscribed := false; !subscribed; {
+			sessionServer.hub.mu.Lock()
+			subscribed = len(sessionServer.hub.subscribers[3]) > 0
+			sessionServer.hub.mu.Unlock()
+			time.Sleep(time.Millisecond)
+		}
+
+		req, err := http.NewRequest("POST", "/battle-sessions/3/actions", bytes.NewBufferString(`{"side": 1, "type": "attack", "move": -1}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		var session models.BattleSession
+		if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		if len(session.Log) == 0 {
+			t.Fatalf("expected the first turn to be resolved; got %+v", session)
+		}
+		for _, event := range session.Log {
+			message := read(t, conn)
+			if message.Event == nil || *message.Event != event {
+				t.Fatalf("expected the event %+v; got %+v", event, message)
+			}
+		}
+	})
+
+	t.Run("upgrade-required", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/ws/battles", nil)
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusUpgradeRequired {
+			t.Errorf("expected status %d; got %v", http.StatusUpgradeRequired, resp.Status)
+		}
+	})
+}
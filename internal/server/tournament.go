@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/database"
+	"pokemon-battle/internal/models"
+)
+
+// tournamentServer is used to handle the tournament routes.
+// The battles of the matches are stored with the battle service.
+type tournamentServer struct {
+	srv        database.TournamentCRUDService
+	battleSrv  database.BattleCRUDService
+	pokemonSrv database.PokemonCRUDService
+	settings   fightSettings
+
+	// feed broadcasts the battles of the matches to the clients of the battle stream
+	feed *battleFeed
+
+	// mu serializes the rounds, so the same round is never played twice at once
+	mu sync.Mutex
+}
+
+type tournamentRequest struct {
+	Name   string `json:"name"`
+	Format string `json:"format"` // single-elimination, round-robin or swiss
+
+	// PokemonIDs are the pokemon of the tournament, ordered by seed
+	PokemonIDs []int `json:"pokemon_ids"`
+
+	// Rounds is the number of rounds of a swiss tournament, optional
+	Rounds int `json:"rounds,omitempty"`
+}
+
+// CreateTournament creates a tournament and pairs its first round
+func (s *tournamentServer) CreateTournament(c *fiber.Ctx) error {
+	ctx := context.Background()
+	var req tournamentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	tournament, err := business.NewTournament(req.Name, req.Format, req.PokemonIDs, req.Rounds)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	err = s.srv.Create(ctx, &tournament)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusCreated).JSON(tournament)
+}
+
+func (s *tournamentServer) GetAllTournaments(c *fiber.Ctx) error {
+	ctx := context.Background()
+	tournaments, err := s.srv.GetAll(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(tournaments)
+}
+
+func (s *tournamentServer) GetTournamentByID(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	tournament, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(tournament)
+}
+
+func (s *tournamentServer) DeleteTournament(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	err = s.srv.Delete(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetTournamentStandings returns the standings of a tournament with the matches played so far
+func (s *tournamentServer) GetTournamentStandings(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	tournament, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(business.Standings(tournament))
+}
+
+// GetTournamentBracket returns the bracket tree of a single elimination tournament
+func (s *tournamentServer) GetTournamentBracket(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	tournament, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	bracket, err := business.Bracket(tournament)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(bracket)
+}
+
+// PlayTournamentRound fights the matches of the current round of a tournament,
+// stores their battles and pairs the next round. The body is optional and can
+// override the settings of the fights, like in the battles.
+func (s *tournamentServer) PlayTournamentRound(c *fiber.Ctx) error {
+	ctx := context.Background()
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req fightRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+		}
+	}
+
+	opts, err := req.options(s.settings)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournament, err := s.srv.GetByID(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if tournament.Status == models.TournamentFinished {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": business.ErrTournamentFinished.Error()})
+	}
+
+	// retrieve the pokemons from the database, with their moves
+	pokemons := make(map[int]models.Pokemon, len(tournament.PokemonIDs))
+	for _, pokemonID := range tournament.PokemonIDs {
+		pokemon, err := s.pokemonSrv.GetByID(ctx, pokemonID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		pokemons[pokemonID] = pokemon
+	}
+
+	record := func(battle *models.Battle) error {
+		return createBattle(ctx, s.battleSrv, s.feed, battle)
+	}
+	playErr := business.PlayRound(&tournament, pokemons, record, s.settings.diceSides, opts...)
+
+	// the matches stored before an error are kept, so the round can be played again
+	if err := s.srv.Update(ctx, tournament); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if playErr != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": playErr.Error()})
+	}
+	return c.JSON(tournament)
+}
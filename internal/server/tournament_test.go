@@ -0,0 +1,187 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"pokemon-battle/internal/business"
+	"pokemon-battle/internal/models"
+)
+
+// mockTournamentService is used for testing the tournament routes
+// including the ability to return an error so we can test error handling.
+// The tournament 1 is a single elimination in progress, the tournament 2
+// a finished round robin.
+type mockTournamentService struct {
+	hasError bool
+
+	// updated is the last tournament passed to Update
+	updated *models.Tournament
+}
+
+func (m *mockTournamentService) Create(ctx context.Context, tournament *models.Tournament) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	tournament.ID = 1
+	return nil
+}
+
+func (m *mockTournamentService) Delete(ctx context.Context, id int) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+func (m *mockTournamentService) GetAll(ctx context.Context) ([]models.Tournament, error) {
+	if m.hasError {
+		return nil, errors.New("mock error")
+	}
+	tournament, _ := m.GetByID(ctx, 1)
+	return []models.Tournament{tournament}, nil
+}
+
+func (m *mockTournamentService) GetByID(ctx context.Context, id int) (models.Tournament, error) {
+	if m.hasError {
+		return models.Tournament{}, errors.New("mock error")
+	}
+
+	if id == 2 {
+		tournament, _ := business.NewTournament("Liga Johto", models.TournamentRoundRobin, []int{1, 2}, 0)
+		tournament.ID = 2
+		tournament.Status = models.TournamentFinished
+		tournament.WinnerID = 1
+		tournament.Matches[0].Played = true
+		tournament.Matches[0].WinnerID = 1
+		return tournament, nil
+	}
+
+	tournament, _ := business.NewTournament("Copa Kanto", models.TournamentSingleElimination, []int{1, 2, 3, 4}, 0)
+	tournament.ID = id
+	return tournament, nil
+}
+
+func (m *mockTournamentService) Update(ctx context.Context, tournament models.Tournament) error {
+	if m.hasError {
+		return errors.New("mock error")
+	}
+	m.updated = &tournament
+	return nil
+}
+
+func TestTournamentRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     *mockTournamentService
+		expected int
+	}{
+		{name: "create/success", method: "POST", path: "/tournaments", body: `{"name": "Copa Kanto", "format": "swiss", "pokemon_ids": [1, 2, 3, 4]}`, mock: &mockTournamentService{}, expected: http.StatusCreated},
+		{name: "create/invalid-format", method: "POST", path: "/tournaments", body: `{"name": "Copa Kanto", "format": "ladder", "pokemon_ids": [1, 2, 3, 4]}`, mock: &mockTournamentService{}, expected: http.StatusBadRequest},
+		{name: "create/invalid-rounds", method: "POST", path: "/tournaments", body: `{"name": "Copa Kanto", "format": "swiss", "pokemon_ids": [1, 2], "rounds": 3}`, mock: &mockTournamentService{}, expected: http.StatusBadRequest},
+		{name: "create/error", method: "POST", path: "/tournaments", body: `{"name": "Copa Kanto", "format": "swiss", "pokemon_ids": [1, 2, 3, 4]}`, mock: &mockTournamentService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get-all/success", method: "GET", path: "/tournaments", mock: &mockTournamentService{}, expected: http.StatusOK},
+		{name: "get-all/error", method: "GET", path: "/tournaments", mock: &mockTournamentService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "get/success", method: "GET", path: "/tournaments/1", mock: &mockTournamentService{}, expected: http.StatusOK},
+		{name: "get/invalid-id", method: "GET", path: "/tournaments/abc", mock: &mockTournamentService{}, expected: http.StatusBadRequest},
+		{name: "delete/success", method: "DELETE", path: "/tournaments/1", mock: &mockTournamentService{}, expected: http.StatusNoContent},
+		{name: "standings/success", method: "GET", path: "/tournaments/2/standings", mock: &mockTournamentService{}, expected: http.StatusOK},
+		{name: "standings/error", method: "GET", path: "/tournaments/2/standings", mock: &mockTournamentService{hasError: true}, expected: http.StatusInternalServerError},
+		{name: "bracket/success", method: "GET", path: "/tournaments/1/bracket", mock: &mockTournamentService{}, expected: http.StatusOK},
+		{name: "bracket/not-single-elimination", method: "GET", path: "/tournaments/2/bracket", mock: &mockTournamentService{}, expected: http.StatusBadRequest},
+		{name: "rounds/finished", method: "POST", path: "/tournaments/2/rounds", mock: &mockTournamentService{}, expected: http.StatusConflict},
+		{name: "rounds/invalid-request", method: "POST", path: "/tournaments/1/rounds", body: `{"max_turns": 0}`, mock: &mockTournamentService{}, expected: http.StatusBadRequest},
+		{name: "rounds/error", method: "POST", path: "/tournaments/1/rounds", mock: &mockTournamentService{hasError: true}, expected: http.StatusInternalServerError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := New()
+
+			tournamentServer := tournamentServer{
+				srv:        testCase.mock,
+				battleSrv:  &mockBattleService{hasError: false},
+				pokemonSrv: &mockPokemonService{hasError: false},
+				settings:   fightSettings{diceSides: 6, maxTurns: 100},
+			}
+			tournamentRoutes := s.App.Group("/tournaments")
+			tournamentRoutes.Post("/", tournamentServer.CreateTournament)
+			tournamentRoutes.Get("/", tournamentServer.GetAllTournaments)
+			tournamentRoutes.Get("/:id", tournamentServer.GetTournamentByID)
+			tournamentRoutes.Delete("/:id", tournamentServer.DeleteTournament)
+			tournamentRoutes.Get("/:id/standings", tournamentServer.GetTournamentStandings)
+			tournamentRoutes.Get("/:id/bracket", tournamentServer.GetTournamentBracket)
+			tournamentRoutes.Post("/:id/rounds", tournamentServer.PlayTournamentRound)
+
+			req, err := http.NewRequest(testCase.method, testCase.path, bytes.NewBufferString(testCase.body))
+			req.Header.Set("Content-Type", "application/json")
+			if err != nil {
+				t.Fatalf("error creating request. Err: %v", err)
+			}
+
+			resp, err := s.App.Test(req)
+			if err != nil {
+				t.Fatalf("error making request to server. Err: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expected {
+				t.Errorf("expected status %d; got %v", testCase.expected, resp.Status)
+			}
+		})
+	}
+
+	t.Run("rounds/success", func(t *testing.T) {
+		s := New()
+
+		mock := &mockTournamentService{}
+		feed := newBattleFeed()
+		tournamentServer := tournamentServer{
+			srv:        mock,
+			battleSrv:  &mockBattleService{hasError: false},
+			pokemonSrv: &mockPokemonService{hasError: false},
+			settings:   fightSettings{diceSides: 6, maxTurns: 100},
+			feed:       feed,
+		}
+		s.App.Post("/tournaments/:id/rounds", tournamentServer.PlayTournamentRound)
+
+		req, err := http.NewRequest("POST", "/tournaments/1/rounds", bytes.NewBufferString(`{"seed": 42}`))
+		req.Header.Set("Content-Type", "application/json")
+		if err != nil {
+			t.Fatalf("error creating request. Err: %v", err)
+		}
+
+		resp, err := s.App.Test(req)
+		if err != nil {
+			t.Fatalf("error making request to server. Err: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status OK; got %v", resp.Status)
+		}
+
+		var tournament models.Tournament
+		if err := json.NewDecoder(resp.Body).Decode(&tournament); err != nil {
+			t.Fatalf("error decoding response body. Err: %v", err)
+		}
+		// the two semifinals were fought and the final was paired
+		if tournament.CurrentRound != 2 || len(tournament.Matches) != 3 {
+			t.Errorf("expected the final to be paired; got %+v", tournament)
+		}
+		if mock.updated == nil || mock.updated.CurrentRound != 2 {
+			t.Errorf("expected the tournament to be updated; got %+v", mock.updated)
+		}
+		// the battles of the semifinals are published like the rest of the created battles
+		if feed.lastID != 2 {
+			t.Errorf("expected the 2 battles to be published; got %d", feed.lastID)
+		}
+	})
+}